@@ -0,0 +1,205 @@
+package adopt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kyoh86/gogh/v4/app/move"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+var (
+	// ErrNotRepository is returned when the path is not a git repository
+	ErrNotRepository = errors.New("not a git repository")
+
+	// ErrNoRemote is returned when the repository does not have the default remote
+	ErrNoRemote = errors.New("no default remote")
+
+	// ErrConflict is returned when another clone already exists at the layout path
+	ErrConflict = errors.New("conflicts with an existing clone")
+
+	// ErrAlreadyAdopted is returned when the repository is already at the layout path
+	ErrAlreadyAdopted = errors.New("repository is already adopted")
+)
+
+// Usecase defines the use case for adopting existing clones from outside the roots
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	indexService     workspace.IndexService
+	hostingService   hosting.HostingService
+	gitService       git.GitService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	indexService workspace.IndexService,
+	hostingService hosting.HostingService,
+	gitService git.GitService,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		indexService:     indexService,
+		hostingService:   hostingService,
+		gitService:       gitService,
+	}
+}
+
+// Options for adopting a repository
+type Options struct {
+	// Root to adopt the repository into.
+	// If empty, the root is chosen by the routes (or the primary root).
+	Root string
+	// Symlink creates a symbolic link in the root instead of moving the repository
+	Symlink bool
+	// DryRun resolves the destination without moving the repository
+	DryRun bool
+}
+
+// Result is the result of adopting a repository
+type Result struct {
+	// Source is the original path of the repository
+	Source string
+	// Location is the location of the repository in the root
+	Location *repository.Location
+}
+
+// Execute adopts the repository at the path into the layout path of its default remote.
+// It returns ErrConflict if another clone already exists at the layout path.
+func (uc *Usecase) Execute(ctx context.Context, path string, opts Options) (*Result, error) {
+	source, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isRepository(source) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRepository, path)
+	}
+	ref, err := uc.resolve(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := uc.layoutFor(*ref, opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	if !layout.CanHold(*ref) {
+		return nil, fmt.Errorf("the layout of %s cannot hold %s", layout.GetRoot(), ref)
+	}
+	destination := layout.PathFor(*ref)
+	result := &Result{
+		Source:   source,
+		Location: repository.NewLocation(destination, ref.Host(), ref.Owner(), ref.Name()),
+	}
+	if destination == source {
+		return result, ErrAlreadyAdopted
+	}
+	if _, err := os.Lstat(destination); err == nil {
+		return result, fmt.Errorf("%w: %s", ErrConflict, destination)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return nil, fmt.Errorf("creating parent directory: %w", err)
+	}
+	if opts.Symlink {
+		if err := os.Symlink(source, destination); err != nil {
+			return nil, fmt.Errorf("linking %s to %s: %w", destination, source, err)
+		}
+	} else if err := move.MoveDir(source, destination); err != nil {
+		return nil, fmt.Errorf("moving %s to %s: %w", source, destination, err)
+	}
+	uc.indexService.Add(result.Location)
+	return result, nil
+}
+
+// resolve reads the default remote of the repository and converts it to a reference
+func (uc *Usecase) resolve(ctx context.Context, localPath string) (*repository.Reference, error) {
+	remotes, err := uc.gitService.GetDefaultRemotes(ctx, localPath)
+	if err != nil {
+		return nil, fmt.Errorf("getting default remotes: %w", err)
+	}
+	if len(remotes) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoRemote, localPath)
+	}
+	u, err := url.Parse(git.ToHTTPSURL(remotes[0]))
+	if err != nil {
+		return nil, fmt.Errorf("parsing remote URL %q: %w", remotes[0], err)
+	}
+	ref, err := uc.hostingService.ParseURL(u)
+	if err != nil {
+		return nil, fmt.Errorf("resolving remote URL %q: %w", remotes[0], err)
+	}
+	return ref, nil
+}
+
+// layoutFor returns the layout of the root to adopt the repository into
+func (uc *Usecase) layoutFor(ref repository.Reference, root string) (workspace.LayoutService, error) {
+	if root == "" {
+		return uc.workspaceService.GetLayoutForReference(ref), nil
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(uc.workspaceService.GetRoots(), abs) {
+		return nil, fmt.Errorf("%w: %s", workspace.ErrRootNotFound, root)
+	}
+	return uc.workspaceService.GetLayoutFor(abs), nil
+}
+
+// Scan discovers the git repositories under the directory recursively.
+// It does not look into the repositories and the roots.
+func (uc *Usecase) Scan(ctx context.Context, dir string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		roots := uc.workspaceService.GetRoots()
+		if err := filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			if path != abs && slices.Contains(roots, path) {
+				return filepath.SkipDir
+			}
+			if !isRepository(path) {
+				return nil
+			}
+			if !yield(path, nil) {
+				return filepath.SkipAll
+			}
+			return filepath.SkipDir
+		}); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// isRepository returns whether the directory is a git repository (it has ".git")
+func isRepository(path string) bool {
+	_, err := os.Lstat(filepath.Join(path, ".git"))
+	return err == nil
+}
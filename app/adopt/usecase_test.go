@@ -0,0 +1,213 @@
+package adopt_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/adopt"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func mkRepository(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(path, ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+}
+
+func setup(t *testing.T, root string, remote string) (*testtarget.Usecase, workspace.IndexService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockLayout := workspace_mock.NewMockLayoutService(ctrl)
+	mockHosting := hosting_mock.NewMockHostingService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockWorkspace.EXPECT().GetRoots().Return([]workspace.Root{root}).AnyTimes()
+	mockWorkspace.EXPECT().GetLayoutForReference(gomock.Any()).Return(mockLayout).AnyTimes()
+	mockWorkspace.EXPECT().GetLayoutFor(root).Return(mockLayout).AnyTimes()
+	mockLayout.EXPECT().GetRoot().Return(root).AnyTimes()
+	// The layout of the root can hold only the repositories on github.com
+	mockLayout.EXPECT().CanHold(gomock.Any()).DoAndReturn(func(ref repository.Reference) bool {
+		return ref.Host() == "github.com"
+	}).AnyTimes()
+	mockLayout.EXPECT().PathFor(gomock.Any()).DoAndReturn(func(ref repository.Reference) string {
+		return filepath.Join(root, ref.Host(), ref.Owner(), ref.Name())
+	}).AnyTimes()
+	mockGit.EXPECT().GetDefaultRemotes(gomock.Any(), gomock.Any()).Return([]string{remote}, nil).AnyTimes()
+	mockHosting.EXPECT().ParseURL(gomock.Any()).DoAndReturn(func(u *url.URL) (*repository.Reference, error) {
+		ref := repository.NewReference(u.Host, "kyoh86", "gogh")
+		return &ref, nil
+	}).AnyTimes()
+
+	index := workspace.NewIndexService()
+	index.Reset(nil)
+	return testtarget.NewUsecase(mockWorkspace, index, mockHosting, mockGit), index
+}
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("move into the layout path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(tmpDir, "src", "gogh")
+		mkRepository(t, source)
+		uc, index := setup(t, root, "git@github.com:kyoh86/gogh.git")
+
+		result, err := uc.Execute(ctx, source, testtarget.Options{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := filepath.Join(root, "github.com", "kyoh86", "gogh")
+		if result.Location.FullPath() != want {
+			t.Errorf("expected %s, got %s", want, result.Location.FullPath())
+		}
+		if _, err := os.Stat(filepath.Join(want, ".git")); err != nil {
+			t.Errorf("repository should be moved: %v", err)
+		}
+		if _, err := os.Stat(source); !os.IsNotExist(err) {
+			t.Errorf("source should be removed: %v", err)
+		}
+		if _, ok := index.Get(want); !ok {
+			t.Error("adopted repository should be indexed")
+		}
+	})
+
+	t.Run("symlink into the layout path", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(tmpDir, "src", "gogh")
+		mkRepository(t, source)
+		uc, _ := setup(t, root, "https://github.com/kyoh86/gogh")
+
+		result, err := uc.Execute(ctx, source, testtarget.Options{Symlink: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		link, err := os.Readlink(result.Location.FullPath())
+		if err != nil {
+			t.Fatalf("destination should be a link: %v", err)
+		}
+		if link != source {
+			t.Errorf("expected link to %s, got %s", source, link)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(tmpDir, "src", "gogh")
+		mkRepository(t, source)
+		uc, _ := setup(t, root, "https://github.com/kyoh86/gogh")
+
+		result, err := uc.Execute(ctx, source, testtarget.Options{DryRun: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Stat(result.Location.FullPath()); !os.IsNotExist(err) {
+			t.Errorf("destination should not be created: %v", err)
+		}
+	})
+
+	t.Run("conflict with an existing clone", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(tmpDir, "src", "gogh")
+		mkRepository(t, source)
+		mkRepository(t, filepath.Join(root, "github.com", "kyoh86", "gogh"))
+		uc, _ := setup(t, root, "https://github.com/kyoh86/gogh")
+
+		if _, err := uc.Execute(ctx, source, testtarget.Options{}); !errors.Is(err, testtarget.ErrConflict) {
+			t.Errorf("expected ErrConflict, got %v", err)
+		}
+		if _, err := os.Stat(source); err != nil {
+			t.Errorf("source should be kept: %v", err)
+		}
+	})
+
+	t.Run("already adopted", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(root, "github.com", "kyoh86", "gogh")
+		mkRepository(t, source)
+		uc, _ := setup(t, root, "https://github.com/kyoh86/gogh")
+
+		if _, err := uc.Execute(ctx, source, testtarget.Options{}); !errors.Is(err, testtarget.ErrAlreadyAdopted) {
+			t.Errorf("expected ErrAlreadyAdopted, got %v", err)
+		}
+	})
+
+	t.Run("layout cannot hold", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		root := filepath.Join(tmpDir, "root")
+		source := filepath.Join(tmpDir, "src", "gogh")
+		mkRepository(t, source)
+		uc, _ := setup(t, root, "https://gitlab.com/kyoh86/gogh")
+
+		if _, err := uc.Execute(ctx, source, testtarget.Options{}); err == nil {
+			t.Error("expected an error for the repository which the layout cannot hold")
+		}
+		if _, err := os.Stat(filepath.Join(root, "gitlab.com", "kyoh86", "gogh")); !os.IsNotExist(err) {
+			t.Errorf("destination should not be created: %v", err)
+		}
+		if _, err := os.Stat(source); err != nil {
+			t.Errorf("source should be kept: %v", err)
+		}
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		uc, _ := setup(t, filepath.Join(tmpDir, "root"), "https://github.com/kyoh86/gogh")
+
+		if _, err := uc.Execute(ctx, tmpDir, testtarget.Options{}); !errors.Is(err, testtarget.ErrNotRepository) {
+			t.Errorf("expected ErrNotRepository, got %v", err)
+		}
+	})
+}
+
+func TestUsecase_Scan(t *testing.T) {
+	tmpDir := t.TempDir()
+	root := filepath.Join(tmpDir, "src", "root")
+	for _, path := range []string{
+		filepath.Join(tmpDir, "src", "a"),
+		filepath.Join(tmpDir, "src", "a", "nested"),
+		filepath.Join(tmpDir, "src", "group", "b"),
+		filepath.Join(root, "github.com", "kyoh86", "gogh"),
+	} {
+		mkRepository(t, path)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "src", "empty"), 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	uc, _ := setup(t, root, "https://github.com/kyoh86/gogh")
+
+	var found []string
+	for path, err := range uc.Scan(context.Background(), filepath.Join(tmpDir, "src")) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		found = append(found, path)
+	}
+	want := []string{
+		filepath.Join(tmpDir, "src", "a"),
+		filepath.Join(tmpDir, "src", "group", "b"),
+	}
+	if len(found) != len(want) {
+		t.Fatalf("expected %v, got %v", want, found)
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("expected %s, got %s", want[i], found[i])
+		}
+	}
+}
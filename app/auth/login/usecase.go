@@ -40,6 +40,22 @@ func (uc *Usecase) Execute(ctx context.Context, host string, verify Verify) erro
 	if err != nil {
 		return fmt.Errorf("authenticating: %w", err)
 	}
+	return uc.save(host, user, token)
+}
+
+// ExecuteWithToken performs the authentication with an access token issued by the host.
+func (uc *Usecase) ExecuteWithToken(ctx context.Context, host string, accessToken string) error {
+	if accessToken == "" {
+		return fmt.Errorf("access token is empty")
+	}
+	user, token, err := uc.authService.AuthenticateWithToken(ctx, host, accessToken)
+	if err != nil {
+		return fmt.Errorf("authenticating: %w", err)
+	}
+	return uc.save(host, user, token)
+}
+
+func (uc *Usecase) save(host, user string, token *auth.Token) error {
 	if token == nil {
 		return fmt.Errorf("token is nil")
 	}
@@ -96,3 +96,77 @@ func TestUsecase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_ExecuteWithToken(t *testing.T) {
+	type mocks struct {
+		tokenService   *auth_mock.MockTokenService
+		authService    *auth_mock.MockAuthenticateService
+		hostingService *hosting_mock.MockHostingService
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(m mocks)
+		host        string
+		accessToken string
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name: "success",
+			setupMock: func(m mocks) {
+				token := oauth2.Token{AccessToken: "pat"}
+				m.authService.EXPECT().AuthenticateWithToken(gomock.Any(), "gitea.example.com", "pat").Return("test-user", &token, nil)
+				m.tokenService.EXPECT().Set("gitea.example.com", "test-user", token).Return(nil)
+			},
+			host:        "gitea.example.com",
+			accessToken: "pat",
+			wantErr:     false,
+		},
+		{
+			name:        "empty token",
+			host:        "gitea.example.com",
+			accessToken: "",
+			wantErr:     true,
+			errMsg:      "access token is empty",
+		},
+		{
+			name: "invalid token",
+			setupMock: func(m mocks) {
+				m.authService.EXPECT().AuthenticateWithToken(gomock.Any(), "gitea.example.com", "pat").Return("", nil, errors.New("401 Unauthorized"))
+			},
+			host:        "gitea.example.com",
+			accessToken: "pat",
+			wantErr:     true,
+			errMsg:      "authenticating: 401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				tokenService:   auth_mock.NewMockTokenService(ctrl),
+				authService:    auth_mock.NewMockAuthenticateService(ctrl),
+				hostingService: hosting_mock.NewMockHostingService(ctrl),
+			}
+			if tt.setupMock != nil {
+				tt.setupMock(m)
+			}
+
+			uc := testtarget.NewUsecase(m.tokenService, m.authService, m.hostingService)
+			err := uc.ExecuteWithToken(context.Background(), tt.host, tt.accessToken)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Usecase.ExecuteWithToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && err != nil && err.Error() != tt.errMsg {
+				t.Errorf("Usecase.ExecuteWithToken() error message = %v, want %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package expand
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/typ"
+)
+
+// Usecase expands a doublestar pattern of remote repositories into their references
+type Usecase struct {
+	hostingService     hosting.HostingService
+	defaultNameService repository.DefaultNameService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	hostingService hosting.HostingService,
+	defaultNameService repository.DefaultNameService,
+) *Usecase {
+	return &Usecase{
+		hostingService:     hostingService,
+		defaultNameService: defaultNameService,
+	}
+}
+
+// Options contains the filters for the expanded repositories, accepting the same values as the repos.Options.
+// Empty values do not filter the repositories.
+type Options struct {
+	// Fork is "forked" or "not-forked"
+	Fork string
+	// Archive is "archived" or "not-archived"
+	Archive string
+}
+
+func convertOpts(opts Options) (hosting.ListRepositoryOptions, error) {
+	listOpts := hosting.ListRepositoryOptions{
+		OwnerAffiliations: []hosting.RepositoryAffiliation{
+			hosting.RepositoryAffiliationOwner,
+			hosting.RepositoryAffiliationOrganizationMember,
+			hosting.RepositoryAffiliationCollaborator,
+		},
+	}
+	if err := typ.Remap(&listOpts.IsFork, map[string]typ.Tristate{
+		"forked":     typ.TristateTrue,
+		"not-forked": typ.TristateFalse,
+	}, opts.Fork); err != nil {
+		return listOpts, fmt.Errorf("invalid fork option %q", opts.Fork)
+	}
+	if err := typ.Remap(&listOpts.IsArchived, map[string]typ.Tristate{
+		"archived":     typ.TristateTrue,
+		"not-archived": typ.TristateFalse,
+	}, opts.Archive); err != nil {
+		return listOpts, fmt.Errorf("invalid archive option %q", opts.Archive)
+	}
+	return listOpts, nil
+}
+
+// IsPattern reports whether the string contains any meta characters of the doublestar pattern
+func IsPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[{")
+}
+
+// resolvePattern completes the host and the owner of the pattern "[[<host>/]<owner>/]<name>"
+// (or "<alias>:[<owner>/]<name>") like the ReferenceParser does.
+// The host cannot be a pattern because it is used to list the repositories.
+func (uc *Usecase) resolvePattern(pattern string) (host, owner, full string, err error) {
+	var name string
+	if alias, rest, ok := strings.Cut(pattern, ":"); ok && repository.ValidateHostAlias(alias) == nil {
+		resolved, ok := uc.defaultNameService.ResolveHostAlias(alias)
+		if !ok {
+			return "", "", "", fmt.Errorf("unknown host alias %q", alias)
+		}
+		host = resolved
+		if i := strings.LastIndex(rest, "/"); i >= 0 {
+			owner, name = rest[:i], rest[i+1:]
+		} else {
+			name = rest
+		}
+	} else {
+		switch parts := strings.Split(pattern, "/"); len(parts) {
+		case 1:
+			host, name = uc.defaultNameService.GetDefaultHost(), parts[0]
+		case 2:
+			host, owner, name = uc.defaultNameService.GetDefaultHost(), parts[0], parts[1]
+		default:
+			host, owner, name = parts[0], strings.Join(parts[1:len(parts)-1], "/"), parts[len(parts)-1]
+		}
+	}
+	if IsPattern(host) {
+		return "", "", "", fmt.Errorf("host cannot be a pattern: %q", host)
+	}
+	if err := repository.ValidateHost(host); err != nil {
+		return "", "", "", err
+	}
+	if owner == "" {
+		owner, err = uc.defaultNameService.GetDefaultOwnerFor(host)
+		if err != nil {
+			return "", "", "", err
+		}
+		if owner == "" {
+			return "", "", "", fmt.Errorf("%w for %s", repository.ErrEmptyOwner, host)
+		}
+	}
+	full = host + "/" + owner + "/" + name
+	if !doublestar.ValidatePattern(full) {
+		return "", "", "", fmt.Errorf("invalid pattern %q: %w", pattern, doublestar.ErrBadPattern)
+	}
+	return host, owner, full, nil
+}
+
+// Execute lists the remote repositories on the host of the pattern,
+// and yields the references matching the pattern "<host>/<owner>/<name>".
+// If the owner is not a pattern, the repositories owned by the owner are listed.
+// Otherwise, only the repositories which the user is affiliated with are listed.
+func (uc *Usecase) Execute(ctx context.Context, pattern string, opts Options) iter.Seq2[*repository.Reference, error] {
+	return func(yield func(*repository.Reference, error) bool) {
+		listOpts, err := convertOpts(opts)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		host, owner, full, err := uc.resolvePattern(pattern)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		listOpts.Host = host
+		if !IsPattern(owner) {
+			listOpts.Owner = owner
+		}
+		for repo, err := range uc.hostingService.ListRepository(ctx, listOpts) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if repo == nil {
+				continue
+			}
+			if match, _ := doublestar.Match(full, repo.Ref.String()); !match {
+				continue
+			}
+			if !yield(&repo.Ref, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package expand_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/clone/expand"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/typ"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIsPattern(t *testing.T) {
+	for s, want := range map[string]bool{
+		"kyoh86/gogh":         false,
+		"our-org/svc-*":       true,
+		"our-org/**":          true,
+		"kyoh86/gogh?":        true,
+		"kyoh86/{gogh,dotfs}": true,
+		"kyoh86/[a-z]*":       true,
+	} {
+		if got := testtarget.IsPattern(s); got != want {
+			t.Errorf("IsPattern(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestUsecase_Execute(t *testing.T) {
+	remotes := []*hosting.Repository{
+		{Ref: repository.NewReference("github.com", "our-org", "svc-api")},
+		{Ref: repository.NewReference("github.com", "our-org", "svc-web")},
+		{Ref: repository.NewReference("github.com", "our-org", "docs")},
+		{Ref: repository.NewReference("github.com", "kyoh86", "svc-tool")},
+		{Ref: repository.NewReference("github.com", "kyoh86", "gogh")},
+	}
+	listAll := func(yield func(*hosting.Repository, error) bool) {
+		for _, repo := range remotes {
+			if !yield(repo, nil) {
+				return
+			}
+		}
+	}
+	defaultNames := repository.NewDefaultNameService()
+	if err := defaultNames.SetDefaultOwnerFor("github.com", "kyoh86"); err != nil {
+		t.Fatalf("failed to set default owner: %v", err)
+	}
+	if err := defaultNames.SetHostAlias("gh", "github.com"); err != nil {
+		t.Fatalf("failed to set host alias: %v", err)
+	}
+
+	for _, testcase := range []struct {
+		pattern string
+		owner   string
+		want    []string
+	}{
+		{pattern: "our-org/svc-*", owner: "our-org", want: []string{"github.com/our-org/svc-api", "github.com/our-org/svc-web"}},
+		{pattern: "github.com/*/svc-*", want: []string{"github.com/our-org/svc-api", "github.com/our-org/svc-web", "github.com/kyoh86/svc-tool"}},
+		{pattern: "svc-*", owner: "kyoh86", want: []string{"github.com/kyoh86/svc-tool"}},
+		{pattern: "gh:our-org/{docs,svc-web}", owner: "our-org", want: []string{"github.com/our-org/svc-web", "github.com/our-org/docs"}},
+		{pattern: "gh:g*", owner: "kyoh86", want: []string{"github.com/kyoh86/gogh"}},
+		{pattern: "gh:{kyoh86,our-org}/svc-*", want: []string{"github.com/our-org/svc-api", "github.com/our-org/svc-web", "github.com/kyoh86/svc-tool"}},
+		{pattern: "nothing-*", owner: "kyoh86", want: nil},
+	} {
+		t.Run(testcase.pattern, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockHosting := hosting_mock.NewMockHostingService(ctrl)
+			mockHosting.EXPECT().
+				ListRepository(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, opts hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+					if opts.Host != "github.com" {
+						t.Errorf("expected host github.com, got %q", opts.Host)
+					}
+					if opts.Owner != testcase.owner {
+						t.Errorf("expected owner %q, got %q", testcase.owner, opts.Owner)
+					}
+					if opts.IsFork != typ.TristateFalse || opts.IsArchived != typ.TristateFalse {
+						t.Errorf("expected the filters to be passed, got %+v", opts)
+					}
+					return listAll
+				})
+
+			uc := testtarget.NewUsecase(mockHosting, defaultNames)
+			var got []string
+			for ref, err := range uc.Execute(context.Background(), testcase.pattern, testtarget.Options{
+				Fork:    "not-forked",
+				Archive: "not-archived",
+			}) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, ref.String())
+			}
+			if !slices.Equal(got, testcase.want) {
+				t.Errorf("expected %v, got %v", testcase.want, got)
+			}
+		})
+	}
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := testtarget.NewUsecase(hosting_mock.NewMockHostingService(ctrl), defaultNames)
+		for _, pattern := range []string{
+			"*.com/our-org/svc-*",
+			"our-org/svc-[",
+			"unknown:our-org/svc-*",
+		} {
+			for _, err := range uc.Execute(context.Background(), pattern, testtarget.Options{}) {
+				if err == nil {
+					t.Errorf("expected an error for %q", pattern)
+				}
+			}
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		for _, testcase := range []struct {
+			opts       testtarget.Options
+			isFork     typ.Tristate
+			isArchived typ.Tristate
+		}{
+			{opts: testtarget.Options{}, isFork: typ.TristateZero, isArchived: typ.TristateZero},
+			{opts: testtarget.Options{Fork: "forked", Archive: "archived"}, isFork: typ.TristateTrue, isArchived: typ.TristateTrue},
+			{opts: testtarget.Options{Fork: "not-forked", Archive: "not-archived"}, isFork: typ.TristateFalse, isArchived: typ.TristateFalse},
+		} {
+			ctrl := gomock.NewController(t)
+			mockHosting := hosting_mock.NewMockHostingService(ctrl)
+			mockHosting.EXPECT().
+				ListRepository(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, opts hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+					if opts.IsFork != testcase.isFork || opts.IsArchived != testcase.isArchived {
+						t.Errorf("unexpected filters for %+v: %+v", testcase.opts, opts)
+					}
+					return listAll
+				})
+			uc := testtarget.NewUsecase(mockHosting, defaultNames)
+			for _, err := range uc.Execute(context.Background(), "our-org/*", testcase.opts) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			ctrl.Finish()
+		}
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		uc := testtarget.NewUsecase(hosting_mock.NewMockHostingService(ctrl), defaultNames)
+		for _, err := range uc.Execute(context.Background(), "our-org/*", testtarget.Options{Fork: "spoon"}) {
+			if err == nil {
+				t.Error("expected an error for an invalid fork option")
+			}
+		}
+	})
+
+	t.Run("listing error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		listErr := errors.New("listing error")
+		mockHosting := hosting_mock.NewMockHostingService(ctrl)
+		mockHosting.EXPECT().
+			ListRepository(gomock.Any(), gomock.Any()).
+			Return(func(yield func(*hosting.Repository, error) bool) {
+				yield(nil, listErr)
+			})
+
+		uc := testtarget.NewUsecase(mockHosting, defaultNames)
+		for _, err := range uc.Execute(context.Background(), "our-org/*", testtarget.Options{}) {
+			if !errors.Is(err, listErr) {
+				t.Errorf("expected the listing error, got %v", err)
+			}
+		}
+	})
+}
@@ -16,7 +16,9 @@ import (
 // Usecase provides common operations for repository manipulation
 type Usecase struct {
 	hostingService   hosting.HostingService
+	providerService  hosting.ProviderService
 	workspaceService workspace.WorkspaceService
+	indexService     workspace.IndexService
 	overlayService   overlay.OverlayService
 	gitService       git.GitService
 }
@@ -24,13 +26,17 @@ type Usecase struct {
 // NewUsecase creates a new instance of RepositoryService.
 func NewUsecase(
 	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
 	workspaceService workspace.WorkspaceService,
+	indexService workspace.IndexService,
 	overlayService overlay.OverlayService,
 	gitService git.GitService,
 ) *Usecase {
 	return &Usecase{
 		hostingService:   hostingService,
+		providerService:  providerService,
 		workspaceService: workspaceService,
+		indexService:     indexService,
 		overlayService:   overlayService,
 		gitService:       gitService,
 	}
@@ -65,11 +71,16 @@ func RetryLimit(limit int, notify Notify) Notify {
 	}
 }
 
+// CloneOptions contains options for the local clone operation
+type CloneOptions = git.CloneOptions
+
 type Options struct {
 	// Notify is a callback function to notify the status of the operation.
 	Notify Notify
 	// Timeout is the maximum wait time for each clone attempt.
 	Timeout time.Duration
+	// CloneOptions is options for the local clone operation (e.g. shallow clone).
+	CloneOptions CloneOptions
 }
 
 // Execute attempts to clone a repository with retry logic.
@@ -84,47 +95,70 @@ func (uc *Usecase) Execute(
 	if alias != nil {
 		targetRef = *alias
 	}
-	layout := uc.workspaceService.GetPrimaryLayout()
+	layout := uc.workspaceService.GetLayoutForReference(targetRef)
+	if !layout.CanHold(targetRef) {
+		return fmt.Errorf("the layout of %s cannot hold %s", layout.GetRoot(), targetRef)
+	}
 	localPath := layout.PathFor(targetRef)
 
-	// Get the user and token for authentication
-	user, token, err := uc.hostingService.GetTokenFor(ctx, repo.Ref.Host(), repo.Ref.Owner())
-	if err != nil {
-		return err
-	}
-	gitService, err := uc.gitService.AuthenticateWithUsernamePassword(ctx, user, token.AccessToken)
+	// Use the URL in the form of the protocol preferred for the host
+	provider := uc.providerService.Resolve(repo.Ref.Host())
+	cloneURL := provider.RemoteURLOf(repo.CloneURL)
+
+	gitService, err := uc.authenticate(ctx, repo.Ref, provider, cloneURL)
 	if err != nil {
 		return err
 	}
 
 	// Perform git clone operation
-	if err := cloneWithRetry(ctx, gitService, layout, repo.Ref, repo.CloneURL, localPath, opts.Timeout, opts.Notify); err != nil {
+	if err := cloneWithRetry(ctx, gitService, layout, repo.Ref, cloneURL, localPath, opts); err != nil {
 		return fmt.Errorf("cloning: %w", err)
 	}
 
+	uc.indexService.Add(repository.NewLocation(localPath, targetRef.Host(), targetRef.Owner(), targetRef.Name()))
+
 	// Set up remotes
-	if err := gitService.SetDefaultRemotes(ctx, localPath, []string{repo.CloneURL}); err != nil {
+	if err := gitService.SetDefaultRemotes(ctx, localPath, []string{cloneURL}); err != nil {
 		return fmt.Errorf("setting default remote: %w", err)
 	}
 
 	// Set up additional remotes if needed
 	if repo.Parent != nil {
-		if err = gitService.SetRemotes(ctx, localPath, "upstream", []string{repo.Parent.CloneURL}); err != nil {
+		parentURL := uc.providerService.Resolve(repo.Parent.Ref.Host()).RemoteURLOf(repo.Parent.CloneURL)
+		if err = gitService.SetRemotes(ctx, localPath, "upstream", []string{parentURL}); err != nil {
 			return fmt.Errorf("setting upstream remote: %w", err)
 		}
 	}
 	return nil
 }
 
+// authenticate prepares the git service to access the remote:
+// SSH remotes use the SSH keys, and others use the token for the owner.
+func (uc *Usecase) authenticate(ctx context.Context, ref repository.Reference, provider hosting.Provider, remoteURL string) (git.GitService, error) {
+	if git.IsSSHURL(remoteURL) {
+		opts := git.SSHAuthOptions{User: git.SSHUserOf(remoteURL)}
+		if provider.SSHKeyFile != "" {
+			opts.KeyFiles = []string{provider.SSHKeyFile}
+		}
+		return uc.gitService.AuthenticateWithSSH(ctx, opts)
+	}
+	user, token, err := uc.hostingService.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, err
+	}
+	return uc.gitService.AuthenticateWithUsernamePassword(ctx, user, token.AccessToken)
+}
+
 func cloneWithRetry(
 	ctx context.Context,
 	gitService git.GitService,
 	layout workspace.LayoutService,
 	ref repository.Reference,
 	cloneURL, localPath string,
-	timeout time.Duration,
-	notify Notify,
+	opts Options,
 ) (err error) {
+	notify := opts.Notify
+	timeout := opts.Timeout
 	if notify == nil {
 		notify = func(n Status) error { return nil }
 	}
@@ -133,7 +167,7 @@ func cloneWithRetry(
 	}
 	for {
 		toctx, tocancel := context.WithTimeout(ctx, timeout)
-		err = gitService.Clone(toctx, cloneURL, localPath, git.CloneOptions{})
+		err = gitService.Clone(toctx, cloneURL, localPath, opts.CloneOptions)
 		tocancel()
 		switch {
 		case errors.Is(err, git.ErrRepositoryNotExists), errors.Is(err, context.DeadlineExceeded):
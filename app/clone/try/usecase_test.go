@@ -14,6 +14,7 @@ import (
 	"github.com/kyoh86/gogh/v4/core/hosting_mock"
 	"github.com/kyoh86/gogh/v4/core/overlay_mock"
 	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/core/workspace_mock"
 	"go.uber.org/mock/gomock"
 )
@@ -27,7 +28,8 @@ func TestNewUsecase(t *testing.T) {
 	overlayService := overlay_mock.NewMockOverlayService(ctrl)
 	gitService := git_mock.NewMockGitService(ctrl)
 
-	svc := try.NewUsecase(hostingService, workspaceService, overlayService, gitService)
+	providerService := hosting_mock.NewMockProviderService(ctrl)
+	svc := try.NewUsecase(hostingService, providerService, workspaceService, workspace.NewIndexService(), overlayService, gitService)
 	if svc == nil {
 		t.Fatal("NewRepositoryService returned nil")
 	}
@@ -132,7 +134,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication
@@ -162,7 +165,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication error
@@ -186,7 +190,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication
@@ -214,7 +219,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication
@@ -246,7 +252,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication
@@ -287,7 +294,8 @@ func TestTryClone(t *testing.T) {
 				localPath := "/path/to/repo"
 
 				// Layout setup
-				mws.EXPECT().GetPrimaryLayout().Return(mls)
+				mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+				mls.EXPECT().CanHold(ref).Return(true)
 				mls.EXPECT().PathFor(ref).Return(localPath)
 
 				// Authentication
@@ -316,7 +324,10 @@ func TestTryClone(t *testing.T) {
 
 			mhs, mws, mgs, mos := tc.setupMocks(ctrl)
 
-			svc := try.NewUsecase(mhs, mws, mgs, mos)
+			mps := hosting_mock.NewMockProviderService(ctrl)
+			mps.EXPECT().Resolve(gomock.Any()).DoAndReturn(hosting.GuessProvider).AnyTimes()
+
+			svc := try.NewUsecase(mhs, mps, mws, workspace.NewIndexService(), mgs, mos)
 
 			repo := &hosting.Repository{
 				Ref:      repository.NewReference("github.com", "user", "repo"),
@@ -370,3 +381,117 @@ func TestTryClone(t *testing.T) {
 func containsString(s, substr string) bool {
 	return s != "" && substr != "" && s != substr && len(s) > len(substr) && s[len(s)-len(substr):] == substr
 }
+
+func TestTryCloneWithSSHAndOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mhs := hosting_mock.NewMockHostingService(ctrl)
+	mps := hosting_mock.NewMockProviderService(ctrl)
+	mws := workspace_mock.NewMockWorkspaceService(ctrl)
+	mls := workspace_mock.NewMockLayoutService(ctrl)
+	mos := overlay_mock.NewMockOverlayService(ctrl)
+	mgs := git_mock.NewMockGitService(ctrl)
+
+	ref := repository.NewReference("github.com", "user", "repo")
+	repo := &hosting.Repository{
+		Ref:      ref,
+		CloneURL: "https://github.com/user/repo.git",
+		Parent: &hosting.ParentRepository{
+			Ref:      repository.NewReference("github.com", "original", "repo"),
+			CloneURL: "https://github.com/original/repo.git",
+		},
+	}
+	localPath := "/path/to/repo"
+
+	mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+	mls.EXPECT().CanHold(ref).Return(true)
+	mls.EXPECT().PathFor(ref).Return(localPath)
+	mps.EXPECT().Resolve("github.com").Return(hosting.Provider{
+		Host:       "github.com",
+		Kind:       hosting.ProviderKindGitHub,
+		Protocol:   hosting.ProtocolSSH,
+		SSHKeyFile: "/home/user/.ssh/id_ed25519",
+	}).AnyTimes()
+
+	// SSH remotes do not use the token
+	mhs.EXPECT().GetTokenFor(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mgs.EXPECT().AuthenticateWithSSH(gomock.Any(), git.SSHAuthOptions{
+		User:     "git",
+		KeyFiles: []string{"/home/user/.ssh/id_ed25519"},
+	}).Return(mgs, nil)
+	mgs.EXPECT().Clone(gomock.Any(), "git@github.com:user/repo.git", localPath, git.CloneOptions{Depth: 1, SingleBranch: true}).Return(nil)
+	mgs.EXPECT().SetDefaultRemotes(gomock.Any(), localPath, []string{"git@github.com:user/repo.git"}).Return(nil)
+	mgs.EXPECT().SetRemotes(gomock.Any(), localPath, "upstream", []string{"git@github.com:original/repo.git"}).Return(nil)
+
+	svc := try.NewUsecase(mhs, mps, mws, workspace.NewIndexService(), mos, mgs)
+	if err := svc.Execute(context.Background(), repo, nil, try.Options{
+		CloneOptions: try.CloneOptions{Depth: 1, SingleBranch: true},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTryCloneRoutesByAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mhs := hosting_mock.NewMockHostingService(ctrl)
+	mps := hosting_mock.NewMockProviderService(ctrl)
+	mws := workspace_mock.NewMockWorkspaceService(ctrl)
+	mls := workspace_mock.NewMockLayoutService(ctrl)
+	mos := overlay_mock.NewMockOverlayService(ctrl)
+	mgs := git_mock.NewMockGitService(ctrl)
+
+	ref := repository.NewReference("github.com", "user", "repo")
+	alias := repository.NewReference("github.com", "our-org", "repo")
+	repo := &hosting.Repository{
+		Ref:      ref,
+		CloneURL: "https://github.com/user/repo.git",
+	}
+	localPath := "/secure/github.com/our-org/repo"
+
+	// The root is chosen by the local reference
+	mws.EXPECT().GetLayoutForReference(alias).Return(mls)
+	mls.EXPECT().CanHold(alias).Return(true)
+	mls.EXPECT().PathFor(alias).Return(localPath)
+	mps.EXPECT().Resolve(gomock.Any()).DoAndReturn(hosting.GuessProvider).AnyTimes()
+	mhs.EXPECT().GetTokenFor(gomock.Any(), "github.com", "user").Return("user", auth.Token{AccessToken: "token"}, nil)
+	mgs.EXPECT().AuthenticateWithUsernamePassword(gomock.Any(), "user", "token").Return(mgs, nil)
+	mgs.EXPECT().Clone(gomock.Any(), repo.CloneURL, localPath, gomock.Any()).Return(nil)
+	mgs.EXPECT().SetDefaultRemotes(gomock.Any(), localPath, []string{repo.CloneURL}).Return(nil)
+
+	svc := try.NewUsecase(mhs, mps, mws, workspace.NewIndexService(), mos, mgs)
+	if err := svc.Execute(context.Background(), repo, &alias, try.Options{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTryCloneLayoutCannotHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mhs := hosting_mock.NewMockHostingService(ctrl)
+	mps := hosting_mock.NewMockProviderService(ctrl)
+	mws := workspace_mock.NewMockWorkspaceService(ctrl)
+	mls := workspace_mock.NewMockLayoutService(ctrl)
+	mos := overlay_mock.NewMockOverlayService(ctrl)
+	mgs := git_mock.NewMockGitService(ctrl)
+
+	ref := repository.NewReference("gitlab.com", "a", "b")
+	repo := &hosting.Repository{
+		Ref:      ref,
+		CloneURL: "https://gitlab.com/a/b.git",
+	}
+
+	// e.g. every root has the layout "{owner}/{name}" for github.com: it must not be cloned at all
+	mws.EXPECT().GetLayoutForReference(ref).Return(mls)
+	mls.EXPECT().CanHold(ref).Return(false)
+	mls.EXPECT().GetRoot().Return("/oss")
+	mgs.EXPECT().Clone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	svc := try.NewUsecase(mhs, mps, mws, workspace.NewIndexService(), mos, mgs)
+	if err := svc.Execute(context.Background(), repo, nil, try.Options{}); err == nil {
+		t.Fatal("expected an error for the reference which the layout cannot hold")
+	}
+}
@@ -18,8 +18,10 @@ import (
 // Usecase represents the clone use case
 type Usecase struct {
 	hostingService   hosting.HostingService
+	providerService  hosting.ProviderService
 	workspaceService workspace.WorkspaceService
 	finderService    workspace.FinderService
+	indexService     workspace.IndexService
 	overlayService   overlay.OverlayService
 	scriptService    script.ScriptService
 	hookService      hook.HookService
@@ -30,8 +32,10 @@ type Usecase struct {
 // NewUsecase creates a new clone use case
 func NewUsecase(
 	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
 	workspaceService workspace.WorkspaceService,
 	finderService workspace.FinderService,
+	indexService workspace.IndexService,
 	overlayService overlay.OverlayService,
 	scriptService script.ScriptService,
 	hookService hook.HookService,
@@ -40,8 +44,10 @@ func NewUsecase(
 ) *Usecase {
 	return &Usecase{
 		hostingService:   hostingService,
+		providerService:  providerService,
 		workspaceService: workspaceService,
 		finderService:    finderService,
+		indexService:     indexService,
 		overlayService:   overlayService,
 		scriptService:    scriptService,
 		hookService:      hookService,
@@ -68,7 +74,7 @@ func (uc *Usecase) Execute(ctx context.Context, refWithAlias string, opts Option
 	if err != nil {
 		return err
 	}
-	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.workspaceService, uc.overlayService, uc.gitService)
+	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.providerService, uc.workspaceService, uc.indexService, uc.overlayService, uc.gitService)
 	if err := tryCloneUsecase.Execute(ctx, repo, ref.Alias, opts.TryCloneOptions); err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ type DefaultNameStore struct{}
 
 type tomlDefaultNameStore struct {
 	Hosts       map[string]string `toml:"hosts,omitempty"`
+	HostAliases map[string]string `toml:"host_aliases,omitempty"`
 	DefaultHost string            `toml:"default_host,omitempty"`
 }
 
@@ -37,6 +38,11 @@ func (d *DefaultNameStore) Load(ctx context.Context, initial func() repository.D
 			return nil, fmt.Errorf("set default owner for %s: %w", host, err)
 		}
 	}
+	for alias, host := range v.HostAliases {
+		if err := svc.SetHostAlias(alias, host); err != nil {
+			return nil, fmt.Errorf("set host alias %s: %w", alias, err)
+		}
+	}
 	svc.MarkSaved()
 	return svc, nil
 }
@@ -52,6 +58,7 @@ func (d *DefaultNameStore) Save(ctx context.Context, ds repository.DefaultNameSe
 	}
 	v := tomlDefaultNameStore{
 		Hosts:       ds.GetMap(),
+		HostAliases: ds.GetHostAliases(),
 		DefaultHost: ds.GetDefaultHost(),
 	}
 
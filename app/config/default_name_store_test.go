@@ -68,6 +68,9 @@ func createTestTOMLFile(t *testing.T, path string) {
 [hosts]
 'github.com' = "testuser"
 'gitlab.com' = "otheruser"
+
+[host_aliases]
+gh = "github.com"
 `
 
 	err := os.WriteFile(path, []byte(content), 0o644)
@@ -110,6 +113,7 @@ func TestLoad_FileExists(t *testing.T) {
 	mockService.EXPECT().SetDefaultHost("github.com").Return(nil)
 	mockService.EXPECT().SetDefaultOwnerFor("github.com", "testuser").Return(nil)
 	mockService.EXPECT().SetDefaultOwnerFor("gitlab.com", "otheruser").Return(nil)
+	mockService.EXPECT().SetHostAlias("gh", "github.com").Return(nil)
 	mockService.EXPECT().MarkSaved()
 
 	// Call Load
@@ -248,6 +252,7 @@ func TestSave_WithChanges(t *testing.T) {
 		"github.com": "testuser",
 		"gitlab.com": "otheruser",
 	})
+	mockService.EXPECT().GetHostAliases().Return(map[string]string{"gh": "github.com"})
 	mockService.EXPECT().GetDefaultHost().Return("github.com")
 	mockService.EXPECT().MarkSaved()
 
@@ -280,6 +285,7 @@ func TestSave_ForceWithoutChanges(t *testing.T) {
 	mockService.EXPECT().GetMap().Return(map[string]string{
 		"github.com": "testuser",
 	})
+	mockService.EXPECT().GetHostAliases().Return(map[string]string{"gh": "github.com"})
 	mockService.EXPECT().GetDefaultHost().Return("github.com")
 	mockService.EXPECT().MarkSaved()
 
@@ -318,6 +324,7 @@ func TestSave_CreateDirectory(t *testing.T) {
 	mockService.EXPECT().GetMap().Return(map[string]string{
 		"github.com": "testuser",
 	})
+	mockService.EXPECT().GetHostAliases().Return(map[string]string{"gh": "github.com"})
 	mockService.EXPECT().GetDefaultHost().Return("github.com")
 	mockService.EXPECT().MarkSaved()
 
@@ -29,11 +29,22 @@ func LocationFormatter(v string) (repository.LocationFormat, error) {
 
 // BundleDumpFlags is a struct that contains flags for dumping a bundle.
 type BundleDumpFlags struct {
-	File string `yaml:"file,omitempty" toml:"file,omitempty"`
+	File string   `yaml:"file,omitempty" toml:"file,omitempty"`
+	Tags []string `yaml:"-" toml:"-"`
+}
+
+// CloneOptionFlags is a struct that contains flags for the local clone operation.
+type CloneOptionFlags struct {
+	Depth             int    `yaml:"depth,omitempty" toml:"depth,omitempty"`
+	Branch            string `yaml:"-" toml:"-"`
+	SingleBranch      bool   `yaml:"singleBranch,omitempty" toml:"single-branch,omitempty"`
+	Filter            string `yaml:"filter,omitempty" toml:"filter,omitempty"`
+	RecurseSubmodules bool   `yaml:"recurseSubmodules,omitempty" toml:"recurse-submodules,omitempty"`
 }
 
 // BundleRestoreFlags is a struct that contains flags for restoring a bundle.
 type BundleRestoreFlags struct {
+	CloneOptionFlags  `yaml:",inline"`
 	File              string        `yaml:"file,omitempty" toml:"file,omitempty"`
 	CloneRetryTimeout time.Duration `yaml:"cloneRetryTimeout,omitempty" toml:"clone-retry-timeout,omitempty"`
 	CloneRetryLimit   int           `yaml:"cloneRetryLimit,omitempty" toml:"clone-retry-limit,omitempty"`
@@ -42,12 +53,16 @@ type BundleRestoreFlags struct {
 
 // CloneFlags is a struct that contains flags for cloning a repository.
 type CloneFlags struct {
+	CloneOptionFlags  `yaml:",inline"`
 	CloneRetryTimeout time.Duration `yaml:"cloneRetryTimeout,omitempty" toml:"clone-retry-timeout,omitempty"`
+	Fork              string        `yaml:"-" toml:"-"`
+	Archive           string        `yaml:"-" toml:"-"`
 	DryRun            bool          `yaml:"-" toml:"-"`
 }
 
 // CreateFlags is a struct that contains flags for creating a repository.
 type CreateFlags struct {
+	CloneOptionFlags    `yaml:",inline"`
 	Template            string        `yaml:"template,omitempty" toml:"template,omitempty"`
 	Description         string        `yaml:"-" toml:"-"`
 	Homepage            string        `yaml:"-" toml:"-"`
@@ -92,18 +107,56 @@ type ReposFlags struct {
 type ListFlags struct {
 	Limit    int      `yaml:"limit,omitempty" toml:"limit,omitempty"`
 	Patterns []string `yaml:"-" toml:"-"`
+	Tags     []string `yaml:"-" toml:"-"`
 	Format   string   `yaml:"format,omitempty" toml:"format,omitempty"`
 	Primary  bool     `yaml:"primary,omitempty" toml:"primary,omitempty"`
 }
 
 // ForkFlags is a struct that contains flags for forking a repository.
 type ForkFlags struct {
+	CloneOptionFlags  `yaml:",inline"`
 	To                string        `yaml:"-" toml:"-"`
 	DefaultBranchOnly bool          `yaml:"defaultBranchOnly,omitempty" toml:"default-branch-only,omitempty"`
 	CloneRetryTimeout time.Duration `yaml:"cloneRetryTimeout,omitempty" toml:"clone-retry-timeout,omitempty"`
 	CloneRetryLimit   int           `yaml:"cloneRetryLimit,omitempty" toml:"clone-retry-limit,omitempty"`
 }
 
+// PullFlags is a struct that contains flags for updating local repositories.
+type PullFlags struct {
+	Patterns    []string `yaml:"-" toml:"-"`
+	Primary     bool     `yaml:"primary,omitempty" toml:"primary,omitempty"`
+	Concurrency int      `yaml:"concurrency,omitempty" toml:"concurrency,omitempty"`
+	FetchOnly   bool     `yaml:"fetchOnly,omitempty" toml:"fetch-only,omitempty"`
+}
+
+// StatusFlags is a struct that contains flags for reporting the status of local repositories.
+type StatusFlags struct {
+	Patterns  []string `yaml:"-" toml:"-"`
+	Primary   bool     `yaml:"primary,omitempty" toml:"primary,omitempty"`
+	Format    string   `yaml:"format,omitempty" toml:"format,omitempty"`
+	OnlyDirty bool     `yaml:"onlyDirty,omitempty" toml:"only-dirty,omitempty"`
+}
+
+// GCFlags is a struct that contains flags for removing stale local repositories.
+type GCFlags struct {
+	Patterns []string `yaml:"-" toml:"-"`
+	Primary  bool     `yaml:"primary,omitempty" toml:"primary,omitempty"`
+	Days     int      `yaml:"days,omitempty" toml:"days,omitempty"`
+	Format   string   `yaml:"format,omitempty" toml:"format,omitempty"`
+}
+
+// GitBackendGoGit is the git backend built in gogh (go-git). It is used by default.
+const GitBackendGoGit = "go-git"
+
+// GitBackendSystem is the git backend which runs the git command installed in the system.
+const GitBackendSystem = "system"
+
+// GitFlags is a struct that contains flags for the git operations.
+type GitFlags struct {
+	// Backend is the implementation of the git operations: "go-git" or "system".
+	Backend string `yaml:"backend,omitempty" toml:"backend,omitempty"`
+}
+
 // Flags is a struct that contains all the flags for the application.
 type Flags struct {
 	RawHasChanges bool               `yaml:"-" toml:"-"` // RawHasChanges is used to track if there are any changes in the flags.
@@ -115,6 +168,10 @@ type Flags struct {
 	Create        CreateFlags        `yaml:"create,omitempty" toml:"create,omitempty"`
 	Repos         ReposFlags         `yaml:"repos,omitempty" toml:"repos,omitempty"`
 	Fork          ForkFlags          `yaml:"fork,omitempty" toml:"fork,omitempty"`
+	Pull          PullFlags          `yaml:"pull,omitempty" toml:"pull,omitempty"`
+	Status        StatusFlags        `yaml:"status,omitempty" toml:"status,omitempty"`
+	GC            GCFlags            `yaml:"gc,omitempty" toml:"gc,omitempty"`
+	Git           GitFlags           `yaml:"git,omitempty" toml:"git,omitempty"`
 }
 
 // HasChanges always returns false because Flags does not support saving.
@@ -151,5 +208,9 @@ func DefaultFlags() *Flags {
 
 	f.Fork.CloneRetryTimeout = 5 * time.Minute
 	f.Fork.CloneRetryLimit = 3
+
+	f.Pull.Concurrency = 8
+
+	f.GC.Days = 90
 	return f
 }
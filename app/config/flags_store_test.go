@@ -59,6 +59,12 @@ primary = true
 limit = 50
 privacy = "public"
 fork = "exclude"
+
+[clone]
+depth = 1
+single-branch = true
+filter = "blob:none"
+recurse-submodules = true
 `
 	flagsPath := filepath.Join(tempDir, "flags.v4.toml")
 	err = os.WriteFile(flagsPath, []byte(flagsContent), 0o644)
@@ -101,6 +107,16 @@ fork = "exclude"
 		if flags.Repos.Fork != "exclude" {
 			t.Errorf("expected Repos.Fork to be 'exclude', got '%s'", flags.Repos.Fork)
 		}
+
+		// Check Clone flags
+		if want := (config.CloneOptionFlags{
+			Depth:             1,
+			SingleBranch:      true,
+			Filter:            "blob:none",
+			RecurseSubmodules: true,
+		}); flags.Clone.CloneOptionFlags != want {
+			t.Errorf("expected Clone.CloneOptionFlags to be %+v, got %+v", want, flags.Clone.CloneOptionFlags)
+		}
 	})
 
 	t.Run("file not found", func(t *testing.T) {
@@ -212,6 +212,9 @@ func TestDefaultFlags(t *testing.T) {
 	if f.Fork.CloneRetryLimit != 3 {
 		t.Errorf("expected Fork.CloneRetryLimit to be 3, got %d", f.Fork.CloneRetryLimit)
 	}
+	if f.GC.Days != 90 {
+		t.Errorf("expected GC.Days to be 90, got %d", f.GC.Days)
+	}
 
 	// Default boolean flags should be false
 	if f.BundleRestore.DryRun {
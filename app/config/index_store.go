@@ -0,0 +1,96 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// IndexStore is a repository for managing the index of the local repositories.
+type IndexStore struct{}
+
+type tomlIndexStore struct {
+	Repositories []tomlIndexEntry `toml:"repositories"`
+}
+
+type tomlIndexEntry struct {
+	Path  string `toml:"path"`
+	Host  string `toml:"host"`
+	Owner string `toml:"owner"`
+	Name  string `toml:"name"`
+}
+
+func (*IndexStore) Source() (string, error) {
+	path, err := AppContextPathFunc("GOGH_INDEX_PATH", os.UserCacheDir, "index.v4.toml")
+	if err != nil {
+		return "", fmt.Errorf("search index path: %w", err)
+	}
+	return path, nil
+}
+
+// Load implements store.Store.
+func (s *IndexStore) Load(ctx context.Context, initial func() workspace.IndexService) (workspace.IndexService, error) {
+	source, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := loadTOMLFile[tomlIndexStore](source)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([]*repository.Location, 0, len(v.Repositories))
+	for _, entry := range v.Repositories {
+		locations = append(locations, repository.NewLocation(entry.Path, entry.Host, entry.Owner, entry.Name))
+	}
+	svc := initial()
+	svc.Reset(locations)
+	svc.MarkSaved()
+	return svc, nil
+}
+
+// Save implements store.Store.
+// If the index is not built, it removes the stored index.
+func (s *IndexStore) Save(ctx context.Context, svc workspace.IndexService, force bool) error {
+	if !svc.HasChanges() && !force {
+		return nil
+	}
+	source, err := s.Source()
+	if err != nil {
+		return err
+	}
+	if !svc.Built() {
+		if err := os.Remove(source); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		svc.MarkSaved()
+		return nil
+	}
+
+	v := tomlIndexStore{Repositories: []tomlIndexEntry{}}
+	for _, location := range svc.Entries() {
+		v.Repositories = append(v.Repositories, tomlIndexEntry{
+			Path:  location.FullPath(),
+			Host:  location.Host(),
+			Owner: location.Owner(),
+			Name:  location.Name(),
+		})
+	}
+	if err := saveTOMLFile(source, v); err != nil {
+		return err
+	}
+	svc.MarkSaved()
+	return nil
+}
+
+// NewIndexStore creates a new IndexStore instance.
+func NewIndexStore() *IndexStore {
+	return &IndexStore{}
+}
+
+var _ store.Store[workspace.IndexService] = (*IndexStore)(nil)
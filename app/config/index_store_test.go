@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func setupIndexStoreTest(t *testing.T) (string, *config.IndexStore) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	// Override the appContextPath to use our test directory
+	origAppContextPath := config.AppContextPathFunc
+	config.AppContextPathFunc = func(envName string, fallbackFunc func() (string, error), rel ...string) (string, error) {
+		return filepath.Join(append([]string{tempDir}, rel...)...), nil
+	}
+	t.Cleanup(func() {
+		config.AppContextPathFunc = origAppContextPath
+	})
+	return filepath.Join(tempDir, "index.v4.toml"), config.NewIndexStore()
+}
+
+func TestIndexStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	source, store := setupIndexStoreTest(t)
+
+	if got, err := store.Source(); err != nil || got != source {
+		t.Fatalf("Expected source %q, got %q (%v)", source, got, err)
+	}
+
+	// Not built index is not saved
+	svc := workspace.NewIndexService()
+	if err := store.Save(ctx, svc, true); err != nil {
+		t.Fatalf("Unexpected error from Save(): %v", err)
+	}
+	if _, err := os.Stat(source); !os.IsNotExist(err) {
+		t.Fatalf("Expected no index file, got %v", err)
+	}
+	if _, err := config.LoadAlternative(ctx, workspace.NewIndexService, store); err != nil {
+		t.Fatalf("Expected no error for a missing index file, got %v", err)
+	}
+
+	location := repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh")
+	svc.Reset([]*repository.Location{location})
+	if err := store.Save(ctx, svc, false); err != nil {
+		t.Fatalf("Unexpected error from Save(): %v", err)
+	}
+	if svc.HasChanges() {
+		t.Error("Expected the index to be marked as saved")
+	}
+
+	loaded, err := store.Load(ctx, workspace.NewIndexService)
+	if err != nil {
+		t.Fatalf("Unexpected error from Load(): %v", err)
+	}
+	if !loaded.Built() || loaded.HasChanges() {
+		t.Error("Expected the loaded index to be built and unchanged")
+	}
+	entries := loaded.Entries()
+	if len(entries) != 1 || *entries[0] != *location {
+		t.Errorf("Expected %v, got %v", location, entries)
+	}
+
+	// Dropped index removes the file
+	loaded.Drop()
+	if err := store.Save(ctx, loaded, false); err != nil {
+		t.Fatalf("Unexpected error from Save(): %v", err)
+	}
+	if _, err := os.Stat(source); !os.IsNotExist(err) {
+		t.Errorf("Expected the index file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/store"
+)
+
+// ProviderStore is a store for the registry of the hosting service providers for each host.
+type ProviderStore struct{}
+
+type tomlProvider struct {
+	Kind       string `toml:"kind"`
+	APIBase    string `toml:"api-base,omitempty"`
+	CloneURL   string `toml:"clone-url,omitempty"`
+	Protocol   string `toml:"protocol,omitempty"`
+	SSHKeyFile string `toml:"ssh-key-file,omitempty"`
+}
+
+type tomlProviderStore struct {
+	Hosts map[string]tomlProvider `toml:"hosts,omitempty"`
+}
+
+func (d *ProviderStore) Source() (string, error) {
+	path, err := AppContextPathFunc("GOGH_PROVIDERS_PATH", os.UserConfigDir, "providers.v4.toml")
+	if err != nil {
+		return "", fmt.Errorf("search providers path: %w", err)
+	}
+	return path, nil
+}
+
+// Load implements store.Store.
+func (d *ProviderStore) Load(ctx context.Context, initial func() hosting.ProviderService) (hosting.ProviderService, error) {
+	source, err := d.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := loadTOMLFile[tomlProviderStore](source)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := initial()
+	for host, p := range v.Hosts {
+		kind, err := hosting.ParseProviderKind(p.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("parse provider kind for %s: %w", host, err)
+		}
+		if err := svc.Set(hosting.Provider{
+			Host:       host,
+			Kind:       kind,
+			APIBase:    p.APIBase,
+			CloneURL:   p.CloneURL,
+			Protocol:   hosting.Protocol(p.Protocol),
+			SSHKeyFile: p.SSHKeyFile,
+		}); err != nil {
+			return nil, fmt.Errorf("set provider for %s: %w", host, err)
+		}
+	}
+	svc.MarkSaved()
+	return svc, nil
+}
+
+// Save implements store.Store.
+func (d *ProviderStore) Save(ctx context.Context, ds hosting.ProviderService, force bool) error {
+	if !ds.HasChanges() && !force {
+		return nil
+	}
+	source, err := d.Source()
+	if err != nil {
+		return err
+	}
+	v := tomlProviderStore{Hosts: map[string]tomlProvider{}}
+	for _, p := range ds.Entries() {
+		v.Hosts[p.Host] = tomlProvider{
+			Kind:       string(p.Kind),
+			APIBase:    p.APIBase,
+			CloneURL:   p.CloneURL,
+			Protocol:   string(p.Protocol),
+			SSHKeyFile: p.SSHKeyFile,
+		}
+	}
+
+	if err := saveTOMLFile(source, v); err != nil {
+		return err
+	}
+	ds.MarkSaved()
+	return nil
+}
+
+func NewProviderStore() *ProviderStore {
+	return &ProviderStore{}
+}
+
+var _ store.Store[hosting.ProviderService] = (*ProviderStore)(nil)
@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+)
+
+// setupProviderStoreTest overrides the AppContextPathFunc to use a temporary directory
+func setupProviderStoreTest(t *testing.T) (string, *config.ProviderStore) {
+	t.Helper()
+	tempDir := t.TempDir()
+	origAppContextPath := config.AppContextPathFunc
+	config.AppContextPathFunc = func(envName string, fallbackFunc func() (string, error), rel ...string) (string, error) {
+		return filepath.Join(append([]string{tempDir}, rel...)...), nil
+	}
+	t.Cleanup(func() {
+		config.AppContextPathFunc = origAppContextPath
+	})
+	return tempDir, config.NewProviderStore()
+}
+
+func TestProviderStoreSource(t *testing.T) {
+	tempDir, store := setupProviderStoreTest(t)
+
+	path, err := store.Source()
+	if err != nil {
+		t.Fatalf("Unexpected error from Source(): %v", err)
+	}
+	if expected := filepath.Join(tempDir, "providers.v4.toml"); path != expected {
+		t.Errorf("Expected path %q, got %q", expected, path)
+	}
+}
+
+func TestProviderStoreLoad(t *testing.T) {
+	tempDir, store := setupProviderStoreTest(t)
+	ctx := context.Background()
+
+	t.Run("file not exists", func(t *testing.T) {
+		if _, err := store.Load(ctx, hosting.NewProviderService); !os.IsNotExist(err) {
+			t.Errorf("Expected not-exist error, got %v", err)
+		}
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		content := `[hosts.'github.example.com']
+kind = "github"
+api-base = "https://github.example.com/api/v3"
+protocol = "ssh"
+ssh-key-file = "~/.ssh/id_work"
+
+[hosts.'git.example.com']
+kind = "gitea"
+
+[hosts.'gitolite.example.com']
+kind = "git"
+clone-url = "git@gitolite.example.com:{{.Owner}}/{{.Name}}"
+`
+		if err := os.WriteFile(filepath.Join(tempDir, "providers.v4.toml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write test TOML file: %v", err)
+		}
+		svc, err := store.Load(ctx, hosting.NewProviderService)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if svc.HasChanges() {
+			t.Error("Loaded service should not have changes")
+		}
+		want := []hosting.Provider{
+			{Host: "git.example.com", Kind: hosting.ProviderKindGitea},
+			{Host: "github.example.com", Kind: hosting.ProviderKindGitHub, APIBase: "https://github.example.com/api/v3", Protocol: hosting.ProtocolSSH, SSHKeyFile: "~/.ssh/id_work"},
+			{Host: "gitolite.example.com", Kind: hosting.ProviderKindGit, CloneURL: "git@gitolite.example.com:{{.Owner}}/{{.Name}}"},
+		}
+		got := svc.Entries()
+		if len(got) != len(want) {
+			t.Fatalf("Expected %+v, got %+v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Expected %+v, got %+v", want[i], got[i])
+			}
+		}
+	})
+
+	t.Run("invalid protocol", func(t *testing.T) {
+		content := `[hosts.'git.example.com']
+kind = "gitea"
+protocol = "ftp"
+`
+		if err := os.WriteFile(filepath.Join(tempDir, "providers.v4.toml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write test TOML file: %v", err)
+		}
+		if _, err := store.Load(ctx, hosting.NewProviderService); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		content := `[hosts.'git.example.com']
+kind = "svn"
+`
+		if err := os.WriteFile(filepath.Join(tempDir, "providers.v4.toml"), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write test TOML file: %v", err)
+		}
+		if _, err := store.Load(ctx, hosting.NewProviderService); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
+func TestProviderStoreSave(t *testing.T) {
+	tempDir, store := setupProviderStoreTest(t)
+	ctx := context.Background()
+
+	svc := hosting.NewProviderService()
+	if err := store.Save(ctx, svc, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "providers.v4.toml")); !os.IsNotExist(err) {
+		t.Error("Expected no file to be saved without changes")
+	}
+
+	if err := svc.Set(hosting.Provider{
+		Host:    "gitlab.example.com",
+		Kind:    hosting.ProviderKindGitLab,
+		APIBase: "https://gitlab.example.com/api/v4",
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.Save(ctx, svc, false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if svc.HasChanges() {
+		t.Error("Saved service should not have changes")
+	}
+	content, err := os.ReadFile(filepath.Join(tempDir, "providers.v4.toml"))
+	if err != nil {
+		t.Fatalf("Failed to read saved file: %v", err)
+	}
+	for _, want := range []string{"gitlab.example.com", `kind = 'gitlab'`, `api-base = 'https://gitlab.example.com/api/v4'`} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected saved file to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	loaded, err := store.Load(ctx, hosting.NewProviderService)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p, ok := loaded.Get("gitlab.example.com"); !ok || p.Kind != hosting.ProviderKindGitLab {
+		t.Errorf("Unexpected loaded provider: %+v", p)
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// TagStore is a repository for managing the tags of the repositories.
+type TagStore struct{}
+
+type tomlTagStore struct {
+	Repositories []tomlTagEntry `toml:"repositories"`
+}
+
+type tomlTagEntry struct {
+	Host  string   `toml:"host"`
+	Owner string   `toml:"owner"`
+	Name  string   `toml:"name"`
+	Tags  []string `toml:"tags"`
+}
+
+func (*TagStore) Source() (string, error) {
+	path, err := AppContextPathFunc("GOGH_TAGS_PATH", os.UserConfigDir, "tags.v4.toml")
+	if err != nil {
+		return "", fmt.Errorf("search tags path: %w", err)
+	}
+	return path, nil
+}
+
+// Load implements store.Store.
+func (s *TagStore) Load(ctx context.Context, initial func() workspace.TagService) (workspace.TagService, error) {
+	source, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := loadTOMLFile[tomlTagStore](source)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := initial()
+	for _, entry := range v.Repositories {
+		ref := repository.NewReference(entry.Host, entry.Owner, entry.Name)
+		if err := svc.Add(ref, entry.Tags...); err != nil {
+			return nil, fmt.Errorf("add tags for %s: %w", ref, err)
+		}
+	}
+	svc.MarkSaved()
+	return svc, nil
+}
+
+// Save implements store.Store.
+func (s *TagStore) Save(ctx context.Context, svc workspace.TagService, force bool) error {
+	if !svc.HasChanges() && !force {
+		return nil
+	}
+	source, err := s.Source()
+	if err != nil {
+		return err
+	}
+
+	v := tomlTagStore{Repositories: []tomlTagEntry{}}
+	for ref, tags := range svc.Entries() {
+		v.Repositories = append(v.Repositories, tomlTagEntry{
+			Host:  ref.Host(),
+			Owner: ref.Owner(),
+			Name:  ref.Name(),
+			Tags:  tags,
+		})
+	}
+	if err := saveTOMLFile(source, v); err != nil {
+		return err
+	}
+	svc.MarkSaved()
+	return nil
+}
+
+// NewTagStore creates a new TagStore instance.
+func NewTagStore() *TagStore {
+	return &TagStore{}
+}
+
+var _ store.Store[workspace.TagService] = (*TagStore)(nil)
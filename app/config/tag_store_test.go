@@ -0,0 +1,69 @@
+package config_test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func setupTagStoreTest(t *testing.T) (string, *config.TagStore) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	// Override the appContextPath to use our test directory
+	origAppContextPath := config.AppContextPathFunc
+	config.AppContextPathFunc = func(envName string, fallbackFunc func() (string, error), rel ...string) (string, error) {
+		return filepath.Join(append([]string{tempDir}, rel...)...), nil
+	}
+	t.Cleanup(func() {
+		config.AppContextPathFunc = origAppContextPath
+	})
+	return filepath.Join(tempDir, "tags.v4.toml"), config.NewTagStore()
+}
+
+func TestTagStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	source, store := setupTagStoreTest(t)
+
+	if got, err := store.Source(); err != nil || got != source {
+		t.Fatalf("Expected source %q, got %q (%v)", source, got, err)
+	}
+	if _, err := config.LoadAlternative(ctx, workspace.NewTagService, store); err != nil {
+		t.Fatalf("Expected no error for a missing tags file, got %v", err)
+	}
+
+	svc := workspace.NewTagService()
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	nested := repository.NewReference("gitlab.com", "group/subgroup", "project")
+	if err := svc.Add(gogh, "tools", "go"); err != nil {
+		t.Fatalf("Unexpected error from Add(): %v", err)
+	}
+	if err := svc.Add(nested, "infra"); err != nil {
+		t.Fatalf("Unexpected error from Add(): %v", err)
+	}
+	if err := store.Save(ctx, svc, false); err != nil {
+		t.Fatalf("Unexpected error from Save(): %v", err)
+	}
+	if svc.HasChanges() {
+		t.Error("Expected the tags to be marked as saved")
+	}
+
+	loaded, err := store.Load(ctx, workspace.NewTagService)
+	if err != nil {
+		t.Fatalf("Unexpected error from Load(): %v", err)
+	}
+	if loaded.HasChanges() {
+		t.Error("Expected the loaded tags to be unchanged")
+	}
+	if got := loaded.Tags(gogh); !slices.Equal(got, []string{"go", "tools"}) {
+		t.Errorf("Expected tags of %s to be [go tools], got %v", gogh, got)
+	}
+	if got := loaded.Tags(nested); !slices.Equal(got, []string{"infra"}) {
+		t.Errorf("Expected tags of %s to be [infra], got %v", nested, got)
+	}
+}
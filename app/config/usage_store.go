@@ -0,0 +1,92 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// UsageStore is a repository for managing the usage records of the repositories.
+type UsageStore struct{}
+
+type tomlUsageStore struct {
+	Repositories []tomlUsageEntry `toml:"repositories"`
+}
+
+type tomlUsageEntry struct {
+	Host      string    `toml:"host"`
+	Owner     string    `toml:"owner"`
+	Name      string    `toml:"name"`
+	Count     float64   `toml:"count"`
+	LastVisit time.Time `toml:"last-visit"`
+}
+
+func (*UsageStore) Source() (string, error) {
+	path, err := AppContextPathFunc("GOGH_USAGE_PATH", os.UserCacheDir, "usage.v4.toml")
+	if err != nil {
+		return "", fmt.Errorf("search usage path: %w", err)
+	}
+	return path, nil
+}
+
+// Load implements store.Store.
+func (s *UsageStore) Load(ctx context.Context, initial func() workspace.UsageService) (workspace.UsageService, error) {
+	source, err := s.Source()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := loadTOMLFile[tomlUsageStore](source)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := initial()
+	for _, entry := range v.Repositories {
+		svc.Set(repository.NewReference(entry.Host, entry.Owner, entry.Name), workspace.Usage{
+			Count:     entry.Count,
+			LastVisit: entry.LastVisit,
+		})
+	}
+	svc.MarkSaved()
+	return svc, nil
+}
+
+// Save implements store.Store.
+func (s *UsageStore) Save(ctx context.Context, svc workspace.UsageService, force bool) error {
+	if !svc.HasChanges() && !force {
+		return nil
+	}
+	source, err := s.Source()
+	if err != nil {
+		return err
+	}
+
+	v := tomlUsageStore{Repositories: []tomlUsageEntry{}}
+	for ref, usage := range svc.Entries() {
+		v.Repositories = append(v.Repositories, tomlUsageEntry{
+			Host:      ref.Host(),
+			Owner:     ref.Owner(),
+			Name:      ref.Name(),
+			Count:     usage.Count,
+			LastVisit: usage.LastVisit,
+		})
+	}
+	if err := saveTOMLFile(source, v); err != nil {
+		return err
+	}
+	svc.MarkSaved()
+	return nil
+}
+
+// NewUsageStore creates a new UsageStore instance.
+func NewUsageStore() *UsageStore {
+	return &UsageStore{}
+}
+
+var _ store.Store[workspace.UsageService] = (*UsageStore)(nil)
@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestUsageStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	origAppContextPath := config.AppContextPathFunc
+	config.AppContextPathFunc = func(envName string, fallbackFunc func() (string, error), rel ...string) (string, error) {
+		return filepath.Join(append([]string{tempDir}, rel...)...), nil
+	}
+	t.Cleanup(func() {
+		config.AppContextPathFunc = origAppContextPath
+	})
+	store := config.NewUsageStore()
+
+	if got, err := store.Source(); err != nil || got != filepath.Join(tempDir, "usage.v4.toml") {
+		t.Fatalf("Unexpected source %q (%v)", got, err)
+	}
+	if _, err := config.LoadAlternative(ctx, workspace.NewUsageService, store); err != nil {
+		t.Fatalf("Expected no error for a missing usage file, got %v", err)
+	}
+
+	svc := workspace.NewUsageService()
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	visited := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	svc.Visit(gogh, visited)
+	svc.Visit(gogh, visited.Add(-time.Hour))
+	if err := store.Save(ctx, svc, false); err != nil {
+		t.Fatalf("Unexpected error from Save(): %v", err)
+	}
+	if svc.HasChanges() {
+		t.Error("Expected the usage to be marked as saved")
+	}
+
+	loaded, err := store.Load(ctx, workspace.NewUsageService)
+	if err != nil {
+		t.Fatalf("Unexpected error from Load(): %v", err)
+	}
+	if loaded.HasChanges() {
+		t.Error("Expected the loaded usage to be unchanged")
+	}
+	usage, ok := loaded.Get(gogh)
+	if !ok || usage.Count != 2 || !usage.LastVisit.Equal(visited) {
+		t.Errorf("Unexpected usage of %s: %+v", gogh, usage)
+	}
+}
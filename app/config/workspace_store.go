@@ -13,8 +13,21 @@ import (
 type WorkspaceStore struct{}
 
 type tomlWorkspaceStore struct {
-	Roots       []workspace.Root `toml:"roots,omitempty"`
-	PrimaryRoot string           `toml:"primary_root,omitempty"`
+	Roots       []workspace.Root          `toml:"roots,omitempty"`
+	PrimaryRoot string                    `toml:"primary_root,omitempty"`
+	Layouts     map[string]tomlRootLayout `toml:"layouts,omitempty"`
+	Routes      []tomlRoute               `toml:"routes,omitempty"`
+}
+
+type tomlRoute struct {
+	Pattern string `toml:"pattern"`
+	Root    string `toml:"root"`
+}
+
+type tomlRootLayout struct {
+	Template string `toml:"template,omitempty"`
+	Host     string `toml:"host,omitempty"`
+	Owner    string `toml:"owner,omitempty"`
 }
 
 // Load implements store.Store.
@@ -35,6 +48,16 @@ func (w *WorkspaceStore) Load(ctx context.Context, initial func() workspace.Work
 			return nil, err
 		}
 	}
+	for root, layout := range v.Layouts {
+		if err := svc.SetRootLayout(root, workspace.Layout(layout)); err != nil {
+			return nil, fmt.Errorf("set layout for %q: %w", root, err)
+		}
+	}
+	for _, route := range v.Routes {
+		if err := svc.AddRoute(workspace.Route(route)); err != nil {
+			return nil, fmt.Errorf("add route for %q: %w", route.Pattern, err)
+		}
+	}
 	svc.MarkSaved()
 	return svc, nil
 }
@@ -52,6 +75,19 @@ func (w *WorkspaceStore) Save(ctx context.Context, ws workspace.WorkspaceService
 		Roots:       ws.GetRoots(),
 		PrimaryRoot: ws.GetPrimaryRoot(),
 	}
+	for _, root := range v.Roots {
+		layout := ws.GetRootLayout(root)
+		if layout.IsDefault() {
+			continue
+		}
+		if v.Layouts == nil {
+			v.Layouts = map[string]tomlRootLayout{}
+		}
+		v.Layouts[root] = tomlRootLayout(layout)
+	}
+	for _, route := range ws.GetRoutes() {
+		v.Routes = append(v.Routes, tomlRoute(route))
+	}
 
 	if err := saveTOMLFile(source, v); err != nil {
 		return err
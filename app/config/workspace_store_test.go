@@ -234,6 +234,9 @@ func TestWorkspaceStore_Save_WithChanges(t *testing.T) {
 	mockService.EXPECT().HasChanges().Return(true)
 	mockService.EXPECT().GetRoots().Return(roots)
 	mockService.EXPECT().GetPrimaryRoot().Return(root1)
+	mockService.EXPECT().GetRootLayout(root1).Return(workspace.Layout{})
+	mockService.EXPECT().GetRootLayout(root2).Return(workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"})
+	mockService.EXPECT().GetRoutes().Return([]workspace.Route{{Pattern: "github.com/our-org/**", Root: root2}})
 	mockService.EXPECT().MarkSaved()
 
 	// Call Save
@@ -251,8 +254,58 @@ func TestWorkspaceStore_Save_WithChanges(t *testing.T) {
 	}
 
 	// Check that the content looks reasonable
-	if len(content) == 0 {
-		t.Error("Saved TOML file is empty")
+	var saved struct {
+		Roots   []string                     `toml:"roots"`
+		Layouts map[string]map[string]string `toml:"layouts"`
+		Routes  []map[string]string          `toml:"routes"`
+	}
+	if err := toml.Unmarshal(content, &saved); err != nil {
+		t.Fatalf("Failed to parse saved TOML file: %v", err)
+	}
+	if len(saved.Roots) != 2 {
+		t.Errorf("Expected 2 roots, got %v", saved.Roots)
+	}
+	if len(saved.Layouts) != 1 {
+		t.Fatalf("Expected only the non-default layout to be saved, got %v", saved.Layouts)
+	}
+	if got := saved.Layouts[root2]; got["template"] != "{name}" || got["host"] != "github.com" || got["owner"] != "kyoh86" {
+		t.Errorf("Unexpected layout for %q: %v", root2, got)
+	}
+	if len(saved.Routes) != 1 || saved.Routes[0]["pattern"] != "github.com/our-org/**" || saved.Routes[0]["root"] != root2 {
+		t.Errorf("Unexpected routes: %v", saved.Routes)
+	}
+}
+
+func TestWorkspaceStore_Load_WithLayoutsAndRoutes(t *testing.T) {
+	tempDir, cleanup, mockService, store := setupWorkspaceStoreTestEnvironment(t)
+	defer cleanup()
+
+	root1 := filepath.Join(tempDir, "root1")
+	configPath := filepath.Join(tempDir, "workspace.v4.toml")
+	encoded, err := toml.Marshal(map[string]any{
+		"roots":        []string{root1},
+		"primary_root": root1,
+		"layouts": map[string]any{
+			root1: map[string]string{"template": "{owner}/{name}", "host": "github.com"},
+		},
+		"routes": []map[string]string{
+			{"pattern": "github.com/our-org/**", "root": root1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to encode TOML: %v", err)
+	}
+	if err := os.WriteFile(configPath, encoded, 0o644); err != nil {
+		t.Fatalf("Failed to write TOML file: %v", err)
+	}
+
+	mockService.EXPECT().AddRoot(root1, true).Return(nil)
+	mockService.EXPECT().SetRootLayout(root1, workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}).Return(nil)
+	mockService.EXPECT().AddRoute(workspace.Route{Pattern: "github.com/our-org/**", Root: root1}).Return(nil)
+	mockService.EXPECT().MarkSaved()
+
+	if _, err := store.Load(context.Background(), func() workspace.WorkspaceService { return mockService }); err != nil {
+		t.Fatalf("Unexpected error from Load(): %v", err)
 	}
 }
 
@@ -268,6 +321,8 @@ func TestWorkspaceStore_Save_ForceWithoutChanges(t *testing.T) {
 	mockService.EXPECT().HasChanges().Return(false)
 	mockService.EXPECT().GetRoots().Return(roots)
 	mockService.EXPECT().GetPrimaryRoot().Return(root1)
+	mockService.EXPECT().GetRootLayout(root1).Return(workspace.Layout{})
+	mockService.EXPECT().GetRoutes().Return(nil)
 	mockService.EXPECT().MarkSaved()
 
 	// Call Save with force=true
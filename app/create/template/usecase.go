@@ -18,8 +18,10 @@ import (
 // Usecase represents the use case for creating a repository from a template.
 type Usecase struct {
 	hostingService   hosting.HostingService
+	providerService  hosting.ProviderService
 	workspaceService workspace.WorkspaceService
 	finderService    workspace.FinderService
+	indexService     workspace.IndexService
 	overlayService   overlay.OverlayService
 	scriptService    script.ScriptService
 	hookService      hook.HookService
@@ -29,8 +31,10 @@ type Usecase struct {
 
 func NewUsecase(
 	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
 	workspaceService workspace.WorkspaceService,
 	finderService workspace.FinderService,
+	indexService workspace.IndexService,
 	overlayService overlay.OverlayService,
 	scriptService script.ScriptService,
 	hookService hook.HookService,
@@ -39,8 +43,10 @@ func NewUsecase(
 ) *Usecase {
 	return &Usecase{
 		hostingService:   hostingService,
+		providerService:  providerService,
 		workspaceService: workspaceService,
 		finderService:    finderService,
+		indexService:     indexService,
 		overlayService:   overlayService,
 		scriptService:    scriptService,
 		hookService:      hookService,
@@ -68,7 +74,7 @@ func (uc *Usecase) Execute(
 	if err != nil {
 		return fmt.Errorf("invalid reference: %w", err)
 	}
-	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.workspaceService, uc.overlayService, uc.gitService)
+	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.providerService, uc.workspaceService, uc.indexService, uc.overlayService, uc.gitService)
 	repo, err := uc.hostingService.CreateRepositoryFromTemplate(ctx, ref.Reference, tmp, opts.RepositoryOptions)
 	if err != nil {
 		return fmt.Errorf("creating repository from template: %w", err)
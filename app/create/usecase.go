@@ -18,8 +18,10 @@ import (
 // Usecase represents the create use case
 type Usecase struct {
 	hostingService   hosting.HostingService
+	providerService  hosting.ProviderService
 	workspaceService workspace.WorkspaceService
 	finderService    workspace.FinderService
+	indexService     workspace.IndexService
 	overlayService   overlay.OverlayService
 	scriptService    script.ScriptService
 	hookService      hook.HookService
@@ -29,8 +31,10 @@ type Usecase struct {
 
 func NewUsecase(
 	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
 	workspaceService workspace.WorkspaceService,
 	finderService workspace.FinderService,
+	indexService workspace.IndexService,
 	overlayService overlay.OverlayService,
 	scriptService script.ScriptService,
 	hookService hook.HookService,
@@ -39,8 +43,10 @@ func NewUsecase(
 ) *Usecase {
 	return &Usecase{
 		hostingService:   hostingService,
+		providerService:  providerService,
 		workspaceService: workspaceService,
 		finderService:    finderService,
+		indexService:     indexService,
 		overlayService:   overlayService,
 		scriptService:    scriptService,
 		hookService:      hookService,
@@ -65,7 +71,7 @@ func (uc *Usecase) Execute(ctx context.Context, refWithAlias string, opts Option
 	if err != nil {
 		return fmt.Errorf("invalid ref: %w", err)
 	}
-	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.workspaceService, uc.overlayService, uc.gitService)
+	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.providerService, uc.workspaceService, uc.indexService, uc.overlayService, uc.gitService)
 	repo, err := uc.hostingService.CreateRepository(ctx, ref.Reference, opts.RepositoryOptions)
 	if err != nil {
 		return fmt.Errorf("creating: %w", err)
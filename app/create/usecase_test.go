@@ -23,6 +23,7 @@ import (
 	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/repository_mock"
 	"github.com/kyoh86/gogh/v4/core/script_mock"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/core/workspace_mock"
 	"go.uber.org/mock/gomock"
 )
@@ -98,8 +99,9 @@ func TestUsecase_Execute(t *testing.T) {
 
 				pseudoPath := filepath.Join(tmpDir, "github.com/kyoh86/new-repo")
 				// Get primary layout to clone into
+				mockLayout.EXPECT().CanHold(ref).Return(true)
 				mockLayout.EXPECT().PathFor(ref).Return(pseudoPath)
-				mockWorkspace.EXPECT().GetPrimaryLayout().Return(mockLayout)
+				mockWorkspace.EXPECT().GetLayoutForReference(gomock.Any()).Return(mockLayout)
 
 				// Set expectations for GitService.Clone
 				mockGit.EXPECT().AuthenticateWithUsernamePassword(gomock.Any(), "kyoh86", "").Return(mockGit, nil)
@@ -216,6 +218,8 @@ func TestUsecase_Execute(t *testing.T) {
 
 			// Create mocks
 			mockHosting := hosting_mock.NewMockHostingService(ctrl)
+			mockProvider := hosting_mock.NewMockProviderService(ctrl)
+			mockProvider.EXPECT().Resolve(gomock.Any()).DoAndReturn(hosting.GuessProvider).AnyTimes()
 			mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
 			mockFinder := workspace_mock.NewMockFinderService(ctrl)
 			mockLayout := workspace_mock.NewMockLayoutService(ctrl)
@@ -229,7 +233,7 @@ func TestUsecase_Execute(t *testing.T) {
 			tt.setupMocks(mockHosting, mockWorkspace, mockFinder, mockLayout, mockOverlay, mockScript, mockHook, mockRefParser, mockGit)
 
 			// Create Usecase to test
-			usecase := testtarget.NewUsecase(mockHosting, mockWorkspace, mockFinder, mockOverlay, mockScript, mockHook, mockRefParser, mockGit)
+			usecase := testtarget.NewUsecase(mockHosting, mockProvider, mockWorkspace, mockFinder, workspace.NewIndexService(), mockOverlay, mockScript, mockHook, mockRefParser, mockGit)
 
 			// Execute test
 			err := usecase.Execute(context.Background(), tt.refWithAlias, tt.options)
@@ -2,6 +2,7 @@ package cwd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/kyoh86/gogh/v4/core/workspace"
 )
 
+// ErrNotInRepository is returned when the working directory is not in any local repository
+var ErrNotInRepository = errors.New("not in a local repository")
+
 // Usecase defines the use case for listing repository locations
 type Usecase struct {
 	workspaceService workspace.WorkspaceService
@@ -32,5 +36,9 @@ func (uc *Usecase) Execute(ctx context.Context) (*repository.Location, error) {
 	if err != nil {
 		return nil, fmt.Errorf("getting working directory: %w", err)
 	}
-	return uc.finderService.FindByPath(ctx, uc.workspaceService, wd)
+	location, err := uc.finderService.FindByPath(ctx, uc.workspaceService, wd)
+	if errors.Is(err, workspace.ErrNotMatched) {
+		return nil, fmt.Errorf("%w: %s", ErrNotInRepository, wd)
+	}
+	return location, err
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyoh86/gogh/v4/app/cwd"
 	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/core/workspace_mock"
 	"go.uber.org/mock/gomock"
 )
@@ -112,6 +113,28 @@ func TestUsecase_Execute(t *testing.T) {
 		}
 	})
 
+	t.Run("Error: Not in any repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockWorkspaceService := workspace_mock.NewMockWorkspaceService(ctrl)
+		mockFinderService := workspace_mock.NewMockFinderService(ctrl)
+
+		mockFinderService.EXPECT().
+			FindByPath(ctx, mockWorkspaceService, gomock.Any()).
+			Return(nil, workspace.ErrNotMatched)
+
+		uc := cwd.NewUsecase(mockWorkspaceService, mockFinderService)
+		result, err := uc.Execute(ctx)
+
+		if !errors.Is(err, cwd.ErrNotInRepository) {
+			t.Errorf("expected error %v, got %v", cwd.ErrNotInRepository, err)
+		}
+		if result != nil {
+			t.Errorf("expected nil result, got %v", result)
+		}
+	})
+
 	t.Run("Error: FindByPath fails", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -2,6 +2,7 @@ package delete
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -14,6 +15,8 @@ import (
 type Usecase struct {
 	workspaceService workspace.WorkspaceService
 	finderService    workspace.FinderService
+	indexService     workspace.IndexService
+	tagService       workspace.TagService
 	hostingService   hosting.HostingService
 	referenceParser  repository.ReferenceParser
 }
@@ -22,12 +25,16 @@ type Usecase struct {
 func NewUsecase(
 	workspaceService workspace.WorkspaceService,
 	finderService workspace.FinderService,
+	indexService workspace.IndexService,
+	tagService workspace.TagService,
 	hostingService hosting.HostingService,
 	referenceParser repository.ReferenceParser,
 ) *Usecase {
 	return &Usecase{
 		workspaceService: workspaceService,
 		finderService:    finderService,
+		indexService:     indexService,
+		tagService:       tagService,
 		hostingService:   hostingService,
 		referenceParser:  referenceParser,
 	}
@@ -65,7 +72,24 @@ func (uc *Usecase) deleteLocal(ctx context.Context, ref repository.Reference, op
 	if match == nil {
 		return nil
 	}
-	return os.RemoveAll(match.FullPath())
+	return uc.DeleteLocation(ctx, match)
+}
+
+// DeleteLocation deletes the local repository at the location.
+// The tags of the repository are removed if no other clone of it remains in the roots.
+func (uc *Usecase) DeleteLocation(ctx context.Context, location *repository.Location) error {
+	if err := os.RemoveAll(location.FullPath()); err != nil {
+		return err
+	}
+	uc.indexService.Remove(location.FullPath())
+	other, err := uc.finderService.FindByReference(ctx, uc.workspaceService, location.Ref())
+	switch {
+	case errors.Is(err, workspace.ErrNotMatched) || (err == nil && other == nil):
+		uc.tagService.Clear(location.Ref())
+	case err != nil:
+		return fmt.Errorf("finding other clones: %w", err)
+	}
+	return nil
 }
 
 func (uc *Usecase) deleteRemote(ctx context.Context, ref repository.Reference, opts Options) error {
@@ -3,12 +3,14 @@ package delete_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 
 	"github.com/kyoh86/gogh/v4/app/delete"
 	"github.com/kyoh86/gogh/v4/core/hosting_mock"
 	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/repository_mock"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/core/workspace_mock"
 	"go.uber.org/mock/gomock"
 )
@@ -40,6 +42,10 @@ func TestUsecase_Execute(t *testing.T) {
 				mfs.EXPECT().
 					FindByReference(gomock.Any(), mws, ref).
 					Return(match, nil)
+				// Find other clones after deleting
+				mfs.EXPECT().
+					FindByReference(gomock.Any(), mws, ref).
+					Return(nil, nil)
 
 				// Not expecting a call to DeleteRepository since Remote is false
 			},
@@ -85,6 +91,10 @@ func TestUsecase_Execute(t *testing.T) {
 				mfs.EXPECT().
 					FindByReference(gomock.Any(), mws, ref).
 					Return(match, nil)
+				// Find other clones after deleting
+				mfs.EXPECT().
+					FindByReference(gomock.Any(), mws, ref).
+					Return(nil, nil)
 
 				mhs.EXPECT().
 					DeleteRepository(gomock.Any(), ref).
@@ -186,6 +196,8 @@ func TestUsecase_Execute(t *testing.T) {
 			usecase := delete.NewUsecase(
 				mockWorkspaceService,
 				mockFinderService,
+				workspace.NewIndexService(),
+				workspace.NewTagService(),
 				mockHostingService,
 				mockReferenceParser,
 			)
@@ -205,3 +217,55 @@ func TestUsecase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestUsecase_DeleteLocation(t *testing.T) {
+	ref := repository.NewReference("github.com", "user", "repo")
+	location := repository.NewLocation("/path/to/repo", "github.com", "user", "repo")
+
+	for _, tc := range []struct {
+		name     string
+		other    *repository.Location
+		err      error
+		wantTags []string
+	}{
+		{
+			name:     "clear tags of the deleted repository",
+			other:    nil,
+			err:      workspace.ErrNotMatched,
+			wantTags: nil,
+		},
+		{
+			name:     "keep tags while another clone remains",
+			other:    repository.NewLocation("/another/root/repo", "github.com", "user", "repo"),
+			wantTags: []string{"billing"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockWorkspaceService := workspace_mock.NewMockWorkspaceService(ctrl)
+			mockFinderService := workspace_mock.NewMockFinderService(ctrl)
+			mockFinderService.EXPECT().
+				FindByReference(gomock.Any(), mockWorkspaceService, ref).
+				Return(tc.other, tc.err)
+
+			tagService := workspace.NewTagService()
+			if err := tagService.Add(ref, "billing"); err != nil {
+				t.Fatalf("Failed to add tags: %v", err)
+			}
+			usecase := delete.NewUsecase(
+				mockWorkspaceService,
+				mockFinderService,
+				workspace.NewIndexService(),
+				tagService,
+				hosting_mock.NewMockHostingService(ctrl),
+				repository_mock.NewMockReferenceParser(ctrl),
+			)
+			if err := usecase.DeleteLocation(context.Background(), location); err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if got := tagService.Tags(ref); !slices.Equal(got, tc.wantTags) {
+				t.Errorf("Expected tags %v but got %v", tc.wantTags, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"iter"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/kyoh86/gogh/v4/app/move"
+	"github.com/kyoh86/gogh/v4/core/extra"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// ErrCannotFix is returned when the problem cannot be fixed automatically
+var ErrCannotFix = errors.New("cannot be fixed automatically")
+
+// ProblemKind is a kind of the problem found in the roots
+type ProblemKind string
+
+const (
+	// ProblemEmptyDirectory is an empty directory shallower than the repositories
+	// (e.g. an owner or a host directory left after deleting the repositories)
+	ProblemEmptyDirectory ProblemKind = "empty directory"
+	// ProblemIncompleteClone is a directory at the repository depth left by a failed clone:
+	// it is empty, or its ".git" does not have "HEAD"
+	ProblemIncompleteClone ProblemKind = "incomplete clone"
+	// ProblemNotRepository is a directory at the repository depth which is not a git repository.
+	// It is not fixed automatically because it may have files of the user.
+	ProblemNotRepository ProblemKind = "not a repository"
+	// ProblemRemoteMismatch is a clone whose default remote does not match its layout path
+	ProblemRemoteMismatch ProblemKind = "remote mismatch"
+)
+
+// Problem is a problem found in the roots
+type Problem struct {
+	Kind ProblemKind
+	// Path is the full path of the directory
+	Path string
+	// Detail describes the problem
+	Detail string
+	// Remote is the reference of the default remote for ProblemRemoteMismatch.
+	// It is nil if the remote cannot be resolved.
+	Remote *repository.Reference
+	// Location is the location of the clone in the layout for ProblemRemoteMismatch
+	Location *repository.Location
+}
+
+// Usecase defines the use case for finding and fixing problems in the workspace roots
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	indexService     workspace.IndexService
+	tagService       workspace.TagService
+	hostingService   hosting.HostingService
+	gitService       git.GitService
+	extraService     extra.ExtraService
+	referenceParser  repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	indexService workspace.IndexService,
+	tagService workspace.TagService,
+	hostingService hosting.HostingService,
+	gitService git.GitService,
+	extraService extra.ExtraService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		indexService:     indexService,
+		tagService:       tagService,
+		hostingService:   hostingService,
+		gitService:       gitService,
+		extraService:     extraService,
+		referenceParser:  referenceParser,
+	}
+}
+
+// Execute walks the roots and yields the problems found in them
+func (uc *Usecase) Execute(ctx context.Context) iter.Seq2[*Problem, error] {
+	return func(yield func(*Problem, error) bool) {
+		for _, root := range uc.workspaceService.GetRoots() {
+			if !uc.checkRoot(ctx, uc.workspaceService.GetLayoutFor(root), yield) {
+				return
+			}
+		}
+	}
+}
+
+// checkRoot walks the root and yields the problems.
+// It returns false if the yield requests to stop or an error occurs.
+func (uc *Usecase) checkRoot(ctx context.Context, layout workspace.LayoutService, yield func(*Problem, error) bool) bool {
+	root := layout.GetRoot()
+	stopped := false
+	report := func(problem *Problem) error {
+		if !yield(problem, nil) {
+			stopped = true
+			return filepath.SkipAll
+		}
+		return nil
+	}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if path == root || !d.IsDir() {
+			return nil
+		}
+		ref, err := layout.ExactMatch(path)
+		if errors.Is(err, workspace.ErrNotMatched) {
+			if _, err := layout.Match(path); !errors.Is(err, workspace.ErrNotMatched) {
+				// Ignore directories deeper than the layout
+				return filepath.SkipDir
+			}
+			if empty, err := isEmpty(path); err != nil {
+				return err
+			} else if empty {
+				// It may be removed by the yield
+				if err := report(&Problem{Kind: ProblemEmptyDirectory, Path: path, Detail: "no repository in it"}); err != nil {
+					return err
+				}
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		problem, descend, err := uc.checkRepository(ctx, path, *ref)
+		if err != nil {
+			return err
+		}
+		if problem != nil {
+			if err := report(problem); err != nil {
+				return err
+			}
+			return filepath.SkipDir
+		}
+		if descend {
+			// A namespace for the repositories with a multi-segment owner
+			return nil
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		yield(nil, fmt.Errorf("checking %s: %w", root, err))
+		return false
+	}
+	return !stopped
+}
+
+// checkRepository checks the directory at the repository depth.
+// It returns true if the directory is a namespace to walk into.
+func (uc *Usecase) checkRepository(ctx context.Context, path string, ref repository.Reference) (*Problem, bool, error) {
+	if _, err := os.Lstat(filepath.Join(path, ".git")); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, false, err
+		}
+		empty, err := isEmpty(path)
+		if err != nil {
+			return nil, false, err
+		}
+		if empty {
+			return &Problem{Kind: ProblemIncompleteClone, Path: path, Detail: "empty directory"}, false, nil
+		}
+		namespace, err := hasRepository(path)
+		if err != nil || namespace {
+			return nil, namespace, err
+		}
+		return &Problem{Kind: ProblemNotRepository, Path: path, Detail: "no .git in it"}, false, nil
+	}
+	if _, err := os.Stat(filepath.Join(path, ".git", "HEAD")); err != nil {
+		if !os.IsNotExist(err) {
+			return nil, false, err
+		}
+		if info, err := os.Stat(filepath.Join(path, ".git")); err == nil && info.IsDir() {
+			return &Problem{Kind: ProblemIncompleteClone, Path: path, Detail: "no HEAD in .git"}, false, nil
+		}
+		// ".git" may be a file for a worktree or a submodule
+	}
+	problem, err := uc.checkRemote(ctx, path, ref)
+	return problem, false, err
+}
+
+// checkRemote checks that any default remote points to the host of the layout path,
+// in the same way as "bundle dump" finds the remote of the repository.
+func (uc *Usecase) checkRemote(ctx context.Context, path string, ref repository.Reference) (*Problem, error) {
+	location := repository.NewLocation(path, ref.Host(), ref.Owner(), ref.Name())
+	remotes, err := uc.gitService.GetDefaultRemotes(ctx, path)
+	if err != nil {
+		return &Problem{Kind: ProblemRemoteMismatch, Path: path, Detail: fmt.Sprintf("failed to get the default remotes: %v", err), Location: location}, nil
+	}
+	if len(remotes) == 0 {
+		return &Problem{Kind: ProblemRemoteMismatch, Path: path, Detail: "no default remote", Location: location}, nil
+	}
+	for _, remote := range remotes {
+		u, err := url.Parse(git.ToHTTPSURL(remote))
+		if err == nil && u.Host == ref.Host() {
+			return nil, nil
+		}
+	}
+	problem := &Problem{Kind: ProblemRemoteMismatch, Path: path, Detail: fmt.Sprintf("remote %s is not on %s", remotes[0], ref.Host()), Location: location}
+	if u, err := url.Parse(git.ToHTTPSURL(remotes[0])); err == nil {
+		if remote, err := uc.hostingService.ParseURL(u); err == nil {
+			problem.Remote = remote
+		}
+	}
+	return problem, nil
+}
+
+// Fix fixes the problem.
+// It removes the directories for ProblemEmptyDirectory (if it is still empty) and ProblemIncompleteClone,
+// and moves the clone into the layout path of its remote for ProblemRemoteMismatch like "gogh move".
+// It returns ErrCannotFix for ProblemNotRepository.
+func (uc *Usecase) Fix(ctx context.Context, problem *Problem) error {
+	switch problem.Kind {
+	case ProblemEmptyDirectory:
+		// It may have been filled after it is found (e.g. by fixing other problems)
+		if empty, err := isEmpty(problem.Path); err != nil || !empty {
+			return err
+		}
+		if err := os.Remove(problem.Path); err != nil {
+			return err
+		}
+		return uc.removeEmptyParents(problem.Path)
+	case ProblemIncompleteClone:
+		if err := os.RemoveAll(problem.Path); err != nil {
+			return err
+		}
+		uc.indexService.Remove(problem.Path)
+		return uc.removeEmptyParents(problem.Path)
+	case ProblemNotRepository:
+		return fmt.Errorf("%w: remove it by yourself if it is not needed", ErrCannotFix)
+	case ProblemRemoteMismatch:
+		if problem.Remote == nil || problem.Location == nil {
+			return fmt.Errorf("%w: %s", ErrCannotFix, problem.Detail)
+		}
+		// The remote is correct: keep it even if the owner or the name is changed
+		if _, err := move.NewUsecase(
+			uc.workspaceService,
+			uc.finderService,
+			uc.indexService,
+			uc.tagService,
+			uc.gitService,
+			uc.extraService,
+			uc.referenceParser,
+		).MoveLocation(ctx, problem.Location, *problem.Remote, move.Options{KeepRemotes: true}); err != nil {
+			return err
+		}
+		return uc.removeEmptyParents(problem.Path)
+	default:
+		return fmt.Errorf("%w: unknown problem %q", ErrCannotFix, problem.Kind)
+	}
+}
+
+// removeEmptyParents removes the empty parent directories of the path in the root
+func (uc *Usecase) removeEmptyParents(path string) error {
+	var root string
+	for _, r := range uc.workspaceService.GetRoots() {
+		if rel, err := filepath.Rel(r, path); err == nil && filepath.IsLocal(rel) {
+			root = r
+			break
+		}
+	}
+	if root == "" {
+		return nil
+	}
+	for dir := filepath.Dir(path); dir != root && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		empty, err := isEmpty(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		if !empty {
+			return nil
+		}
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isEmpty returns whether the directory has no entry
+func isEmpty(path string) (bool, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
+// hasRepository returns whether any git repository is found under the directory
+func hasRepository(path string) (bool, error) {
+	found := false
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found, err
+}
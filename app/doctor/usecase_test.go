@@ -0,0 +1,226 @@
+package doctor_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/doctor"
+	"github.com/kyoh86/gogh/v4/core/extra_mock"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+// layout is a simple layout placing repositories in "<root>/<host>/<owner>/<name>"
+type layout struct {
+	root string
+}
+
+func (l *layout) GetRoot() string { return l.root }
+
+func (l *layout) split(path string) []string {
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil
+	}
+	return strings.Split(filepath.ToSlash(rel), "/")
+}
+
+func (l *layout) Match(path string) (*repository.Reference, error) {
+	parts := l.split(path)
+	if len(parts) < 3 {
+		return nil, workspace.ErrNotMatched
+	}
+	ref := repository.NewReference(parts[0], parts[1], parts[2])
+	return &ref, nil
+}
+
+func (l *layout) ExactMatch(path string) (*repository.Reference, error) {
+	if len(l.split(path)) != 3 {
+		return nil, workspace.ErrNotMatched
+	}
+	return l.Match(path)
+}
+
+func (l *layout) CanHold(repository.Reference) bool {
+	return true
+}
+
+func (l *layout) PathFor(ref repository.Reference) string {
+	return filepath.Join(l.root, ref.Host(), ref.Owner(), ref.Name())
+}
+
+func (l *layout) CreateRepositoryFolder(ref repository.Reference) (string, error) {
+	return l.PathFor(ref), nil
+}
+
+func (l *layout) DeleteRepository(ref repository.Reference) error {
+	return nil
+}
+
+func mkdir(t *testing.T, path ...string) string {
+	t.Helper()
+	p := filepath.Join(path...)
+	if err := os.MkdirAll(p, 0o755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	return p
+}
+
+func mkRepository(t *testing.T, path ...string) string {
+	t.Helper()
+	p := mkdir(t, path...)
+	mkdir(t, p, ".git")
+	if err := os.WriteFile(filepath.Join(p, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatalf("failed to create HEAD: %v", err)
+	}
+	return p
+}
+
+func setup(t *testing.T, remotes map[string][]string) (string, workspace.TagService, *extra_mock.MockExtraService, *testtarget.Usecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	root := t.TempDir()
+	l := &layout{root: root}
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockWorkspace.EXPECT().GetRoots().Return([]workspace.Root{root}).AnyTimes()
+	mockWorkspace.EXPECT().GetLayoutFor(root).Return(l).AnyTimes()
+	mockWorkspace.EXPECT().GetLayoutForReference(gomock.Any()).Return(l).AnyTimes()
+
+	mockGit := git_mock.NewMockGitService(ctrl)
+	mockGit.EXPECT().GetDefaultRemotes(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, path string) ([]string, error) {
+		rel, _ := filepath.Rel(root, path)
+		return remotes[filepath.ToSlash(rel)], nil
+	}).AnyTimes()
+
+	mockHosting := hosting_mock.NewMockHostingService(ctrl)
+	mockHosting.EXPECT().ParseURL(gomock.Any()).DoAndReturn(func(u *url.URL) (*repository.Reference, error) {
+		parts := strings.Split(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), "/")
+		ref := repository.NewReference(u.Host, parts[0], parts[1])
+		return &ref, nil
+	}).AnyTimes()
+
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockFinder.EXPECT().FindByReference(gomock.Any(), mockWorkspace, gomock.Any()).Return(nil, workspace.ErrNotMatched).AnyTimes()
+
+	tags := workspace.NewTagService()
+	mockExtra := extra_mock.NewMockExtraService(ctrl)
+	return root, tags, mockExtra, testtarget.NewUsecase(
+		mockWorkspace,
+		mockFinder,
+		workspace.NewIndexService(),
+		tags,
+		mockHosting,
+		mockGit,
+		mockExtra,
+		repository.NewReferenceParser("github.com", "kyoh86"),
+	)
+}
+
+func TestUsecase(t *testing.T) {
+	ctx := context.Background()
+	root, tags, mockExtra, uc := setup(t, map[string][]string{
+		"github.com/kyoh86/gogh":  {"https://github.com/kyoh86/gogh"},
+		"github.com/kyoh86/alias": {"git@github.com:kyoh86/original.git"},
+		"github.com/kyoh86/moved": {"https://gitlab.com/kyoh86/moved"},
+		"gitlab.com/kyoh86/moved": {"https://gitlab.com/kyoh86/moved"},
+	})
+	mkRepository(t, root, "github.com", "kyoh86", "gogh")
+	mkRepository(t, root, "github.com", "kyoh86", "alias")
+	mkRepository(t, root, "github.com", "kyoh86", "moved")
+	mkRepository(t, root, "github.com", "kyoh86", "no-remote")
+	mkdir(t, root, "github.com", "kyoh86", "failed")
+	mkdir(t, root, "github.com", "kyoh86", "broken", ".git")
+	mkdir(t, root, "github.com", "kyoh86", "junk", "files")
+	mkdir(t, root, "github.com", "deleted")
+	mkdir(t, root, "gitlab.com")
+	if err := os.WriteFile(filepath.Join(root, "github.com", "kyoh86", "junk", "files", "memo.txt"), []byte("memo"), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	mismatched := repository.NewReference("github.com", "kyoh86", "moved")
+	remote := repository.NewReference("gitlab.com", "kyoh86", "moved")
+	if err := tags.Add(mismatched, "work"); err != nil {
+		t.Fatalf("failed to add tags: %v", err)
+	}
+	mockExtra.EXPECT().MoveAutoExtra(gomock.Any(), mismatched, remote).Return(nil)
+
+	var problems []*testtarget.Problem
+	for problem, err := range uc.Execute(ctx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		problems = append(problems, problem)
+	}
+	var got []string
+	for _, problem := range problems {
+		rel, _ := filepath.Rel(root, problem.Path)
+		got = append(got, string(problem.Kind)+" "+filepath.ToSlash(rel))
+	}
+	want := []string{
+		"empty directory github.com/deleted",
+		"incomplete clone github.com/kyoh86/broken",
+		"incomplete clone github.com/kyoh86/failed",
+		"not a repository github.com/kyoh86/junk",
+		"remote mismatch github.com/kyoh86/moved",
+		"remote mismatch github.com/kyoh86/no-remote",
+		"empty directory gitlab.com",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected problems:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	for _, problem := range problems {
+		err := uc.Fix(ctx, problem)
+		if (problem.Remote == nil && problem.Kind == testtarget.ProblemRemoteMismatch) || problem.Kind == testtarget.ProblemNotRepository {
+			if !errors.Is(err, testtarget.ErrCannotFix) {
+				t.Errorf("expected ErrCannotFix for %s, got %v", problem.Path, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error to fix %s: %v", problem.Path, err)
+		}
+		if problem.Path == filepath.Join(root, "gitlab.com") {
+			// It is not empty anymore: the mismatched clone has been moved into it
+			continue
+		}
+		if _, err := os.Stat(problem.Path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, got %v", problem.Path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "gitlab.com", "kyoh86", "moved", ".git")); err != nil {
+		t.Errorf("expected the mismatched clone to be moved: %v", err)
+	}
+	if got := tags.Tags(remote); len(got) != 1 || got[0] != "work" {
+		t.Errorf("expected the tags to be moved to %s, got %v", remote, got)
+	}
+	if got := tags.Tags(mismatched); len(got) != 0 {
+		t.Errorf("expected no tags for %s, got %v", mismatched, got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "github.com", "kyoh86", "junk", "files", "memo.txt")); err != nil {
+		t.Errorf("expected the files which are not in a repository to be kept: %v", err)
+	}
+
+	var remaining []string
+	for problem, err := range uc.Execute(ctx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		remaining = append(remaining, problem.Path)
+	}
+	if want := []string{
+		filepath.Join(root, "github.com", "kyoh86", "junk"),
+		filepath.Join(root, "github.com", "kyoh86", "no-remote"),
+	}; strings.Join(remaining, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected only the junk and the clone without remote to remain, got %v", remaining)
+	}
+}
@@ -60,7 +60,8 @@ func (uc *Usecase) Execute(ctx context.Context, opts Options) iter.Seq2[*BundleE
 			}
 
 			for _, remote := range remotes {
-				uobj, err := url.Parse(remote)
+				// SSH remotes (e.g. "git@github.com:kyoh86/gogh.git") cannot be parsed as URLs
+				uobj, err := url.Parse(git.ToHTTPSURL(remote))
 				if err != nil {
 					yield(nil, err)
 					return
@@ -60,6 +60,30 @@ func TestExecute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Success: When the remote uses SSH",
+			setupMocks: func(finder *workspace_mock.MockFinderService, ws *workspace_mock.MockWorkspaceService, hosting *hosting_mock.MockHostingService, git *git_mock.MockGitService) {
+				loc := repository.NewLocation("/path/to/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh")
+				finder.EXPECT().
+					ListAllRepository(gomock.Any(), ws, gomock.Any()).
+					Return(func(yield func(*repository.Location, error) bool) {
+						yield(loc, nil)
+					})
+				git.EXPECT().
+					GetDefaultRemotes(gomock.Any(), "/path/to/github.com/kyoh86/gogh").
+					Return([]string{"git@github.com:kyoh86/gogh.git"}, nil)
+				expectedURL, _ := url.Parse("https://github.com/kyoh86/gogh.git")
+				ref := repository.NewReference("github.com", "kyoh86", "gogh")
+				hosting.EXPECT().
+					ParseURL(gomock.Eq(expectedURL)).
+					Return(&ref, nil)
+			},
+			options:       workspace.ListOptions{},
+			expectedCount: 1,
+			expectedEntries: []*testtarget.BundleEntry{
+				{Name: "github.com/kyoh86/gogh"},
+			},
+		},
 		{
 			name: "Success: When remote name differs from local path",
 			setupMocks: func(finder *workspace_mock.MockFinderService, ws *workspace_mock.MockWorkspaceService, hosting *hosting_mock.MockHostingService, git *git_mock.MockGitService) {
@@ -18,8 +18,10 @@ import (
 // Usecase represents the fork use case
 type Usecase struct {
 	hostingService     hosting.HostingService
+	providerService    hosting.ProviderService
 	workspaceService   workspace.WorkspaceService
 	finderService      workspace.FinderService
+	indexService       workspace.IndexService
 	overlayService     overlay.OverlayService
 	scriptService      script.ScriptService
 	hookService        hook.HookService
@@ -31,8 +33,10 @@ type Usecase struct {
 // NewUsecase creates a new fork use case
 func NewUsecase(
 	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
 	workspaceService workspace.WorkspaceService,
 	finderService workspace.FinderService,
+	indexService workspace.IndexService,
 	overlayService overlay.OverlayService,
 	scriptService script.ScriptService,
 	hookService hook.HookService,
@@ -42,8 +46,10 @@ func NewUsecase(
 ) *Usecase {
 	return &Usecase{
 		hostingService:     hostingService,
+		providerService:    providerService,
 		workspaceService:   workspaceService,
 		finderService:      finderService,
+		indexService:       indexService,
 		overlayService:     overlayService,
 		scriptService:      scriptService,
 		hookService:        hookService,
@@ -91,6 +97,13 @@ func (uc *Usecase) parseRefs(source, target string) (*repository.Reference, *rep
 	return srcRef, toRef, nil
 }
 
+// Target returns the reference of the forked repository (with the alias for the local repository)
+// for the source and the target given as Options.Target
+func (uc *Usecase) Target(source, target string) (*repository.ReferenceWithAlias, error) {
+	_, toRef, err := uc.parseRefs(source, target)
+	return toRef, err
+}
+
 // Execute forks a repository and clones it to the local machine
 func (uc *Usecase) Execute(ctx context.Context, source string, opts Options) error {
 	ref, targetRef, err := uc.parseRefs(source, opts.Target)
@@ -101,7 +114,7 @@ func (uc *Usecase) Execute(ctx context.Context, source string, opts Options) err
 	if err != nil {
 		return fmt.Errorf("requesting fork: %w", err)
 	}
-	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.workspaceService, uc.overlayService, uc.gitService)
+	tryCloneUsecase := try.NewUsecase(uc.hostingService, uc.providerService, uc.workspaceService, uc.indexService, uc.overlayService, uc.gitService)
 	if err := tryCloneUsecase.Execute(ctx, fork, targetRef.Alias, opts.TryCloneOptions); err != nil {
 		return err
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/repository_mock"
 	"github.com/kyoh86/gogh/v4/core/script_mock"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/core/workspace_mock"
 	"go.uber.org/mock/gomock"
 )
@@ -98,11 +99,14 @@ func TestUsecase_Execute(t *testing.T) {
 					Return(nil)
 
 				pseudoPath := filepath.Join(tmpDir, "github.com/target/repo")
+				mockLayout.EXPECT().
+					CanHold(targetRef).
+					Return(true)
 				mockLayout.EXPECT().
 					PathFor(targetRef).
 					Return(pseudoPath)
 				mockWorkspace.EXPECT().
-					GetPrimaryLayout().
+					GetLayoutForReference(gomock.Any()).
 					Return(mockLayout)
 
 				// Hook finds the repository by reference
@@ -221,11 +225,14 @@ func TestUsecase_Execute(t *testing.T) {
 					Return(nil)
 
 				pseudoPath := filepath.Join(tmpDir, "github.com/target/repo")
+				mockLayout.EXPECT().
+					CanHold(defaultRef).
+					Return(true)
 				mockLayout.EXPECT().
 					PathFor(defaultRef).
 					Return(pseudoPath)
 				mockWorkspace.EXPECT().
-					GetPrimaryLayout().
+					GetLayoutForReference(gomock.Any()).
 					Return(mockLayout)
 
 				// Hook finds the repository by reference
@@ -355,11 +362,14 @@ func TestUsecase_Execute(t *testing.T) {
 					Clone(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("cloning error"))
 
+				mockLayout.EXPECT().
+					CanHold(targetRef).
+					Return(true)
 				mockLayout.EXPECT().
 					PathFor(targetRef).
 					Return("/path/to/repo")
 				mockWorkspace.EXPECT().
-					GetPrimaryLayout().
+					GetLayoutForReference(gomock.Any()).
 					Return(mockLayout)
 			},
 			expectErrText: "cloning forked repository",
@@ -397,10 +407,15 @@ func TestUsecase_Execute(t *testing.T) {
 					mockGitService,
 				)
 
+				mockProviderService := hosting_mock.NewMockProviderService(ctrl)
+				mockProviderService.EXPECT().Resolve(gomock.Any()).DoAndReturn(hosting.GuessProvider).AnyTimes()
+
 				usecase := fork.NewUsecase(
 					mockHostingService,
+					mockProviderService,
 					mockWorkspaceService,
 					mockFinderService,
+					workspace.NewIndexService(),
 					mockOverlayService,
 					mockScriptService,
 					mockHookService,
@@ -435,3 +450,38 @@ func TestUsecase_Execute(t *testing.T) {
 func containsString(s, substr string) bool {
 	return s != "" && substr != "" && s != substr && len(s) > len(substr) && s[len(s)-len(substr):] == substr
 }
+
+func TestUsecase_Target(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDefaultName := repository_mock.NewMockDefaultNameService(ctrl)
+	mockDefaultName.EXPECT().GetDefaultOwnerFor("github.com").Return("kyoh86", nil)
+	usecase := fork.NewUsecase(
+		hosting_mock.NewMockHostingService(ctrl),
+		hosting_mock.NewMockProviderService(ctrl),
+		workspace_mock.NewMockWorkspaceService(ctrl),
+		workspace_mock.NewMockFinderService(ctrl),
+		workspace.NewIndexService(),
+		overlay_mock.NewMockOverlayService(ctrl),
+		script_mock.NewMockScriptService(ctrl),
+		hook_mock.NewMockHookService(ctrl),
+		mockDefaultName,
+		repository.NewReferenceParser("github.com", "kyoh86"),
+		git_mock.NewMockGitService(ctrl),
+	)
+
+	for _, testcase := range []struct {
+		target string
+		want   string
+	}{
+		{target: "", want: "github.com/kyoh86/repo"},
+		{target: "kyoh86-tryouts/repo=sample", want: "github.com/kyoh86-tryouts/repo=github.com/kyoh86-tryouts/sample"},
+	} {
+		got, err := usecase.Target("source/repo", testcase.target)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", testcase.target, err)
+		}
+		if got.String() != testcase.want {
+			t.Errorf("expected %q for %q, got %q", testcase.want, testcase.target, got.String())
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package gc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// Printer prints the candidates of the garbage collection
+type Printer interface {
+	Print(c Candidate) error
+	Close() error
+}
+
+// NewPrinter creates a printer for the format: "table" or "json"
+func NewPrinter(w io.Writer, format string) (Printer, error) {
+	switch format {
+	case "", "table":
+		return &tablePrinter{w: tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)}, nil
+	case "json":
+		return &jsonPrinter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("invalid format: %q", format)
+}
+
+type tablePrinter struct {
+	w *tabwriter.Writer
+}
+
+// Print prints the candidate in a line like below.
+//
+//	github.com/kyoh86/gogh  2024-01-02  12.3MiB  removable
+func (p *tablePrinter) Print(c Candidate) error {
+	if c.Err != nil {
+		_, err := fmt.Fprintf(p.w, "%s\terror: %s\n", c.Location.Path(), c.Err)
+		return err
+	}
+	state := "removable"
+	if !c.Removable() {
+		state = c.Reason
+	}
+	_, err := fmt.Fprintf(p.w, "%s\t%s\t%s\t%s\n", c.Location.Path(), formatDate(c.LastActive()), FormatSize(c.DiskUsage), state)
+	return err
+}
+
+func (p *tablePrinter) Close() error {
+	return p.w.Flush()
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateOnly)
+}
+
+// FormatSize formats the size in bytes in a human readable form (e.g. "12.3MiB")
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+type jsonCandidate struct {
+	FullPath     string     `json:"fullPath"`
+	Path         string     `json:"path"`
+	Host         string     `json:"host"`
+	Owner        string     `json:"owner"`
+	Name         string     `json:"name"`
+	LastCommit   *time.Time `json:"lastCommit,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	DiskUsage    int64      `json:"diskUsage"`
+	Clean        bool       `json:"clean"`
+	Removable    bool       `json:"removable"`
+	Reason       string     `json:"reason,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Print prints the candidate in a line of JSON
+func (p *jsonPrinter) Print(c Candidate) error {
+	v := jsonCandidate{
+		FullPath: c.Location.FullPath(),
+		Path:     c.Location.Path(),
+		Host:     c.Location.Host(),
+		Owner:    c.Location.Owner(),
+		Name:     c.Location.Name(),
+	}
+	if c.Err != nil {
+		v.Error = c.Err.Error()
+	} else {
+		v.LastCommit = timePtr(c.LastCommit())
+		v.LastModified = timePtr(c.LastModified)
+		v.DiskUsage = c.DiskUsage
+		v.Clean = c.Status.Clean()
+		v.Removable = c.Removable()
+		v.Reason = c.Reason
+	}
+	return p.enc.Encode(v)
+}
+
+func (p *jsonPrinter) Close() error {
+	return nil
+}
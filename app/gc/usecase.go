@@ -0,0 +1,255 @@
+package gc
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/app/delete"
+	"github.com/kyoh86/gogh/v4/app/status"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency is the default number of repositories to inspect at once
+const DefaultConcurrency = 8
+
+// DefaultDays is the default number of days which the removable repositories are untouched for
+const DefaultDays = 90
+
+// ErrNotRemovable is returned when the repository is not removable anymore
+var ErrNotRemovable = errors.New("repository is not removable")
+
+// Usecase defines the use case for finding and removing stale local repositories
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	indexService     workspace.IndexService
+	tagService       workspace.TagService
+	hostingService   hosting.HostingService
+	gitService       git.GitService
+	referenceParser  repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	indexService workspace.IndexService,
+	tagService workspace.TagService,
+	hostingService hosting.HostingService,
+	gitService git.GitService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		indexService:     indexService,
+		tagService:       tagService,
+		hostingService:   hostingService,
+		gitService:       gitService,
+		referenceParser:  referenceParser,
+	}
+}
+
+type ListOptions = workspace.ListOptions
+
+// Options defines the options for finding stale repositories
+type Options struct {
+	// Primary finds just the repositories in the primary root
+	Primary bool
+	ListOptions
+	// Concurrency is the maximum number of repositories to inspect at once (default: DefaultConcurrency)
+	Concurrency int
+	// Days is the number of days which the removable repositories are untouched for (default: DefaultDays)
+	Days int
+}
+
+// Candidate is a local repository inspected for the garbage collection
+type Candidate struct {
+	Location *repository.Location
+	Status   *git.Status
+	// LastModified is the latest modification time of the working tree,
+	// the HEAD and the index (updated by the checkout)
+	LastModified time.Time
+	// DiskUsage is the total size of the files in the repository in bytes
+	DiskUsage int64
+	// Reason describes why the repository is not removable (empty if it is removable)
+	Reason string
+	// Err is an error on inspecting the repository
+	Err error
+}
+
+// LastCommit returns the committer time of the HEAD (zero if unknown)
+func (c *Candidate) LastCommit() time.Time {
+	if c.Status == nil {
+		return time.Time{}
+	}
+	return c.Status.HeadTime
+}
+
+// LastActive returns the later one of the LastCommit and the LastModified
+func (c *Candidate) LastActive() time.Time {
+	if last := c.LastCommit(); last.After(c.LastModified) {
+		return last
+	}
+	return c.LastModified
+}
+
+// Removable returns whether the repository is clean, fully pushed and untouched for the days
+func (c *Candidate) Removable() bool {
+	return c.Err == nil && c.Reason == ""
+}
+
+// Execute inspects the local repositories concurrently and returns them
+// ranked from the least recently active one (and the larger one for the same time).
+// Repositories failed to be inspected are placed at the end.
+func (uc *Usecase) Execute(ctx context.Context, opts Options) ([]*Candidate, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	days := opts.Days
+	if days <= 0 {
+		days = DefaultDays
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	var candidates []*Candidate
+	var eg errgroup.Group
+	eg.SetLimit(concurrency)
+	for result, err := range status.NewUsecase(uc.workspaceService, uc.finderService, uc.gitService).Execute(ctx, status.Options{
+		Primary:     opts.Primary,
+		ListOptions: opts.ListOptions,
+		Concurrency: concurrency,
+	}) {
+		if err != nil {
+			_ = eg.Wait()
+			return nil, err
+		}
+		candidate := &Candidate{Location: result.Location, Status: result.Status, Err: result.Err}
+		candidates = append(candidates, candidate)
+		if candidate.Err != nil {
+			continue
+		}
+		eg.Go(func() error {
+			candidate.LastModified, candidate.DiskUsage, candidate.Err = inspect(ctx, candidate.Location.FullPath())
+			if candidate.Err == nil {
+				candidate.Reason = reason(candidate, cutoff)
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *Candidate) int {
+		if (a.Err == nil) != (b.Err == nil) {
+			if a.Err == nil {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(
+			a.LastActive().Compare(b.LastActive()),
+			cmp.Compare(b.DiskUsage, a.DiskUsage),
+		)
+	})
+	return candidates, nil
+}
+
+// reason describes why the repository is not removable
+func reason(c *Candidate, cutoff time.Time) string {
+	s := c.Status
+	switch {
+	case s.Dirty:
+		return "uncommitted changes"
+	case s.Untracked:
+		return "untracked files"
+	case s.Ahead > 0 || len(s.AheadBranches) > 0:
+		return "unpushed commits"
+	case s.Stashes > 0:
+		return "stashes"
+	case len(s.NoUpstreamBranches) > 0:
+		return "branches without upstream"
+	case s.Upstream == "":
+		return "no upstream"
+	case c.LastActive().After(cutoff):
+		return "recently active"
+	}
+	return ""
+}
+
+// inspect walks the repository to get the latest modification time and the disk usage
+func inspect(ctx context.Context, path string) (time.Time, int64, error) {
+	gitDir := filepath.Join(path, ".git")
+	checkout := []string{filepath.Join(gitDir, "HEAD"), filepath.Join(gitDir, "index")}
+	var last time.Time
+	var usage int64
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			usage += info.Size()
+		}
+		// Files in the .git are updated by fetching or so, except for the HEAD and the index
+		if isUnder(gitDir, p) && !slices.Contains(checkout, p) {
+			return nil
+		}
+		if mtime := info.ModTime(); mtime.After(last) {
+			last = mtime
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("inspecting %s: %w", path, err)
+	}
+	return last, usage, nil
+}
+
+// isUnder returns whether the path is the directory or under it
+func isUnder(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// Remove removes the local repository of the candidate.
+// It checks the status again and returns ErrNotRemovable if the repository is not clean anymore.
+func (uc *Usecase) Remove(ctx context.Context, candidate *Candidate) error {
+	if !candidate.Removable() {
+		return fmt.Errorf("%w: %s", ErrNotRemovable, candidate.Reason)
+	}
+	current, err := uc.gitService.GetStatus(ctx, candidate.Location.FullPath())
+	if err != nil {
+		return fmt.Errorf("getting status: %w", err)
+	}
+	if !current.Clean() || current.Head != candidate.Status.Head {
+		return fmt.Errorf("%w: changed after inspected", ErrNotRemovable)
+	}
+	return delete.NewUsecase(
+		uc.workspaceService,
+		uc.finderService,
+		uc.indexService,
+		uc.tagService,
+		uc.hostingService,
+		uc.referenceParser,
+	).DeleteLocation(ctx, candidate.Location)
+}
@@ -0,0 +1,244 @@
+package gc_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/gc"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+// mkRepository creates a repository with a file of the size, and makes all of them modified at the mtime
+func mkRepository(t *testing.T, root, name string, mtime time.Time, size int) *repository.Location {
+	t.Helper()
+	path := filepath.Join(root, "github.com", "kyoh86", name)
+	if err := os.MkdirAll(filepath.Join(path, ".git"), 0o755); err != nil {
+		t.Fatalf("failed to create repository: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o644); err != nil {
+		t.Fatalf("failed to create HEAD: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, "README.md"), bytes.Repeat([]byte("x"), size), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	if err := filepath.WalkDir(path, func(p string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(p, mtime, mtime)
+	}); err != nil {
+		t.Fatalf("failed to change times: %v", err)
+	}
+	return repository.NewLocation(path, "github.com", "kyoh86", name)
+}
+
+func TestUsecase(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	ctrl := gomock.NewController(t)
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	now := time.Now()
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	clean := func(days int) *git.Status {
+		return &git.Status{Branch: "main", Head: "abc", HeadTime: ago(days), Upstream: "origin/main"}
+	}
+	statuses := map[string]*git.Status{
+		"old":         clean(200),
+		"older":       clean(300),
+		"older-small": clean(300),
+		"recent":      clean(200),
+		"committed":   clean(1),
+		"dirty":       {Branch: "main", Head: "abc", Upstream: "origin/main", Dirty: true},
+		"no-upstream": {Branch: "main", Head: "abc"},
+		"unpushed-branch": {
+			Branch: "main", Head: "abc", HeadTime: ago(300), Upstream: "origin/main",
+			AheadBranches: map[string]int{"feature": 1},
+		},
+	}
+	locations := []*repository.Location{
+		mkRepository(t, root, "broken", ago(400), 10),
+		mkRepository(t, root, "old", ago(200), 10),
+		mkRepository(t, root, "older-small", ago(300), 10),
+		mkRepository(t, root, "older", ago(300), 20),
+		mkRepository(t, root, "recent", ago(2), 10),
+		mkRepository(t, root, "committed", ago(200), 10),
+		mkRepository(t, root, "dirty", ago(250), 10),
+		mkRepository(t, root, "no-upstream", ago(250), 10),
+		mkRepository(t, root, "unpushed-branch", ago(260), 10),
+	}
+	mockFinder.EXPECT().ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{}).Return(iter.Seq2[*repository.Location, error](func(yield func(*repository.Location, error) bool) {
+		for _, location := range locations {
+			if !yield(location, nil) {
+				return
+			}
+		}
+	}))
+	mockFinder.EXPECT().FindByReference(gomock.Any(), mockWorkspace, gomock.Any()).Return(nil, nil).AnyTimes()
+	mockGit.EXPECT().GetStatus(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, path string) (*git.Status, error) {
+		status, ok := statuses[filepath.Base(path)]
+		if !ok {
+			return nil, errors.New("not a git repository")
+		}
+		return status, nil
+	}).AnyTimes()
+	uc := testtarget.NewUsecase(
+		mockWorkspace,
+		mockFinder,
+		workspace.NewIndexService(),
+		workspace.NewTagService(),
+		hosting_mock.NewMockHostingService(ctrl),
+		mockGit,
+		repository.NewReferenceParser("github.com", "kyoh86"),
+	)
+
+	candidates, err := uc.Execute(ctx, testtarget.Options{Days: 30, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, c := range candidates {
+		state := "removable"
+		switch {
+		case c.Err != nil:
+			state = "error"
+		case !c.Removable():
+			state = c.Reason
+		}
+		got = append(got, c.Location.Name()+": "+state)
+	}
+	want := []string{
+		"older: removable",
+		"older-small: removable",
+		"unpushed-branch: unpushed commits",
+		"dirty: uncommitted changes",
+		"no-upstream: no upstream",
+		"old: removable",
+		"recent: recently active",
+		"committed: recently active",
+		"broken: error",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+	if candidates[0].DiskUsage != 20+int64(len("ref: refs/heads/main\n")) {
+		t.Errorf("unexpected disk usage: %d", candidates[0].DiskUsage)
+	}
+
+	t.Run("Remove", func(t *testing.T) {
+		if err := uc.Remove(ctx, candidates[0]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Stat(candidates[0].Location.FullPath()); !os.IsNotExist(err) {
+			t.Errorf("expected the repository to be removed: %v", err)
+		}
+	})
+
+	t.Run("NotRemovable", func(t *testing.T) {
+		if err := uc.Remove(ctx, candidates[2]); !errors.Is(err, testtarget.ErrNotRemovable) {
+			t.Errorf("expected ErrNotRemovable, got %v", err)
+		}
+	})
+
+	t.Run("ChangedAfterInspected", func(t *testing.T) {
+		statuses["old"] = &git.Status{Branch: "main", Head: "def", Upstream: "origin/main"}
+		if err := uc.Remove(ctx, candidates[5]); !errors.Is(err, testtarget.ErrNotRemovable) {
+			t.Errorf("expected ErrNotRemovable, got %v", err)
+		}
+		if _, err := os.Stat(candidates[5].Location.FullPath()); err != nil {
+			t.Errorf("expected the repository to be kept: %v", err)
+		}
+	})
+
+	t.Run("BranchAheadAfterInspected", func(t *testing.T) {
+		status := *clean(300)
+		status.AheadBranches = map[string]int{"feature": 2}
+		statuses["older-small"] = &status
+		if err := uc.Remove(ctx, candidates[1]); !errors.Is(err, testtarget.ErrNotRemovable) {
+			t.Errorf("expected ErrNotRemovable, got %v", err)
+		}
+		if _, err := os.Stat(candidates[1].Location.FullPath()); err != nil {
+			t.Errorf("expected the repository to be kept: %v", err)
+		}
+	})
+}
+
+func TestPrinter(t *testing.T) {
+	location := repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh")
+	headTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	candidate := testtarget.Candidate{
+		Location:     location,
+		Status:       &git.Status{Branch: "main", Head: "abc", HeadTime: headTime, Upstream: "origin/main"},
+		LastModified: headTime.Add(-time.Hour),
+		DiskUsage:    12_900_000,
+	}
+	for _, testcase := range []struct {
+		title     string
+		format    string
+		candidate testtarget.Candidate
+		want      string
+	}{
+		{
+			title:     "table",
+			format:    "table",
+			candidate: candidate,
+			want:      "github.com/kyoh86/gogh  " + headTime.Local().Format(time.DateOnly) + "  12.3MiB  removable\n",
+		},
+		{
+			title:     "table error",
+			format:    "",
+			candidate: testtarget.Candidate{Location: location, Err: errors.New("broken")},
+			want:      "github.com/kyoh86/gogh  error: broken\n",
+		},
+		{
+			title:     "json",
+			format:    "json",
+			candidate: candidate,
+			want:      `{"fullPath":"/root/github.com/kyoh86/gogh","path":"github.com/kyoh86/gogh","host":"github.com","owner":"kyoh86","name":"gogh","lastCommit":"2024-01-02T03:04:05Z","lastModified":"2024-01-02T02:04:05Z","diskUsage":12900000,"clean":true,"removable":true}` + "\n",
+		},
+		{
+			title:     "json error",
+			format:    "json",
+			candidate: testtarget.Candidate{Location: location, Err: errors.New("broken")},
+			want:      `{"fullPath":"/root/github.com/kyoh86/gogh","path":"github.com/kyoh86/gogh","host":"github.com","owner":"kyoh86","name":"gogh","diskUsage":0,"clean":false,"removable":false,"error":"broken"}` + "\n",
+		},
+	} {
+		t.Run(testcase.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			printer, err := testtarget.NewPrinter(&buf, testcase.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := printer.Print(testcase.candidate); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := printer.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != testcase.want {
+				t.Errorf("expected %q, got %q", testcase.want, got)
+			}
+		})
+	}
+
+	if _, err := testtarget.NewPrinter(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
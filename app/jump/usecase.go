@@ -0,0 +1,108 @@
+package jump
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for jumping to the frequently and recently visited local repositories
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	usageService     workspace.UsageService
+	referenceParser  repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	usageService workspace.UsageService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		usageService:     usageService,
+		referenceParser:  referenceParser,
+	}
+}
+
+// Location is a local repository to jump to
+type Location = repository.Location
+
+// ListOptions for listing the candidates
+type ListOptions = workspace.ListOptions
+
+// Options for ranking the candidates
+type Options struct {
+	ListOptions
+	// Query narrows down the candidates to the ones
+	// whose path contains all words of it, ignoring the case.
+	// If the query is a reference of a candidate, the candidate comes first.
+	Query string
+}
+
+// Execute lists the local repositories ranked by frecency (see workspace.Usage).
+// Repositories never visited follow them in the order of the listing.
+func (uc *Usecase) Execute(ctx context.Context, opts Options) ([]*repository.Location, error) {
+	type candidate struct {
+		location *repository.Location
+		score    float64
+	}
+	now := time.Now()
+	words := strings.Fields(strings.ToLower(opts.Query))
+	var exact *repository.Reference
+	if len(words) == 1 {
+		// Ignore the error: the query may not be a reference
+		exact, _ = uc.referenceParser.Parse(opts.Query)
+	}
+	var candidates []candidate
+	for location, err := range uc.finderService.ListAllRepository(ctx, uc.workspaceService, opts.ListOptions) {
+		if err != nil {
+			return nil, fmt.Errorf("listing repositories: %w", err)
+		}
+		path := strings.ToLower(location.Path())
+		if !all(words, func(word string) bool { return strings.Contains(path, word) }) {
+			continue
+		}
+		c := candidate{location: location}
+		if usage, ok := uc.usageService.Get(location.Ref()); ok {
+			c.score = usage.Frecency(now)
+		}
+		if exact != nil && location.Ref().String() == exact.String() {
+			c.score = math.Inf(1)
+		}
+		candidates = append(candidates, c)
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(b.score, a.score)
+	})
+	locations := make([]*repository.Location, 0, len(candidates))
+	for _, c := range candidates {
+		locations = append(locations, c.location)
+	}
+	return locations, nil
+}
+
+func all[T any](s []T, f func(T) bool) bool {
+	for _, v := range s {
+		if !f(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Visit records a visit to the repository
+func (uc *Usecase) Visit(location *repository.Location) {
+	uc.usageService.Visit(location.Ref(), time.Now())
+}
@@ -0,0 +1,75 @@
+package jump_test
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/jump"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+
+	var locations []*repository.Location
+	for _, name := range []string{"alpha", "bravo", "charlie", "alphabet"} {
+		locations = append(locations, repository.NewLocation("/root/github.com/kyoh86/"+name, "github.com", "kyoh86", name))
+	}
+	mockFinder.EXPECT().ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{}).Return(iter.Seq2[*repository.Location, error](func(yield func(*repository.Location, error) bool) {
+		for _, location := range locations {
+			if !yield(location, nil) {
+				return
+			}
+		}
+	})).Times(6)
+
+	usageService := workspace.NewUsageService()
+	// "bravo" is visited many times but long ago
+	usageService.Set(locations[1].Ref(), workspace.Usage{Count: 6, LastVisit: time.Now().AddDate(0, -1, 0)})
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, usageService, repository.NewReferenceParser("github.com", "kyoh86"))
+	uc.Visit(locations[3])
+
+	names := func(query string) []string {
+		t.Helper()
+		got, err := uc.Execute(ctx, testtarget.Options{Query: query})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var names []string
+		for _, location := range got {
+			names = append(names, location.Name())
+		}
+		return names
+	}
+	if got, want := names(""), []string{"alphabet", "bravo", "alpha", "charlie"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	uc.Visit(locations[2])
+	uc.Visit(locations[2])
+	if got, want := names(""), []string{"charlie", "alphabet", "bravo", "alpha"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := names("KYOH86 a"), []string{"charlie", "alphabet", "bravo", "alpha"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := names("bet"), []string{"alphabet"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	// "alpha" is not visited, but it is referred by the query exactly
+	if got, want := names("alpha"), []string{"alpha", "alphabet"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := names("kyoh86/bravo"), []string{"bravo"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,272 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/kyoh86/gogh/v4/core/extra"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+var (
+	// ErrDestinationExists is returned when the destination of the repository already exists
+	ErrDestinationExists = errors.New("destination already exists")
+
+	// ErrSameLocation is returned when the destination is same as the current location
+	ErrSameLocation = errors.New("repository is already in the destination")
+)
+
+// Usecase defines the use case for moving a local repository to another root or alias
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	indexService     workspace.IndexService
+	tagService       workspace.TagService
+	gitService       git.GitService
+	extraService     extra.ExtraService
+	referenceParser  repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	indexService workspace.IndexService,
+	tagService workspace.TagService,
+	gitService git.GitService,
+	extraService extra.ExtraService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		indexService:     indexService,
+		tagService:       tagService,
+		gitService:       gitService,
+		extraService:     extraService,
+		referenceParser:  referenceParser,
+	}
+}
+
+// Options defines the options for moving a repository
+type Options struct {
+	// Root is the root to move the repository into.
+	// If it is empty, the root is chosen by the routes (or the primary root).
+	Root string
+	// Alias is the new reference of the repository.
+	// If it is empty, the current reference is kept.
+	Alias string
+	// KeepRemotes keeps the remotes even if the alias changes the owner or the name.
+	KeepRemotes bool
+}
+
+// Execute moves the local repository and returns its new location
+func (uc *Usecase) Execute(ctx context.Context, refs string, opts Options) (*repository.Location, error) {
+	ref, err := uc.referenceParser.Parse(refs)
+	if err != nil {
+		return nil, err
+	}
+	source, err := uc.finderService.FindByReference(ctx, uc.workspaceService, *ref)
+	if err != nil {
+		return nil, fmt.Errorf("finding local repository: %w", err)
+	}
+
+	target := *ref
+	if opts.Alias != "" {
+		alias, err := uc.referenceParser.Parse(opts.Alias)
+		if err != nil {
+			return nil, fmt.Errorf("parsing alias: %w", err)
+		}
+		target = *alias
+	}
+	return uc.MoveLocation(ctx, source, target, opts)
+}
+
+// MoveLocation moves the local repository at the location to the target reference and returns its new location.
+// The Alias of the options is ignored: the target is used instead.
+func (uc *Usecase) MoveLocation(ctx context.Context, source *repository.Location, target repository.Reference, opts Options) (*repository.Location, error) {
+	ref := source.Ref()
+	layout, err := uc.layoutFor(target, opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	destination := layout.PathFor(target)
+	if matched, err := layout.ExactMatch(destination); err != nil || *matched != target {
+		return nil, fmt.Errorf("the layout of %s cannot hold %s", layout.GetRoot(), target)
+	}
+	if destination == source.FullPath() {
+		return nil, ErrSameLocation
+	}
+	if _, err := os.Lstat(destination); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDestinationExists, destination)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return nil, fmt.Errorf("creating parent directory: %w", err)
+	}
+	if err := MoveDir(source.FullPath(), destination); err != nil {
+		return nil, fmt.Errorf("moving %s to %s: %w", source.FullPath(), destination, err)
+	}
+	location := repository.NewLocation(destination, target.Host(), target.Owner(), target.Name())
+	uc.indexService.Remove(source.FullPath())
+	uc.indexService.Add(location)
+	if err := uc.moveTags(ctx, ref, target); err != nil {
+		return nil, fmt.Errorf("moving tags: %w", err)
+	}
+
+	if !opts.KeepRemotes {
+		if err := uc.updateRemotes(ctx, destination, ref, target); err != nil {
+			return nil, fmt.Errorf("updating remotes: %w", err)
+		}
+	}
+
+	if err := uc.extraService.MoveAutoExtra(ctx, ref, target); err != nil && !errors.Is(err, extra.ErrExtraNotFound) {
+		return nil, fmt.Errorf("moving auto extra: %w", err)
+	}
+	return location, nil
+}
+
+// layoutFor returns the layout of the root to move the repository into
+func (uc *Usecase) layoutFor(target repository.Reference, root string) (workspace.LayoutService, error) {
+	if root == "" {
+		return uc.workspaceService.GetLayoutForReference(target), nil
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(uc.workspaceService.GetRoots(), abs) {
+		return nil, fmt.Errorf("%w: %s", workspace.ErrRootNotFound, root)
+	}
+	return uc.workspaceService.GetLayoutFor(abs), nil
+}
+
+// moveTags re-binds the tags of the repository to the new reference.
+// If another clone of the old reference remains in the roots, the tags are copied to keep them for it.
+func (uc *Usecase) moveTags(ctx context.Context, from, to repository.Reference) error {
+	if from == to {
+		return nil
+	}
+	other, err := uc.finderService.FindByReference(ctx, uc.workspaceService, from)
+	switch {
+	case errors.Is(err, workspace.ErrNotMatched) || (err == nil && other == nil):
+		uc.tagService.Move(from, to)
+		return nil
+	case err != nil:
+		return fmt.Errorf("finding other clones: %w", err)
+	}
+	if tags := uc.tagService.Tags(from); len(tags) > 0 {
+		return uc.tagService.Add(to, tags...)
+	}
+	return nil
+}
+
+// updateRemotes rewrites the default remotes which point to the old reference
+// when the owner or the name of the repository are changed.
+func (uc *Usecase) updateRemotes(ctx context.Context, localPath string, from, to repository.Reference) error {
+	if from.Owner() == to.Owner() && from.Name() == to.Name() {
+		return nil
+	}
+	remotes, err := uc.gitService.GetDefaultRemotes(ctx, localPath)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for i, remote := range remotes {
+		if replaced, ok := replaceRemote(remote, from, to); ok {
+			remotes[i] = replaced
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return uc.gitService.SetDefaultRemotes(ctx, localPath, remotes)
+}
+
+// replaceRemote replaces the owner and the name in the remote URL, if it points to the reference.
+func replaceRemote(remote string, from, to repository.Reference) (string, bool) {
+	u, err := url.Parse(git.ToHTTPSURL(remote))
+	if err != nil || u.Hostname() != from.Host() {
+		return remote, false
+	}
+	oldPath := from.Owner() + "/" + from.Name()
+	if strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git") != oldPath {
+		return remote, false
+	}
+	i := strings.LastIndex(remote, oldPath)
+	return remote[:i] + to.Owner() + "/" + to.Name() + remote[i+len(oldPath):], true
+}
+
+// MoveDir renames the directory, or copies and removes it if they are on different devices.
+func MoveDir(source, destination string) error {
+	err := os.Rename(source, destination)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyDir(source, destination); err != nil {
+		_ = os.RemoveAll(destination)
+		return err
+	}
+	return os.RemoveAll(source)
+}
+
+// copyDir copies the directory tree keeping the permissions and the symbolic links.
+func copyDir(source, destination string) error {
+	return filepath.WalkDir(source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destination, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target, info.Mode().Perm())
+		}
+	})
+}
+
+func copyFile(source, destination string, perm fs.FileMode) error {
+	r, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
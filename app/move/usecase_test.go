@@ -0,0 +1,227 @@
+package move_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/move"
+	"github.com/kyoh86/gogh/v4/core/extra"
+	"github.com/kyoh86/gogh/v4/core/extra_mock"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+// layout is a simple layout placing repositories in "<root>/<host>/<owner>/<name>"
+type layout struct {
+	root string
+}
+
+func newLayout(root string) workspace.LayoutService {
+	return &layout{root: root}
+}
+
+func (l *layout) GetRoot() string { return l.root }
+
+func (l *layout) Match(path string) (*repository.Reference, error) {
+	return l.ExactMatch(path)
+}
+
+func (l *layout) ExactMatch(path string) (*repository.Reference, error) {
+	rel, err := filepath.Rel(l.root, path)
+	if err != nil {
+		return nil, workspace.ErrNotMatched
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) != 3 {
+		return nil, workspace.ErrNotMatched
+	}
+	ref := repository.NewReference(parts[0], parts[1], parts[2])
+	return &ref, nil
+}
+
+func (l *layout) CanHold(repository.Reference) bool {
+	return true
+}
+
+func (l *layout) PathFor(ref repository.Reference) string {
+	return filepath.Join(l.root, ref.Host(), ref.Owner(), ref.Name())
+}
+
+func (l *layout) CreateRepositoryFolder(ref repository.Reference) (string, error) {
+	return l.PathFor(ref), nil
+}
+
+func (l *layout) DeleteRepository(ref repository.Reference) error {
+	return nil
+}
+
+type mocks struct {
+	workspace *workspace_mock.MockWorkspaceService
+	finder    *workspace_mock.MockFinderService
+	git       *git_mock.MockGitService
+	extra     *extra_mock.MockExtraService
+	tags      workspace.TagService
+}
+
+func setup(t *testing.T) (string, string, mocks, *testtarget.Usecase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := mocks{
+		workspace: workspace_mock.NewMockWorkspaceService(ctrl),
+		finder:    workspace_mock.NewMockFinderService(ctrl),
+		git:       git_mock.NewMockGitService(ctrl),
+		extra:     extra_mock.NewMockExtraService(ctrl),
+		tags:      workspace.NewTagService(),
+	}
+	tmpDir := t.TempDir()
+	root1 := filepath.Join(tmpDir, "root1")
+	root2 := filepath.Join(tmpDir, "root2")
+	source := filepath.Join(root1, "github.com", "kyoh86", "gogh")
+	if err := os.MkdirAll(source, 0o755); err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(source, "README.md"), []byte("gogh"), 0o644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	m.workspace.EXPECT().GetRoots().Return([]workspace.Root{root1, root2}).AnyTimes()
+	m.workspace.EXPECT().GetLayoutFor(gomock.Any()).DoAndReturn(func(root string) workspace.LayoutService {
+		return newLayout(root)
+	}).AnyTimes()
+	m.finder.EXPECT().FindByReference(gomock.Any(), m.workspace, repository.NewReference("github.com", "kyoh86", "gogh")).
+		DoAndReturn(func(context.Context, workspace.WorkspaceService, repository.Reference) (*repository.Location, error) {
+			// Find the clone in the roots in order
+			for _, root := range []string{root1, root2} {
+				path := filepath.Join(root, "github.com", "kyoh86", "gogh")
+				if _, err := os.Stat(path); err == nil {
+					return repository.NewLocation(path, "github.com", "kyoh86", "gogh"), nil
+				}
+			}
+			return nil, nil
+		}).AnyTimes()
+	uc := testtarget.NewUsecase(m.workspace, m.finder, workspace.NewIndexService(), m.tags, m.git, m.extra, repository.NewReferenceParser("github.com", "kyoh86"))
+	return root1, root2, m, uc
+}
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	ref := repository.NewReference("github.com", "kyoh86", "gogh")
+
+	t.Run("MoveToAnotherRoot", func(t *testing.T) {
+		root1, root2, m, uc := setup(t)
+		m.extra.EXPECT().MoveAutoExtra(gomock.Any(), ref, ref).Return(nil)
+
+		loc, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Root: root2})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := filepath.Join(root2, "github.com", "kyoh86", "gogh")
+		if loc.FullPath() != want {
+			t.Errorf("expected %s, got %s", want, loc.FullPath())
+		}
+		if _, err := os.Stat(filepath.Join(want, "README.md")); err != nil {
+			t.Errorf("expected the working tree to be moved: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root1, "github.com", "kyoh86", "gogh")); !os.IsNotExist(err) {
+			t.Errorf("expected the source to be removed: %v", err)
+		}
+	})
+
+	t.Run("MoveToAlias", func(t *testing.T) {
+		root1, _, m, uc := setup(t)
+		alias := repository.NewReference("github.com", "our-org", "gogh-fork")
+		if err := m.tags.Add(ref, "tools"); err != nil {
+			t.Fatalf("failed to add tags: %v", err)
+		}
+		m.workspace.EXPECT().GetLayoutForReference(alias).Return(newLayout(root1))
+		want := filepath.Join(root1, "github.com", "our-org", "gogh-fork")
+		m.git.EXPECT().GetDefaultRemotes(gomock.Any(), want).Return([]string{
+			"git@github.com:kyoh86/gogh.git",
+			"https://github.com/kyoh86/gogh-other",
+		}, nil)
+		m.git.EXPECT().SetDefaultRemotes(gomock.Any(), want, []string{
+			"git@github.com:our-org/gogh-fork.git",
+			"https://github.com/kyoh86/gogh-other",
+		}).Return(nil)
+		m.extra.EXPECT().MoveAutoExtra(gomock.Any(), ref, alias).Return(extra.ErrExtraNotFound)
+
+		loc, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Alias: "our-org/gogh-fork"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loc.FullPath() != want || loc.Path() != alias.String() {
+			t.Errorf("expected %s (%s), got %s (%s)", want, alias, loc.FullPath(), loc.Path())
+		}
+		if got := m.tags.Tags(alias); !slices.Equal(got, []string{"tools"}) {
+			t.Errorf("expected the tags to be moved to the alias, got %v", got)
+		}
+		if got := m.tags.Tags(ref); len(got) != 0 {
+			t.Errorf("expected the tags of the old reference to be removed, got %v", got)
+		}
+	})
+
+	t.Run("KeepTagsForOtherClone", func(t *testing.T) {
+		root1, root2, m, uc := setup(t)
+		alias := repository.NewReference("github.com", "kyoh86", "gogh-old")
+		if err := os.MkdirAll(filepath.Join(root2, "github.com", "kyoh86", "gogh"), 0o755); err != nil {
+			t.Fatalf("failed to create another clone: %v", err)
+		}
+		if err := m.tags.Add(ref, "tools"); err != nil {
+			t.Fatalf("failed to add tags: %v", err)
+		}
+		m.workspace.EXPECT().GetLayoutForReference(alias).Return(newLayout(root1))
+		m.extra.EXPECT().MoveAutoExtra(gomock.Any(), ref, alias).Return(nil)
+
+		if _, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Alias: "gogh-old", KeepRemotes: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := m.tags.Tags(alias); !slices.Equal(got, []string{"tools"}) {
+			t.Errorf("expected the tags to be copied to the alias, got %v", got)
+		}
+		if got := m.tags.Tags(ref); !slices.Equal(got, []string{"tools"}) {
+			t.Errorf("expected the tags to be kept for the other clone, got %v", got)
+		}
+	})
+
+	t.Run("KeepRemotes", func(t *testing.T) {
+		root1, _, m, uc := setup(t)
+		alias := repository.NewReference("github.com", "kyoh86", "gogh-old")
+		m.workspace.EXPECT().GetLayoutForReference(alias).Return(newLayout(root1))
+		m.extra.EXPECT().MoveAutoExtra(gomock.Any(), ref, alias).Return(nil)
+
+		if _, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Alias: "gogh-old", KeepRemotes: true}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("DestinationExists", func(t *testing.T) {
+		_, root2, _, uc := setup(t)
+		if err := os.MkdirAll(filepath.Join(root2, "github.com", "kyoh86", "gogh"), 0o755); err != nil {
+			t.Fatalf("failed to create destination: %v", err)
+		}
+		if _, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Root: root2}); !errors.Is(err, testtarget.ErrDestinationExists) {
+			t.Errorf("expected ErrDestinationExists, got %v", err)
+		}
+	})
+
+	t.Run("SameLocation", func(t *testing.T) {
+		root1, _, _, uc := setup(t)
+		if _, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Root: root1}); !errors.Is(err, testtarget.ErrSameLocation) {
+			t.Errorf("expected ErrSameLocation, got %v", err)
+		}
+	})
+
+	t.Run("UnknownRoot", func(t *testing.T) {
+		_, _, _, uc := setup(t)
+		if _, err := uc.Execute(ctx, "kyoh86/gogh", testtarget.Options{Root: "/unknown"}); !errors.Is(err, workspace.ErrRootNotFound) {
+			t.Errorf("expected ErrRootNotFound, got %v", err)
+		}
+	})
+}
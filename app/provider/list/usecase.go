@@ -0,0 +1,25 @@
+package list
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/hosting"
+)
+
+// Provider describes the hosting service for a host
+type Provider = hosting.Provider
+
+// Usecase lists the registered hosting service providers
+type Usecase struct {
+	providerService hosting.ProviderService
+}
+
+// NewUsecase creates a new Usecase instance
+func NewUsecase(providerService hosting.ProviderService) *Usecase {
+	return &Usecase{providerService: providerService}
+}
+
+// Execute returns the registered providers in the order of the host name
+func (uc *Usecase) Execute(ctx context.Context) []Provider {
+	return uc.providerService.Entries()
+}
@@ -0,0 +1,31 @@
+package list_test
+
+import (
+	"context"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/provider/list"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := hosting_mock.NewMockProviderService(ctrl)
+	want := []hosting.Provider{
+		{Host: "git.example.com", Kind: hosting.ProviderKindGitea},
+		{Host: "github.example.com", Kind: hosting.ProviderKindGitHub, APIBase: "https://github.example.com/api/v3"},
+	}
+	m.EXPECT().Entries().Return(want)
+
+	got := testtarget.NewUsecase(m).Execute(context.Background())
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %+v, got %+v", want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package remove
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/hosting"
+)
+
+// Usecase unregisters the hosting service provider for a host
+type Usecase struct {
+	providerService hosting.ProviderService
+}
+
+// NewUsecase creates a new Usecase instance
+func NewUsecase(providerService hosting.ProviderService) *Usecase {
+	return &Usecase{providerService: providerService}
+}
+
+// Execute unregisters the provider for the host
+func (uc *Usecase) Execute(ctx context.Context, host string) error {
+	return uc.providerService.Remove(host)
+}
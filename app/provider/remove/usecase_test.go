@@ -0,0 +1,28 @@
+package remove_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/provider/remove"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	m := hosting_mock.NewMockProviderService(ctrl)
+	m.EXPECT().Remove("git.example.com").Return(nil)
+	m.EXPECT().Remove("unknown.example.com").Return(hosting.ErrProviderNotFound)
+
+	uc := testtarget.NewUsecase(m)
+	if err := uc.Execute(ctx, "git.example.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := uc.Execute(ctx, "unknown.example.com"); !errors.Is(err, hosting.ErrProviderNotFound) {
+		t.Errorf("expected ErrProviderNotFound, got %v", err)
+	}
+}
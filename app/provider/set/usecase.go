@@ -0,0 +1,80 @@
+package set
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/core/hosting"
+)
+
+// Usecase registers the hosting service provider for a host
+type Usecase struct {
+	providerService hosting.ProviderService
+}
+
+// NewUsecase creates a new Usecase instance
+func NewUsecase(providerService hosting.ProviderService) *Usecase {
+	return &Usecase{providerService: providerService}
+}
+
+// ProviderKinds returns the names of the supported provider kinds
+func ProviderKinds() []string {
+	kinds := hosting.ProviderKinds()
+	names := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		names = append(names, string(kind))
+	}
+	return names
+}
+
+// Protocols returns the names of the supported protocols
+func Protocols() []string {
+	protocols := hosting.Protocols()
+	names := make([]string, 0, len(protocols))
+	for _, protocol := range protocols {
+		names = append(names, string(protocol))
+	}
+	return names
+}
+
+// Options holds the optional settings of the provider
+type Options struct {
+	// APIBase is a base URL of the REST API.
+	// If it is empty, the default API base URL for the kind will be used.
+	APIBase string
+	// CloneURL is a template of the clone URL for the plain git servers.
+	// If it is empty, hosting.DefaultCloneURLTemplate will be used.
+	CloneURL string
+	// Protocol is the protocol preferred to clone repositories ("https" or "ssh").
+	// If it is empty, "https" will be used.
+	Protocol string
+	// SSHKeyFile is a path of the private key file to access the host over SSH.
+	// If it is empty, the keys in the ssh-agent will be used.
+	SSHKeyFile string
+}
+
+// Execute registers the provider of the kind for the host.
+func (uc *Usecase) Execute(ctx context.Context, host, kind string, opts Options) error {
+	k, err := hosting.ParseProviderKind(kind)
+	if err != nil {
+		return err
+	}
+	var protocol hosting.Protocol
+	if opts.Protocol != "" {
+		protocol, err = hosting.ParseProtocol(opts.Protocol)
+		if err != nil {
+			return err
+		}
+	}
+	if err := uc.providerService.Set(hosting.Provider{
+		Host:       host,
+		Kind:       k,
+		APIBase:    opts.APIBase,
+		CloneURL:   opts.CloneURL,
+		Protocol:   protocol,
+		SSHKeyFile: opts.SSHKeyFile,
+	}); err != nil {
+		return fmt.Errorf("setting provider for %s: %w", host, err)
+	}
+	return nil
+}
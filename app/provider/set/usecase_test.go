@@ -0,0 +1,119 @@
+package set_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/provider/set"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		host      string
+		kind      string
+		opts      testtarget.Options
+		setupMock func(*hosting_mock.MockProviderService)
+		wantErr   bool
+	}{
+		{
+			name: "register GitHub Enterprise",
+			host: "github.example.com",
+			kind: "github",
+			opts: testtarget.Options{APIBase: "https://github.example.com/api/v3"},
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(hosting.Provider{
+					Host:    "github.example.com",
+					Kind:    hosting.ProviderKindGitHub,
+					APIBase: "https://github.example.com/api/v3",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "register plain git server",
+			host: "git.example.com",
+			kind: "git",
+			opts: testtarget.Options{CloneURL: "git@git.example.com:{{.Owner}}/{{.Name}}.git"},
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(hosting.Provider{
+					Host:     "git.example.com",
+					Kind:     hosting.ProviderKindGit,
+					CloneURL: "git@git.example.com:{{.Owner}}/{{.Name}}.git",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "prefer SSH",
+			host: "github.com",
+			kind: "github",
+			opts: testtarget.Options{Protocol: "ssh", SSHKeyFile: "/home/kyoh86/.ssh/id_ed25519"},
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(hosting.Provider{
+					Host:       "github.com",
+					Kind:       hosting.ProviderKindGitHub,
+					Protocol:   hosting.ProtocolSSH,
+					SSHKeyFile: "/home/kyoh86/.ssh/id_ed25519",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "invalid protocol",
+			host: "github.com",
+			kind: "github",
+			opts: testtarget.Options{Protocol: "ftp"},
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid kind",
+			host: "git.example.com",
+			kind: "svn",
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(gomock.Any()).Times(0)
+			},
+			wantErr: true,
+		},
+		{
+			name: "set fails",
+			host: "git.example.com",
+			kind: "gitlab",
+			setupMock: func(m *hosting_mock.MockProviderService) {
+				m.EXPECT().Set(gomock.Any()).Return(errors.New("invalid host"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := hosting_mock.NewMockProviderService(ctrl)
+			tc.setupMock(m)
+
+			err := testtarget.NewUsecase(m).Execute(ctx, tc.host, tc.kind, tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderKinds(t *testing.T) {
+	kinds := testtarget.ProviderKinds()
+	if len(kinds) != len(hosting.ProviderKinds()) {
+		t.Fatalf("expected %d kinds, got %v", len(hosting.ProviderKinds()), kinds)
+	}
+	for i, kind := range hosting.ProviderKinds() {
+		if kinds[i] != string(kind) {
+			t.Errorf("expected %q, got %q", kind, kinds[i])
+		}
+	}
+}
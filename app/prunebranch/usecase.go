@@ -0,0 +1,83 @@
+package prunebranch
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/kyoh86/gogh/v4/app/list"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for pruning the local branches which are no longer needed
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	gitService       git.GitService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	gitService git.GitService,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		gitService:       gitService,
+	}
+}
+
+type ListOptions = workspace.ListOptions
+
+// Options defines the options for searching the branches to prune
+type Options struct {
+	// Primary searches just the repositories in the primary root
+	Primary bool
+	ListOptions
+}
+
+// Branch is a local branch to prune
+type Branch struct {
+	Location *repository.Location
+	git.PrunableBranch
+}
+
+// List yields the branches which are merged into the default branch or whose upstream is gone.
+// An error on a repository is yielded with its path, and the search goes on to the next repository.
+func (uc *Usecase) List(ctx context.Context, opts Options) iter.Seq2[*Branch, error] {
+	return func(yield func(*Branch, error) bool) {
+		for location, err := range list.NewUsecase(uc.workspaceService, uc.finderService).Execute(ctx, list.Options{
+			Primary:     opts.Primary,
+			ListOptions: opts.ListOptions,
+		}) {
+			if err != nil {
+				yield(nil, fmt.Errorf("listing repositories: %w", err))
+				return
+			}
+			branches, err := uc.gitService.ListPrunableBranches(ctx, location.FullPath())
+			if err != nil {
+				if !yield(nil, fmt.Errorf("listing branches in %s: %w", location.Path(), err)) {
+					return
+				}
+				continue
+			}
+			for _, branch := range branches {
+				if !yield(&Branch{Location: location, PrunableBranch: branch}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Prune deletes the branch
+func (uc *Usecase) Prune(ctx context.Context, branch *Branch) error {
+	if err := uc.gitService.DeleteBranch(ctx, branch.Location.FullPath(), branch.Name); err != nil {
+		return fmt.Errorf("deleting branch %s in %s: %w", branch.Name, branch.Location.Path(), err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package prunebranch_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/prunebranch"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func locations(names ...string) iter.Seq2[*repository.Location, error] {
+	return func(yield func(*repository.Location, error) bool) {
+		for _, name := range names {
+			if !yield(repository.NewLocation("/root/github.com/kyoh86/"+name, "github.com", "kyoh86", name), nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestUsecase_List(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockFinder.EXPECT().
+		ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{Patterns: []string{"*/kyoh86/*"}}).
+		Return(locations("foo", "broken", "bar"))
+	mockGit.EXPECT().ListPrunableBranches(gomock.Any(), "/root/github.com/kyoh86/foo").Return([]git.PrunableBranch{
+		{Name: "fix", Reason: git.PruneReasonMerged},
+		{Name: "feat", Reason: git.PruneReasonUpstreamGone},
+	}, nil)
+	mockGit.EXPECT().ListPrunableBranches(gomock.Any(), "/root/github.com/kyoh86/broken").Return(nil, errors.New("not a git repository"))
+	mockGit.EXPECT().ListPrunableBranches(gomock.Any(), "/root/github.com/kyoh86/bar").Return(nil, nil)
+
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, mockGit)
+	var got []string
+	var errs int
+	for branch, err := range uc.List(ctx, testtarget.Options{
+		ListOptions: testtarget.ListOptions{Patterns: []string{"*/kyoh86/*"}},
+	}) {
+		if err != nil {
+			errs++
+			continue
+		}
+		got = append(got, branch.Location.Name()+":"+branch.Name+":"+string(branch.Reason))
+	}
+	want := []string{"foo:fix:merged", "foo:feat:upstream gone"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if errs != 1 {
+		t.Errorf("expected an error for the broken repository, got %d", errs)
+	}
+}
+
+func TestUsecase_Prune(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	location := repository.NewLocation("/root/github.com/kyoh86/foo", "github.com", "kyoh86", "foo")
+	mockGit.EXPECT().DeleteBranch(gomock.Any(), "/root/github.com/kyoh86/foo", "fix").Return(nil)
+	mockGit.EXPECT().DeleteBranch(gomock.Any(), "/root/github.com/kyoh86/foo", "feat").Return(errors.New("locked"))
+
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, mockGit)
+	if err := uc.Prune(ctx, &testtarget.Branch{Location: location, PrunableBranch: git.PrunableBranch{Name: "fix"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := uc.Prune(ctx, &testtarget.Branch{Location: location, PrunableBranch: git.PrunableBranch{Name: "feat"}}); err == nil {
+		t.Error("expected an error")
+	}
+}
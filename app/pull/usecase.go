@@ -0,0 +1,210 @@
+package pull
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/kyoh86/gogh/v4/app/list"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency is the default number of repositories to update at once
+const DefaultConcurrency = 8
+
+// Usecase defines the use case for updating local repositories from their remotes
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	hostingService   hosting.HostingService
+	providerService  hosting.ProviderService
+	gitService       git.GitService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	hostingService hosting.HostingService,
+	providerService hosting.ProviderService,
+	gitService git.GitService,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		hostingService:   hostingService,
+		providerService:  providerService,
+		gitService:       gitService,
+	}
+}
+
+type ListOptions = workspace.ListOptions
+
+// Options defines the options for updating repositories
+type Options struct {
+	// Primary updates just the repositories in the primary root
+	Primary bool
+	ListOptions
+	// Concurrency is the maximum number of repositories to update at once (default: DefaultConcurrency)
+	Concurrency int
+	// FetchOnly fetches the remote without fast-forwarding the current branch
+	FetchOnly bool
+}
+
+// Status is a result of updating a repository
+type Status int
+
+const (
+	// StatusUpToDate indicates that the repository is already up to date
+	StatusUpToDate Status = iota
+	// StatusUpdated indicates that the repository is updated
+	StatusUpdated
+	// StatusDiverged indicates that the current branch cannot be fast-forwarded
+	StatusDiverged
+	// StatusDirty indicates that the worktree has uncommitted changes
+	StatusDirty
+	// StatusNoUpstream indicates that the current branch has no upstream to pull
+	StatusNoUpstream
+	// StatusFailed indicates that the repository failed to be updated
+	StatusFailed
+)
+
+// String returns a human readable label of the status
+func (s Status) String() string {
+	switch s {
+	case StatusUpToDate:
+		return "up to date"
+	case StatusUpdated:
+		return "updated"
+	case StatusDiverged:
+		return "diverged"
+	case StatusDirty:
+		return "dirty"
+	case StatusNoUpstream:
+		return "no upstream"
+	case StatusFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Result is a result of updating a repository
+type Result struct {
+	Location *repository.Location
+	Status   Status
+	// Err is the cause of StatusFailed
+	Err error
+}
+
+// Execute updates the local repositories concurrently and yields the results in the order of completion
+func (uc *Usecase) Execute(ctx context.Context, opts Options) iter.Seq2[*Result, error] {
+	return func(yield func(*Result, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		concurrency := opts.Concurrency
+		if concurrency <= 0 {
+			concurrency = DefaultConcurrency
+		}
+		results := make(chan *Result)
+		listErr := make(chan error, 1)
+		go func() {
+			defer close(results)
+			var eg errgroup.Group
+			eg.SetLimit(concurrency)
+			for location, err := range list.NewUsecase(uc.workspaceService, uc.finderService).Execute(ctx, list.Options{
+				Primary:     opts.Primary,
+				ListOptions: opts.ListOptions,
+			}) {
+				if err != nil {
+					listErr <- err
+					break
+				}
+				eg.Go(func() error {
+					result := uc.update(ctx, location, opts)
+					select {
+					case results <- result:
+					case <-ctx.Done():
+					}
+					return nil
+				})
+			}
+			_ = eg.Wait()
+		}()
+
+		for result := range results {
+			if !yield(result, nil) {
+				cancel()
+				for range results {
+					// Wait for the workers to stop
+				}
+				return
+			}
+		}
+		select {
+		case err := <-listErr:
+			yield(nil, fmt.Errorf("listing repositories: %w", err))
+		default:
+		}
+	}
+}
+
+func (uc *Usecase) update(ctx context.Context, location *repository.Location, opts Options) *Result {
+	failed := func(err error) *Result {
+		return &Result{Location: location, Status: StatusFailed, Err: err}
+	}
+	remotes, err := uc.gitService.GetDefaultRemotes(ctx, location.FullPath())
+	if err != nil {
+		return failed(fmt.Errorf("getting remotes: %w", err))
+	}
+	if len(remotes) == 0 {
+		return failed(errors.New("no remote is set"))
+	}
+	gitService, err := uc.authenticate(ctx, location.Ref(), remotes[0])
+	if err != nil {
+		return failed(fmt.Errorf("authenticating: %w", err))
+	}
+
+	var updated bool
+	if opts.FetchOnly {
+		updated, err = gitService.Fetch(ctx, location.FullPath(), git.FetchOptions{})
+	} else {
+		updated, err = gitService.Pull(ctx, location.FullPath(), git.PullOptions{})
+	}
+	switch {
+	case errors.Is(err, git.ErrWorktreeDirty):
+		return &Result{Location: location, Status: StatusDirty}
+	case errors.Is(err, git.ErrDiverged):
+		return &Result{Location: location, Status: StatusDiverged}
+	case errors.Is(err, git.ErrNoUpstream):
+		return &Result{Location: location, Status: StatusNoUpstream}
+	case err != nil:
+		return failed(err)
+	case updated:
+		return &Result{Location: location, Status: StatusUpdated}
+	}
+	return &Result{Location: location, Status: StatusUpToDate}
+}
+
+// authenticate prepares the git service to access the remote:
+// SSH remotes use the SSH keys, and others use the token for the owner.
+// If no token is found for the owner, it accesses the remote without authentication.
+func (uc *Usecase) authenticate(ctx context.Context, ref repository.Reference, remoteURL string) (git.GitService, error) {
+	if git.IsSSHURL(remoteURL) {
+		opts := git.SSHAuthOptions{User: git.SSHUserOf(remoteURL)}
+		if keyFile := uc.providerService.Resolve(ref.Host()).SSHKeyFile; keyFile != "" {
+			opts.KeyFiles = []string{keyFile}
+		}
+		return uc.gitService.AuthenticateWithSSH(ctx, opts)
+	}
+	user, token, err := uc.hostingService.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return uc.gitService, nil
+	}
+	return uc.gitService.AuthenticateWithUsernamePassword(ctx, user, token.AccessToken)
+}
@@ -0,0 +1,176 @@
+package pull_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"maps"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/pull"
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func locations(paths ...string) iter.Seq2[*repository.Location, error] {
+	return func(yield func(*repository.Location, error) bool) {
+		for _, p := range paths {
+			if !yield(repository.NewLocation("/root/github.com/kyoh86/"+p, "github.com", "kyoh86", p), nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockHosting := hosting_mock.NewMockHostingService(ctrl)
+	mockProvider := hosting_mock.NewMockProviderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockProvider.EXPECT().Resolve(gomock.Any()).DoAndReturn(hosting.GuessProvider).AnyTimes()
+	mockFinder.EXPECT().
+		ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{Patterns: []string{"*/kyoh86/*"}}).
+		Return(locations("updated", "uptodate", "diverged", "dirty", "noupstream", "failed", "noremote", "ssh"))
+
+	mockGit.EXPECT().GetDefaultRemotes(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, path string) ([]string, error) {
+		switch path {
+		case "/root/github.com/kyoh86/noremote":
+			return nil, nil
+		case "/root/github.com/kyoh86/ssh":
+			return []string{"git@github.com:kyoh86/ssh.git"}, nil
+		}
+		return []string{"https://github.com/kyoh86/repo.git"}, nil
+	}).Times(8)
+	mockHosting.EXPECT().GetTokenFor(gomock.Any(), "github.com", "kyoh86").Return("kyoh86", auth.Token{AccessToken: "token"}, nil).Times(6)
+	mockGit.EXPECT().AuthenticateWithUsernamePassword(gomock.Any(), "kyoh86", "token").Return(mockGit, nil).Times(6)
+	mockGit.EXPECT().AuthenticateWithSSH(gomock.Any(), git.SSHAuthOptions{User: "git"}).Return(mockGit, nil)
+	mockGit.EXPECT().Pull(gomock.Any(), gomock.Any(), git.PullOptions{}).DoAndReturn(func(_ context.Context, path string, _ git.PullOptions) (bool, error) {
+		switch path {
+		case "/root/github.com/kyoh86/updated", "/root/github.com/kyoh86/ssh":
+			return true, nil
+		case "/root/github.com/kyoh86/diverged":
+			return false, git.ErrDiverged
+		case "/root/github.com/kyoh86/dirty":
+			return false, git.ErrWorktreeDirty
+		case "/root/github.com/kyoh86/noupstream":
+			return false, git.ErrNoUpstream
+		case "/root/github.com/kyoh86/failed":
+			return false, errors.New("network error")
+		}
+		return false, nil
+	}).Times(7)
+
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, mockHosting, mockProvider, mockGit)
+	got := map[string]testtarget.Status{}
+	for result, err := range uc.Execute(ctx, testtarget.Options{
+		ListOptions: testtarget.ListOptions{Patterns: []string{"*/kyoh86/*"}},
+		Concurrency: 3,
+	}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Status == testtarget.StatusFailed && result.Err == nil {
+			t.Errorf("expected an error for the failed result of %s", result.Location.Name())
+		}
+		got[result.Location.Name()] = result.Status
+	}
+	want := map[string]testtarget.Status{
+		"updated":    testtarget.StatusUpdated,
+		"uptodate":   testtarget.StatusUpToDate,
+		"diverged":   testtarget.StatusDiverged,
+		"dirty":      testtarget.StatusDirty,
+		"noupstream": testtarget.StatusNoUpstream,
+		"failed":     testtarget.StatusFailed,
+		"noremote":   testtarget.StatusFailed,
+		"ssh":        testtarget.StatusUpdated,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestUsecase_ExecuteFetchOnly(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockLayout := workspace_mock.NewMockLayoutService(ctrl)
+	mockHosting := hosting_mock.NewMockHostingService(ctrl)
+	mockProvider := hosting_mock.NewMockProviderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockWorkspace.EXPECT().GetPrimaryRoot().Return("/root")
+	mockWorkspace.EXPECT().GetLayoutFor("/root").Return(mockLayout)
+	mockFinder.EXPECT().ListRepositoryInRoot(gomock.Any(), mockLayout, workspace.ListOptions{}).Return(locations("repo"))
+	mockGit.EXPECT().GetDefaultRemotes(gomock.Any(), "/root/github.com/kyoh86/repo").Return([]string{"https://github.com/kyoh86/repo.git"}, nil)
+	// Public repositories are fetched without authentication
+	mockHosting.EXPECT().GetTokenFor(gomock.Any(), "github.com", "kyoh86").Return("", auth.Token{}, errors.New("token not found"))
+	mockGit.EXPECT().Fetch(gomock.Any(), "/root/github.com/kyoh86/repo", git.FetchOptions{}).Return(true, nil)
+
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, mockHosting, mockProvider, mockGit)
+	var results []*testtarget.Result
+	for result, err := range uc.Execute(ctx, testtarget.Options{Primary: true, FetchOnly: true}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results = append(results, result)
+	}
+	if len(results) != 1 || results[0].Status != testtarget.StatusUpdated {
+		t.Errorf("expected the repository to be updated, got %+v", results)
+	}
+}
+
+func TestUsecase_ExecuteListError(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockHosting := hosting_mock.NewMockHostingService(ctrl)
+	mockProvider := hosting_mock.NewMockProviderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockFinder.EXPECT().ListAllRepository(gomock.Any(), mockWorkspace, gomock.Any()).Return(func(yield func(*repository.Location, error) bool) {
+		yield(nil, errors.New("permission denied"))
+	})
+
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, mockHosting, mockProvider, mockGit)
+	var gotErr error
+	for _, err := range uc.Execute(ctx, testtarget.Options{}) {
+		gotErr = err
+	}
+	if gotErr == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestStatus_String(t *testing.T) {
+	for status, want := range map[testtarget.Status]string{
+		testtarget.StatusUpToDate:   "up to date",
+		testtarget.StatusUpdated:    "updated",
+		testtarget.StatusDiverged:   "diverged",
+		testtarget.StatusDirty:      "dirty",
+		testtarget.StatusNoUpstream: "no upstream",
+		testtarget.StatusFailed:     "failed",
+	} {
+		if got := status.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
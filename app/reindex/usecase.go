@@ -0,0 +1,49 @@
+package reindex
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for rebuilding the index of the local repositories
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	indexService     workspace.IndexService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	indexService workspace.IndexService,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		indexService:     indexService,
+	}
+}
+
+// Execute walks all roots and rebuilds the index with the found repositories.
+// It returns the number of the indexed repositories.
+func (uc *Usecase) Execute(ctx context.Context) (int, error) {
+	// Drop the current index to walk the directories instead of trusting stale entries
+	uc.indexService.Drop()
+	var locations []*repository.Location
+	for location, err := range uc.finderService.ListAllRepository(ctx, uc.workspaceService, workspace.ListOptions{}) {
+		if err != nil {
+			return 0, err
+		}
+		locations = append(locations, location)
+	}
+	uc.indexService.Reset(locations)
+	return len(locations), nil
+}
+
+// Drop removes the index and falls back to walking the directories
+func (uc *Usecase) Drop() {
+	uc.indexService.Drop()
+}
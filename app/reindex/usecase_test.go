@@ -0,0 +1,91 @@
+package reindex_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/reindex"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rebuild the index", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+		mockFinder := workspace_mock.NewMockFinderService(ctrl)
+		index := workspace.NewIndexService()
+		index.Reset([]*repository.Location{
+			repository.NewLocation("/root/github.com/kyoh86/stale", "github.com", "kyoh86", "stale"),
+		})
+
+		mockFinder.EXPECT().
+			ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{}).
+			DoAndReturn(func(context.Context, workspace.WorkspaceService, workspace.ListOptions) iter.Seq2[*repository.Location, error] {
+				if index.Built() {
+					t.Error("the index should be dropped before walking")
+				}
+				return func(yield func(*repository.Location, error) bool) {
+					if !yield(repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh"), nil) {
+						return
+					}
+					yield(repository.NewLocation("/root/github.com/kyoh86/dotfiles", "github.com", "kyoh86", "dotfiles"), nil)
+				}
+			})
+
+		count, err := testtarget.NewUsecase(mockWorkspace, mockFinder, index).Execute(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected 2 repositories, got %d", count)
+		}
+		if !index.Built() {
+			t.Error("the index should be built")
+		}
+		if _, ok := index.Get("/root/github.com/kyoh86/stale"); ok {
+			t.Error("stale entry should be removed")
+		}
+		if _, ok := index.Get("/root/github.com/kyoh86/gogh"); !ok {
+			t.Error("found repository should be indexed")
+		}
+	})
+
+	t.Run("error while walking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+		mockFinder := workspace_mock.NewMockFinderService(ctrl)
+		index := workspace.NewIndexService()
+		walkErr := errors.New("walk error")
+
+		mockFinder.EXPECT().
+			ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{}).
+			Return(iter.Seq2[*repository.Location, error](func(yield func(*repository.Location, error) bool) {
+				yield(nil, walkErr)
+			}))
+
+		if _, err := testtarget.NewUsecase(mockWorkspace, mockFinder, index).Execute(ctx); !errors.Is(err, walkErr) {
+			t.Errorf("expected walk error, got %v", err)
+		}
+		if index.Built() {
+			t.Error("the index should not be built")
+		}
+	})
+}
+
+func TestUsecase_Drop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	index := workspace.NewIndexService()
+	index.Reset(nil)
+
+	testtarget.NewUsecase(workspace_mock.NewMockWorkspaceService(ctrl), workspace_mock.NewMockFinderService(ctrl), index).Drop()
+	if index.Built() {
+		t.Error("the index should be dropped")
+	}
+}
@@ -0,0 +1,72 @@
+package add
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for adding a root into the workspace
+type Usecase struct {
+	workspaceService   workspace.WorkspaceService
+	defaultNameService repository.DefaultNameService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	defaultNameService repository.DefaultNameService,
+) *Usecase {
+	return &Usecase{
+		workspaceService:   workspaceService,
+		defaultNameService: defaultNameService,
+	}
+}
+
+// Options defines the options for adding a root
+type Options struct {
+	// AsPrimary sets the root as the primary root
+	AsPrimary bool
+	// Layout is a layout template of the repositories in the root (e.g. "{owner}/{name}").
+	// Empty means the default layout "{host}/{owner}/{name}".
+	Layout string
+	// Host is the host of the repositories for the layout without "{host}".
+	// Empty means the default host.
+	Host string
+	// Owner is the owner of the repositories for the layout without "{owner}".
+	// Empty means the default owner for the host.
+	Owner string
+}
+
+// Execute adds the root with its layout
+func (uc *Usecase) Execute(_ context.Context, root string, opts Options) error {
+	layout := workspace.Layout{Template: opts.Layout}
+	if !layout.Has(workspace.PlaceholderHost) {
+		layout.Host = opts.Host
+		if layout.Host == "" {
+			layout.Host = uc.defaultNameService.GetDefaultHost()
+		}
+	}
+	if !layout.Has(workspace.PlaceholderOwner) {
+		layout.Owner = opts.Owner
+		if layout.Owner == "" {
+			owner, err := uc.defaultNameService.GetDefaultOwnerFor(layout.Host)
+			if err != nil {
+				return fmt.Errorf("getting default owner for %s: %w", layout.Host, err)
+			}
+			layout.Owner = owner
+		}
+	}
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if err := uc.workspaceService.AddRoot(root, opts.AsPrimary); err != nil {
+		return err
+	}
+	if layout.IsDefault() {
+		return nil
+	}
+	return uc.workspaceService.SetRootLayout(root, layout)
+}
@@ -0,0 +1,102 @@
+package add_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/roots/add"
+	"github.com/kyoh86/gogh/v4/core/repository_mock"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	type mocks struct {
+		workspaceService   *workspace_mock.MockWorkspaceService
+		defaultNameService *repository_mock.MockDefaultNameService
+	}
+
+	tests := []struct {
+		name      string
+		opts      testtarget.Options
+		setupMock func(m mocks)
+		wantErr   bool
+	}{
+		{
+			name: "default layout",
+			opts: testtarget.Options{AsPrimary: true},
+			setupMock: func(m mocks) {
+				m.workspaceService.EXPECT().AddRoot("/root", true).Return(nil)
+			},
+		},
+		{
+			name: "layout with explicit host and owner",
+			opts: testtarget.Options{Layout: "{name}", Host: "gitlab.com", Owner: "kyoh86"},
+			setupMock: func(m mocks) {
+				m.workspaceService.EXPECT().AddRoot("/root", false).Return(nil)
+				m.workspaceService.EXPECT().SetRootLayout("/root", workspace.Layout{Template: "{name}", Host: "gitlab.com", Owner: "kyoh86"}).Return(nil)
+			},
+		},
+		{
+			name: "layout with default host and owner",
+			opts: testtarget.Options{Layout: "{name}"},
+			setupMock: func(m mocks) {
+				m.defaultNameService.EXPECT().GetDefaultHost().Return("github.com")
+				m.defaultNameService.EXPECT().GetDefaultOwnerFor("github.com").Return("kyoh86", nil)
+				m.workspaceService.EXPECT().AddRoot("/root", false).Return(nil)
+				m.workspaceService.EXPECT().SetRootLayout("/root", workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}).Return(nil)
+			},
+		},
+		{
+			name: "host in the template is not fixed",
+			opts: testtarget.Options{Layout: "{host}/{name}", Host: "ignored", Owner: "kyoh86"},
+			setupMock: func(m mocks) {
+				m.workspaceService.EXPECT().AddRoot("/root", false).Return(nil)
+				m.workspaceService.EXPECT().SetRootLayout("/root", workspace.Layout{Template: "{host}/{name}", Owner: "kyoh86"}).Return(nil)
+			},
+		},
+		{
+			name: "no default owner",
+			opts: testtarget.Options{Layout: "{name}", Host: "github.com"},
+			setupMock: func(m mocks) {
+				m.defaultNameService.EXPECT().GetDefaultOwnerFor("github.com").Return("", errors.New("no owner"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid layout is not added",
+			opts:      testtarget.Options{Layout: "{host}/{owner}/{repo}"},
+			setupMock: func(m mocks) {},
+			wantErr:   true,
+		},
+		{
+			name: "add root fails",
+			opts: testtarget.Options{},
+			setupMock: func(m mocks) {
+				m.workspaceService.EXPECT().AddRoot("/root", false).Return(errors.New("root already exists"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := mocks{
+				workspaceService:   workspace_mock.NewMockWorkspaceService(ctrl),
+				defaultNameService: repository_mock.NewMockDefaultNameService(ctrl),
+			}
+			tt.setupMock(m)
+
+			uc := testtarget.NewUsecase(m.workspaceService, m.defaultNameService)
+			err := uc.Execute(context.Background(), "/root", tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package route
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Route is a rule to choose the root where new repositories are placed
+type Route = workspace.Route
+
+// Usecase defines the use case for managing the routes to the roots
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(workspaceService workspace.WorkspaceService) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+	}
+}
+
+// List returns all routes in the order of priority
+func (uc *Usecase) List(_ context.Context) []Route {
+	return uc.workspaceService.GetRoutes()
+}
+
+// Add adds a route from the pattern to the root with the lowest priority
+func (uc *Usecase) Add(_ context.Context, pattern, root string) error {
+	return uc.workspaceService.AddRoute(Route{Pattern: pattern, Root: root})
+}
+
+// Remove removes the route for the pattern
+func (uc *Usecase) Remove(_ context.Context, pattern string) error {
+	return uc.workspaceService.RemoveRoute(pattern)
+}
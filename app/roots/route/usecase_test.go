@@ -0,0 +1,37 @@
+package route_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/roots/route"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	uc := testtarget.NewUsecase(mockWorkspace)
+
+	route := workspace.Route{Pattern: "github.com/our-org/**", Root: "/secure"}
+	mockWorkspace.EXPECT().AddRoute(route).Return(nil)
+	if err := uc.Add(ctx, route.Pattern, route.Root); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockWorkspace.EXPECT().GetRoutes().Return([]workspace.Route{route})
+	if routes := uc.List(ctx); len(routes) != 1 || routes[0] != route {
+		t.Errorf("unexpected routes: %v", routes)
+	}
+
+	mockWorkspace.EXPECT().RemoveRoute("github.com/**").Return(workspace.ErrRouteNotFound)
+	if err := uc.Remove(ctx, "github.com/**"); !errors.Is(err, workspace.ErrRouteNotFound) {
+		t.Errorf("expected ErrRouteNotFound, got %v", err)
+	}
+}
@@ -21,6 +21,9 @@ type ServiceSet struct {
 	TokenStore   store.Saver[auth.TokenService]
 	TokenService auth.TokenService
 
+	ProviderStore   store.Saver[hosting.ProviderService]
+	ProviderService hosting.ProviderService
+
 	WorkspaceStore   store.Saver[workspace.WorkspaceService]
 	WorkspaceService workspace.WorkspaceService
 
@@ -39,6 +42,15 @@ type ServiceSet struct {
 	ExtraStore   *config.ExtraStore
 	ExtraService extra.ExtraService
 
+	IndexStore   store.Saver[workspace.IndexService]
+	IndexService workspace.IndexService
+
+	TagStore   store.Saver[workspace.TagService]
+	TagService workspace.TagService
+
+	UsageStore   store.Saver[workspace.UsageService]
+	UsageService workspace.UsageService
+
 	ReferenceParser     repository.ReferenceParser
 	HostingService      hosting.HostingService
 	FinderService       workspace.FinderService
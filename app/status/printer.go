@@ -0,0 +1,146 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Printer prints the status of repositories
+type Printer interface {
+	Print(r Result) error
+	Close() error
+}
+
+// NewPrinter creates a printer for the format: "table" or "json"
+func NewPrinter(w io.Writer, format string) (Printer, error) {
+	switch format {
+	case "", "table":
+		return &tablePrinter{w: tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)}, nil
+	case "json":
+		return &jsonPrinter{enc: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("invalid format: %q", format)
+}
+
+type tablePrinter struct {
+	w *tabwriter.Writer
+}
+
+// Print prints the status in a line like below.
+//
+//	github.com/kyoh86/gogh  main  ↑1 ↓2  dirty,untracked  stash:1  unpushed:topic  no-upstream:feature
+//
+// "unpushed" lists the other branches than the current one which have commits not in their upstream.
+func (p *tablePrinter) Print(r Result) error {
+	if r.Err != nil {
+		_, err := fmt.Fprintf(p.w, "%s\terror: %s\n", r.Location.Path(), r.Err)
+		return err
+	}
+	s := r.Status
+	branch := s.Branch
+	if branch == "" {
+		branch = "(detached)"
+	}
+	var sync string
+	switch {
+	case s.Upstream == "":
+		sync = "-"
+	case s.Ahead == 0 && s.Behind == 0:
+		sync = "="
+	default:
+		sync = fmt.Sprintf("↑%d ↓%d", s.Ahead, s.Behind)
+	}
+	var changes []string
+	if s.Dirty {
+		changes = append(changes, "dirty")
+	}
+	if s.Untracked {
+		changes = append(changes, "untracked")
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "-")
+	}
+	cells := []string{r.Location.Path(), branch, sync, strings.Join(changes, ",")}
+	if s.Stashes > 0 {
+		cells = append(cells, fmt.Sprintf("stash:%d", s.Stashes))
+	}
+	var unpushed []string
+	for _, name := range slices.Sorted(maps.Keys(s.AheadBranches)) {
+		if name != s.Branch {
+			unpushed = append(unpushed, name)
+		}
+	}
+	if len(unpushed) > 0 {
+		cells = append(cells, "unpushed:"+strings.Join(unpushed, ","))
+	}
+	if len(s.NoUpstreamBranches) > 0 {
+		cells = append(cells, "no-upstream:"+strings.Join(s.NoUpstreamBranches, ","))
+	}
+	_, err := fmt.Fprintln(p.w, strings.Join(cells, "\t"))
+	return err
+}
+
+func (p *tablePrinter) Close() error {
+	return p.w.Flush()
+}
+
+type jsonPrinter struct {
+	enc *json.Encoder
+}
+
+type jsonStatus struct {
+	FullPath           string         `json:"fullPath"`
+	Path               string         `json:"path"`
+	Host               string         `json:"host"`
+	Owner              string         `json:"owner"`
+	Name               string         `json:"name"`
+	Branch             string         `json:"branch,omitempty"`
+	Head               string         `json:"head,omitempty"`
+	Upstream           string         `json:"upstream,omitempty"`
+	Ahead              int            `json:"ahead"`
+	Behind             int            `json:"behind"`
+	Dirty              bool           `json:"dirty"`
+	Untracked          bool           `json:"untracked"`
+	Stashes            int            `json:"stashes"`
+	NoUpstreamBranches []string       `json:"noUpstreamBranches,omitempty"`
+	AheadBranches      map[string]int `json:"aheadBranches,omitempty"`
+	Clean              bool           `json:"clean"`
+	Error              string         `json:"error,omitempty"`
+}
+
+// Print prints the status in a line of JSON
+func (p *jsonPrinter) Print(r Result) error {
+	v := jsonStatus{
+		FullPath: r.Location.FullPath(),
+		Path:     r.Location.Path(),
+		Host:     r.Location.Host(),
+		Owner:    r.Location.Owner(),
+		Name:     r.Location.Name(),
+	}
+	if r.Err != nil {
+		v.Error = r.Err.Error()
+	} else {
+		s := r.Status
+		v.Branch = s.Branch
+		v.Head = s.Head
+		v.Upstream = s.Upstream
+		v.Ahead = s.Ahead
+		v.Behind = s.Behind
+		v.Dirty = s.Dirty
+		v.Untracked = s.Untracked
+		v.Stashes = s.Stashes
+		v.NoUpstreamBranches = s.NoUpstreamBranches
+		v.AheadBranches = s.AheadBranches
+		v.Clean = s.Clean()
+	}
+	return p.enc.Encode(v)
+}
+
+func (p *jsonPrinter) Close() error {
+	return nil
+}
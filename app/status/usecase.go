@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/kyoh86/gogh/v4/app/list"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// DefaultConcurrency is the default number of repositories to inspect at once
+const DefaultConcurrency = 8
+
+// Usecase defines the use case for reporting the status of local repositories
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	gitService       git.GitService
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	gitService git.GitService,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		gitService:       gitService,
+	}
+}
+
+type ListOptions = workspace.ListOptions
+
+// Options defines the options for reporting the status
+type Options struct {
+	// Primary reports just the repositories in the primary root
+	Primary bool
+	ListOptions
+	// Concurrency is the maximum number of repositories to inspect at once (default: DefaultConcurrency)
+	Concurrency int
+	// OnlyDirty reports just the repositories which are not clean (see git.Status.Clean)
+	OnlyDirty bool
+}
+
+// Result is a status of a local repository
+type Result struct {
+	Location *repository.Location
+	Status   *git.Status
+	// Err is an error on retrieving the status
+	Err error
+}
+
+// Execute retrieves the status of the local repositories concurrently
+// and yields them in the order of the repositories listed.
+func (uc *Usecase) Execute(ctx context.Context, opts Options) iter.Seq2[*Result, error] {
+	return func(yield func(*Result, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		concurrency := opts.Concurrency
+		if concurrency <= 0 {
+			concurrency = DefaultConcurrency
+		}
+		// Each repository has its own channel to keep the order:
+		// the capacity of the pending limits the number of the running inspections.
+		pending := make(chan chan *Result, concurrency)
+		listErr := make(chan error, 1)
+		go func() {
+			defer close(pending)
+			for location, err := range list.NewUsecase(uc.workspaceService, uc.finderService).Execute(ctx, list.Options{
+				Primary:     opts.Primary,
+				ListOptions: opts.ListOptions,
+			}) {
+				if err != nil {
+					listErr <- err
+					return
+				}
+				ch := make(chan *Result, 1)
+				select {
+				case pending <- ch:
+				case <-ctx.Done():
+					return
+				}
+				go func() {
+					status, err := uc.gitService.GetStatus(ctx, location.FullPath())
+					ch <- &Result{Location: location, Status: status, Err: err}
+				}()
+			}
+		}()
+
+		for ch := range pending {
+			result := <-ch
+			if opts.OnlyDirty && result.Err == nil && result.Status.Clean() {
+				continue
+			}
+			if !yield(result, nil) {
+				return
+			}
+		}
+		select {
+		case err := <-listErr:
+			yield(nil, fmt.Errorf("listing repositories: %w", err))
+		default:
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/status"
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/git_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func locations(names ...string) iter.Seq2[*repository.Location, error] {
+	return func(yield func(*repository.Location, error) bool) {
+		for _, name := range names {
+			if !yield(repository.NewLocation("/root/github.com/kyoh86/"+name, "github.com", "kyoh86", name), nil) {
+				return
+			}
+		}
+	}
+}
+
+func setup(t *testing.T, names ...string) *testtarget.Usecase {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	mockGit := git_mock.NewMockGitService(ctrl)
+
+	mockFinder.EXPECT().ListAllRepository(gomock.Any(), mockWorkspace, workspace.ListOptions{}).Return(locations(names...))
+	mockGit.EXPECT().GetStatus(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, path string) (*git.Status, error) {
+		switch path {
+		case "/root/github.com/kyoh86/dirty":
+			return &git.Status{Branch: "main", Dirty: true}, nil
+		case "/root/github.com/kyoh86/ahead":
+			return &git.Status{Branch: "main", Upstream: "origin/main", Ahead: 1}, nil
+		case "/root/github.com/kyoh86/broken":
+			return nil, errors.New("not a git repository")
+		}
+		return &git.Status{Branch: "main", Upstream: "origin/main"}, nil
+	}).AnyTimes()
+	return testtarget.NewUsecase(mockWorkspace, mockFinder, mockGit)
+}
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	names := []string{"a", "dirty", "b", "ahead", "c", "broken", "d", "e", "f", "g"}
+
+	t.Run("All", func(t *testing.T) {
+		uc := setup(t, names...)
+		var got []string
+		for result, err := range uc.Execute(ctx, testtarget.Options{Concurrency: 2}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, result.Location.Name())
+		}
+		// The order of the repositories should be kept
+		if !slices.Equal(got, names) {
+			t.Errorf("expected %v, got %v", names, got)
+		}
+	})
+
+	t.Run("OnlyDirty", func(t *testing.T) {
+		uc := setup(t, names...)
+		var got []string
+		for result, err := range uc.Execute(ctx, testtarget.Options{OnlyDirty: true}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, result.Location.Name())
+		}
+		want := []string{"dirty", "ahead", "broken"}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		uc := setup(t, names...)
+		count := 0
+		for range uc.Execute(ctx, testtarget.Options{Concurrency: 1}) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("expected to stop at 2, got %d", count)
+		}
+	})
+}
+
+func TestPrinter(t *testing.T) {
+	location := repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh")
+	status := &git.Status{
+		Branch:             "main",
+		Head:               "abc",
+		Upstream:           "origin/main",
+		Ahead:              1,
+		Behind:             2,
+		Dirty:              true,
+		Untracked:          true,
+		Stashes:            1,
+		NoUpstreamBranches: []string{"feature"},
+		AheadBranches:      map[string]int{"main": 1, "topic": 3},
+	}
+	for _, testcase := range []struct {
+		title  string
+		format string
+		result testtarget.Result
+		want   string
+	}{
+		{
+			title:  "table",
+			format: "table",
+			result: testtarget.Result{Location: location, Status: status},
+			want:   "github.com/kyoh86/gogh  main  ↑1 ↓2  dirty,untracked  stash:1  unpushed:topic  no-upstream:feature\n",
+		},
+		{
+			title:  "table clean",
+			format: "",
+			result: testtarget.Result{Location: location, Status: &git.Status{Branch: "main", Upstream: "origin/main"}},
+			want:   "github.com/kyoh86/gogh  main  =  -\n",
+		},
+		{
+			title:  "table error",
+			format: "table",
+			result: testtarget.Result{Location: location, Err: errors.New("broken")},
+			want:   "github.com/kyoh86/gogh  error: broken\n",
+		},
+		{
+			title:  "json",
+			format: "json",
+			result: testtarget.Result{Location: location, Status: status},
+			want:   `{"fullPath":"/root/github.com/kyoh86/gogh","path":"github.com/kyoh86/gogh","host":"github.com","owner":"kyoh86","name":"gogh","branch":"main","head":"abc","upstream":"origin/main","ahead":1,"behind":2,"dirty":true,"untracked":true,"stashes":1,"noUpstreamBranches":["feature"],"aheadBranches":{"main":1,"topic":3},"clean":false}` + "\n",
+		},
+		{
+			title:  "json error",
+			format: "json",
+			result: testtarget.Result{Location: location, Err: errors.New("broken")},
+			want:   `{"fullPath":"/root/github.com/kyoh86/gogh","path":"github.com/kyoh86/gogh","host":"github.com","owner":"kyoh86","name":"gogh","ahead":0,"behind":0,"dirty":false,"untracked":false,"stashes":0,"clean":false,"error":"broken"}` + "\n",
+		},
+	} {
+		t.Run(testcase.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			printer, err := testtarget.NewPrinter(&buf, testcase.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := printer.Print(testcase.result); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := printer.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != testcase.want {
+				t.Errorf("expected %q, got %q", testcase.want, got)
+			}
+		})
+	}
+
+	if _, err := testtarget.NewPrinter(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("expected an error for an invalid format")
+	}
+}
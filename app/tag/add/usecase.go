@@ -0,0 +1,47 @@
+package add
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for adding tags to local repositories
+type Usecase struct {
+	workspaceService workspace.WorkspaceService
+	finderService    workspace.FinderService
+	tagService       workspace.TagService
+	referenceParser  repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	workspaceService workspace.WorkspaceService,
+	finderService workspace.FinderService,
+	tagService workspace.TagService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		workspaceService: workspaceService,
+		finderService:    finderService,
+		tagService:       tagService,
+		referenceParser:  referenceParser,
+	}
+}
+
+// Execute adds the tags to the local repository
+func (uc *Usecase) Execute(ctx context.Context, refs string, tags ...string) (*repository.Reference, error) {
+	ref, err := uc.referenceParser.Parse(refs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.finderService.FindByReference(ctx, uc.workspaceService, *ref); err != nil {
+		return nil, fmt.Errorf("finding local repository: %w", err)
+	}
+	if err := uc.tagService.Add(*ref, tags...); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
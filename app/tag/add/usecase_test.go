@@ -0,0 +1,48 @@
+package add_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/tag/add"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"github.com/kyoh86/gogh/v4/core/workspace_mock"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	mockWorkspace := workspace_mock.NewMockWorkspaceService(ctrl)
+	mockFinder := workspace_mock.NewMockFinderService(ctrl)
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	unknown := repository.NewReference("github.com", "kyoh86", "unknown")
+	mockFinder.EXPECT().FindByReference(gomock.Any(), mockWorkspace, gogh).
+		Return(repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh"), nil).AnyTimes()
+	mockFinder.EXPECT().FindByReference(gomock.Any(), mockWorkspace, unknown).
+		Return(nil, workspace.ErrNotMatched)
+
+	tags := workspace.NewTagService()
+	uc := testtarget.NewUsecase(mockWorkspace, mockFinder, tags, repository.NewReferenceParser("github.com", "kyoh86"))
+
+	ref, err := uc.Execute(ctx, "gogh", "tools", "go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *ref != gogh {
+		t.Errorf("expected %s, got %s", gogh, ref)
+	}
+	if got := tags.Tags(gogh); !slices.Equal(got, []string{"go", "tools"}) {
+		t.Errorf("expected the tags to be added, got %v", got)
+	}
+
+	if _, err := uc.Execute(ctx, "gogh", "invalid tag"); !errors.Is(err, workspace.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %v", err)
+	}
+	if _, err := uc.Execute(ctx, "unknown", "tools"); !errors.Is(err, workspace.ErrNotMatched) {
+		t.Errorf("expected ErrNotMatched for a repository not found, got %v", err)
+	}
+}
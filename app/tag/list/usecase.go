@@ -0,0 +1,55 @@
+package list
+
+import (
+	"context"
+	"iter"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for listing the tags of repositories
+type Usecase struct {
+	tagService      workspace.TagService
+	referenceParser repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	tagService workspace.TagService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		tagService:      tagService,
+		referenceParser: referenceParser,
+	}
+}
+
+// Entry is a tagged repository
+type Entry struct {
+	Reference repository.Reference
+	Tags      []string
+}
+
+// Execute lists the tagged repositories with their tags in the order of the references.
+// If the refs is not empty, it lists just the repository.
+func (uc *Usecase) Execute(_ context.Context, refs string) iter.Seq2[*Entry, error] {
+	return func(yield func(*Entry, error) bool) {
+		if refs != "" {
+			ref, err := uc.referenceParser.Parse(refs)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if tags := uc.tagService.Tags(*ref); len(tags) > 0 {
+				yield(&Entry{Reference: *ref, Tags: tags}, nil)
+			}
+			return
+		}
+		for ref, tags := range uc.tagService.Entries() {
+			if !yield(&Entry{Reference: ref, Tags: tags}, nil) {
+				return
+			}
+		}
+	}
+}
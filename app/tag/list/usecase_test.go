@@ -0,0 +1,44 @@
+package list_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/tag/list"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	tags := workspace.NewTagService()
+	if err := tags.Add(repository.NewReference("github.com", "kyoh86", "gogh"), "tools", "go"); err != nil {
+		t.Fatalf("failed to add tags: %v", err)
+	}
+	if err := tags.Add(repository.NewReference("github.com", "kyoh86", "dotfiles"), "config"); err != nil {
+		t.Fatalf("failed to add tags: %v", err)
+	}
+	uc := testtarget.NewUsecase(tags, repository.NewReferenceParser("github.com", "kyoh86"))
+
+	collect := func(refs string) []string {
+		var got []string
+		for entry, err := range uc.Execute(ctx, refs) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, entry.Reference.String()+":"+strings.Join(entry.Tags, ","))
+		}
+		return got
+	}
+	if got, want := collect(""), []string{"github.com/kyoh86/dotfiles:config", "github.com/kyoh86/gogh:go,tools"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got, want := collect("gogh"), []string{"github.com/kyoh86/gogh:go,tools"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := collect("untagged"); len(got) != 0 {
+		t.Errorf("expected no entry, got %v", got)
+	}
+}
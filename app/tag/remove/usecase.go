@@ -0,0 +1,36 @@
+package remove
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+// Usecase defines the use case for removing tags from repositories
+type Usecase struct {
+	tagService      workspace.TagService
+	referenceParser repository.ReferenceParser
+}
+
+// NewUsecase creates a new instance of Usecase
+func NewUsecase(
+	tagService workspace.TagService,
+	referenceParser repository.ReferenceParser,
+) *Usecase {
+	return &Usecase{
+		tagService:      tagService,
+		referenceParser: referenceParser,
+	}
+}
+
+// Execute removes the tags from the repository.
+// It does not require the local repository, to clean up the tags of the repository removed outside gogh.
+func (uc *Usecase) Execute(_ context.Context, refs string, tags ...string) (*repository.Reference, error) {
+	ref, err := uc.referenceParser.Parse(refs)
+	if err != nil {
+		return nil, err
+	}
+	uc.tagService.Remove(*ref, tags...)
+	return ref, nil
+}
@@ -0,0 +1,32 @@
+package remove_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/app/tag/remove"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestUsecase_Execute(t *testing.T) {
+	ctx := context.Background()
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	tags := workspace.NewTagService()
+	if err := tags.Add(gogh, "tools", "go"); err != nil {
+		t.Fatalf("failed to add tags: %v", err)
+	}
+
+	uc := testtarget.NewUsecase(tags, repository.NewReferenceParser("github.com", "kyoh86"))
+	ref, err := uc.Execute(ctx, "kyoh86/gogh", "go", "unknown")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *ref != gogh {
+		t.Errorf("expected %s, got %s", gogh, ref)
+	}
+	if got := tags.Tags(gogh); !slices.Equal(got, []string{"tools"}) {
+		t.Errorf("expected the tag to be removed, got %v", got)
+	}
+}
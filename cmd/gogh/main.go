@@ -11,15 +11,23 @@ import (
 	"github.com/kyoh86/gogh/v4/app/service"
 	"github.com/kyoh86/gogh/v4/core/auth"
 	"github.com/kyoh86/gogh/v4/core/extra"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
 	"github.com/kyoh86/gogh/v4/core/gogh"
 	"github.com/kyoh86/gogh/v4/core/hook"
+	"github.com/kyoh86/gogh/v4/core/hosting"
 	"github.com/kyoh86/gogh/v4/core/overlay"
 	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/script"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	"github.com/kyoh86/gogh/v4/infra/filesystem"
 	"github.com/kyoh86/gogh/v4/infra/git"
+	"github.com/kyoh86/gogh/v4/infra/gitea"
+	"github.com/kyoh86/gogh/v4/infra/gitexec"
 	"github.com/kyoh86/gogh/v4/infra/github"
+	"github.com/kyoh86/gogh/v4/infra/gitlab"
 	"github.com/kyoh86/gogh/v4/infra/logger"
+	"github.com/kyoh86/gogh/v4/infra/multihost"
+	"github.com/kyoh86/gogh/v4/infra/plaingit"
 	"github.com/kyoh86/gogh/v4/ui/cli"
 )
 
@@ -77,6 +85,16 @@ func run(ctx context.Context) error {
 		return fmt.Errorf("loading tokens: %w", err)
 	}
 
+	providerStore := config.NewProviderStore()
+	providerService, err := config.LoadAlternative(
+		ctx,
+		hosting.NewProviderService,
+		providerStore,
+	)
+	if err != nil {
+		return fmt.Errorf("loading providers: %w", err)
+	}
+
 	workspaceStore := config.NewWorkspaceStore()
 	workspaceService, err := config.LoadAlternative(
 		ctx,
@@ -116,6 +134,102 @@ func run(ctx context.Context) error {
 		return fmt.Errorf("loading extra: %w", err)
 	}
 
+	indexStore := config.NewIndexStore()
+	indexService, err := config.LoadAlternative(
+		ctx,
+		workspace.NewIndexService,
+		indexStore,
+	)
+	if err != nil {
+		return fmt.Errorf("loading index: %w", err)
+	}
+
+	tagStore := config.NewTagStore()
+	tagService, err := config.LoadAlternative(
+		ctx,
+		workspace.NewTagService,
+		tagStore,
+	)
+	if err != nil {
+		return fmt.Errorf("loading tags: %w", err)
+	}
+
+	usageStore := config.NewUsageStore()
+	usageService, err := config.LoadAlternative(
+		ctx,
+		workspace.NewUsageService,
+		usageStore,
+	)
+	if err != nil {
+		return fmt.Errorf("loading usage: %w", err)
+	}
+	finderService := filesystem.NewFinderService(
+		filesystem.FinderIndex(indexService),
+		filesystem.FinderTags(tagService),
+	)
+
+	// apiBaseFor resolves the API base URL for the host from the providers,
+	// falling back to the default one of the provider kind.
+	apiBaseFor := func(defaultBaseURL func(string) string) func(string) string {
+		return func(host string) string {
+			if p, ok := providerService.Get(host); ok && p.APIBase != "" {
+				return p.APIBase
+			}
+			return defaultBaseURL(host)
+		}
+	}
+	gitlabClientID := os.Getenv("GOGH_GITLAB_CLIENT_ID")
+	githubBaseURL := apiBaseFor(github.DefaultBaseURL)
+	gitlabBaseURL := apiBaseFor(gitlab.DefaultBaseURL)
+	giteaBaseURL := apiBaseFor(gitea.DefaultBaseURL)
+	githubHostingService := github.NewHostingService(tokenService, defaultNameService, github.BaseURL(githubBaseURL))
+	gitlabHostingService := gitlab.NewHostingService(tokenService, defaultNameService, gitlab.BaseURL(gitlabBaseURL), gitlab.OAuthClientID(gitlabClientID))
+	giteaHostingService := gitea.NewHostingService(tokenService, defaultNameService, gitea.BaseURL(giteaBaseURL))
+	plaingitHostingService := plaingit.NewHostingService(tokenService, plaingit.CloneURL(func(host string) string {
+		return providerService.Resolve(host).CloneURL
+	}))
+	githubAuthenticateService := github.NewAuthenticateService(github.AuthenticateBaseURL(githubBaseURL))
+	gitlabAuthenticateService := gitlab.NewAuthenticateService(gitlabClientID, gitlab.AuthenticateBaseURL(gitlabBaseURL))
+	giteaAuthenticateService := gitea.NewAuthenticateService(gitea.AuthenticateBaseURL(giteaBaseURL))
+	plaingitAuthenticateService := plaingit.NewAuthenticateService()
+	hostingService := multihost.NewHostingService(tokenService, func(host string) hosting.HostingService {
+		switch providerService.Resolve(host).Kind {
+		case hosting.ProviderKindGitLab:
+			return gitlabHostingService
+		case hosting.ProviderKindGitea:
+			return giteaHostingService
+		case hosting.ProviderKindGit:
+			return plaingitHostingService
+		default:
+			return githubHostingService
+		}
+	})
+	authenticateService := multihost.NewAuthenticateService(func(host string) auth.AuthenticateService {
+		switch providerService.Resolve(host).Kind {
+		case hosting.ProviderKindGitLab:
+			return gitlabAuthenticateService
+		case hosting.ProviderKindGitea:
+			return giteaAuthenticateService
+		case hosting.ProviderKindGit:
+			return plaingitAuthenticateService
+		default:
+			return githubAuthenticateService
+		}
+	})
+
+	var gitService coregit.GitService
+	switch flags.Git.Backend {
+	case "", config.GitBackendGoGit:
+		gitService = git.NewService(git.CloneProgressWriter(os.Stdout))
+	case config.GitBackendSystem:
+		gitService, err = gitexec.NewService(gitexec.CloneProgressWriter(os.Stdout))
+		if err != nil {
+			return fmt.Errorf("initializing system git backend: %w", err)
+		}
+	default:
+		return fmt.Errorf("invalid git backend %q: it must be %q or %q", flags.Git.Backend, config.GitBackendGoGit, config.GitBackendSystem)
+	}
+
 	svc := &service.ServiceSet{
 		DefaultNameStore:   defaultNameStore,
 		DefaultNameService: defaultNameService,
@@ -123,6 +237,9 @@ func run(ctx context.Context) error {
 		TokenStore:   tokenStore,
 		TokenService: tokenService,
 
+		ProviderStore:   providerStore,
+		ProviderService: providerService,
+
 		WorkspaceStore:   workspaceStore,
 		WorkspaceService: workspaceService,
 
@@ -138,14 +255,23 @@ func run(ctx context.Context) error {
 		ExtraStore:   extraStore,
 		ExtraService: extraService,
 
+		IndexStore:   indexStore,
+		IndexService: indexService,
+
+		TagStore:   tagStore,
+		TagService: tagService,
+
+		UsageStore:   usageStore,
+		UsageService: usageService,
+
 		FlagsStore: flagsStore,
 		Flags:      flags,
 
-		ReferenceParser:     repository.NewReferenceParser(defaultNameService.GetDefaultHostAndOwner()),
-		HostingService:      github.NewHostingService(tokenService, defaultNameService),
-		FinderService:       filesystem.NewFinderService(),
-		AuthenticateService: github.NewAuthenticateService(),
-		GitService:          git.NewService(git.CloneProgressWriter(os.Stdout)),
+		ReferenceParser:     repository.NewReferenceParserFor(defaultNameService),
+		HostingService:      hostingService,
+		FinderService:       finderService,
+		AuthenticateService: authenticateService,
+		GitService:          gitService,
 	}
 	cmd, err := cli.NewApp(ctx, gogh.AppName, fmt.Sprintf("%s-%s (%s)", version, commit, date), svc)
 	if err != nil {
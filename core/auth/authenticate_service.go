@@ -21,4 +21,7 @@ type AuthenticateService interface {
 	// Authenticate the user with the given host.
 	// The function will return a user name and a token if the authentication is successful.
 	Authenticate(ctx context.Context, host string, verify Verify) (string, *Token, error)
+	// AuthenticateWithToken verifies the access token (e.g. a personal access token) issued by the host.
+	// The function will return a user name who owns the token and the token if it is valid.
+	AuthenticateWithToken(ctx context.Context, host string, accessToken string) (string, *Token, error)
 }
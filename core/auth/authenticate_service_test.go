@@ -21,6 +21,10 @@ func (m *mockAuthenticateService) Authenticate(ctx context.Context, host string,
 	return "", nil, errors.New("not implemented")
 }
 
+func (m *mockAuthenticateService) AuthenticateWithToken(context.Context, string, string) (string, *auth.Token, error) {
+	return "", nil, errors.New("not implemented")
+}
+
 func TestDeviceAuthResponse(t *testing.T) {
 	// Test DeviceAuthResponse struct
 	response := auth.DeviceAuthResponse{
@@ -56,3 +56,19 @@ func (mr *MockAuthenticateServiceMockRecorder) Authenticate(ctx, host, verify an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthenticateService)(nil).Authenticate), ctx, host, verify)
 }
+
+// AuthenticateWithToken mocks base method.
+func (m *MockAuthenticateService) AuthenticateWithToken(ctx context.Context, host, accessToken string) (string, *auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateWithToken", ctx, host, accessToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*auth.Token)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthenticateWithToken indicates an expected call of AuthenticateWithToken.
+func (mr *MockAuthenticateServiceMockRecorder) AuthenticateWithToken(ctx, host, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateWithToken", reflect.TypeOf((*MockAuthenticateService)(nil).AuthenticateWithToken), ctx, host, accessToken)
+}
@@ -38,6 +38,9 @@ type ExtraService interface {
 	// RemoveAutoExtra removes auto-apply extra for a repository
 	RemoveAutoExtra(ctx context.Context, repo repository.Reference) error
 
+	// MoveAutoExtra re-binds auto-apply extra for a repository to another repository
+	MoveAutoExtra(ctx context.Context, from repository.Reference, to repository.Reference) error
+
 	// RemoveNamedExtra removes named extra by name
 	RemoveNamedExtra(ctx context.Context, name string) error
 
@@ -131,6 +131,30 @@ func (s *serviceImpl) RemoveAutoExtra(ctx context.Context, repo repository.Refer
 	return nil
 }
 
+func (s *serviceImpl) MoveAutoExtra(ctx context.Context, from repository.Reference, to repository.Reference) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fromKey, toKey := from.String(), to.String()
+	e, exists := s.autoExtra[fromKey]
+	if !exists {
+		return ErrExtraNotFound
+	}
+	if fromKey == toKey {
+		return nil
+	}
+	if _, exists := s.autoExtra[toKey]; exists {
+		return ErrExtraAlreadyExists
+	}
+
+	moved := NewAutoExtra(e.ID(), to, e.Source(), e.Items(), e.CreatedAt())
+	delete(s.autoExtra, fromKey)
+	s.autoExtra[toKey] = moved
+	s.byID[moved.ID()] = moved
+	s.dirty = true
+	return nil
+}
+
 func (s *serviceImpl) RemoveNamedExtra(ctx context.Context, name string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
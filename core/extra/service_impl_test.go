@@ -174,6 +174,60 @@ func TestExtraService_RemoveAutoExtra(t *testing.T) {
 	}
 }
 
+func TestExtraService_MoveAutoExtra(t *testing.T) {
+	ctx := context.Background()
+	service := extra.NewExtraService()
+
+	from := repository.NewReference("github.com", "kyoh86", "test")
+	to := repository.NewReference("github.com", "kyoh86", "renamed")
+	other := repository.NewReference("github.com", "kyoh86", "other")
+	source := repository.NewReference("github.com", "kyoh86", "source")
+	items := []extra.Item{{OverlayID: "o1", HookID: "h1"}}
+
+	id, err := service.AddAutoExtra(ctx, from, source, items)
+	if err != nil {
+		t.Fatalf("failed to add auto extra: %v", err)
+	}
+	if _, err := service.AddAutoExtra(ctx, other, source, items); err != nil {
+		t.Fatalf("failed to add auto extra: %v", err)
+	}
+	service.MarkSaved()
+
+	// Test moving to an existing extra
+	if err := service.MoveAutoExtra(ctx, from, other); err != extra.ErrExtraAlreadyExists {
+		t.Errorf("expected ErrExtraAlreadyExists, got %v", err)
+	}
+
+	// Test moving existing extra
+	if err := service.MoveAutoExtra(ctx, from, to); err != nil {
+		t.Fatalf("failed to move auto extra: %v", err)
+	}
+	if !service.HasChanges() {
+		t.Error("expected changes after moving")
+	}
+	if _, err := service.GetAutoExtra(ctx, from); err != extra.ErrExtraNotFound {
+		t.Errorf("expected ErrExtraNotFound for the old repository, got %v", err)
+	}
+	moved, err := service.GetAutoExtra(ctx, to)
+	if err != nil {
+		t.Fatalf("failed to get moved auto extra: %v", err)
+	}
+	if moved.ID() != id {
+		t.Errorf("expected the ID %s to be kept, got %s", id, moved.ID())
+	}
+	if moved.Repository() == nil || *moved.Repository() != to {
+		t.Errorf("expected repository %v, got %v", to, moved.Repository())
+	}
+	if byID, err := service.Get(ctx, id); err != nil || byID != moved {
+		t.Errorf("expected Get to return the moved extra, got %v, %v", byID, err)
+	}
+
+	// Test moving non-existent extra
+	if err := service.MoveAutoExtra(ctx, from, to); err != extra.ErrExtraNotFound {
+		t.Errorf("expected ErrExtraNotFound, got %v", err)
+	}
+}
+
 func TestExtraService_RemoveNamedExtra(t *testing.T) {
 	ctx := context.Background()
 	service := extra.NewExtraService()
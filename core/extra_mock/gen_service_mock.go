@@ -186,6 +186,20 @@ func (mr *MockExtraServiceMockRecorder) MarkSaved() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSaved", reflect.TypeOf((*MockExtraService)(nil).MarkSaved))
 }
 
+// MoveAutoExtra mocks base method.
+func (m *MockExtraService) MoveAutoExtra(ctx context.Context, from, to repository.Reference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveAutoExtra", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveAutoExtra indicates an expected call of MoveAutoExtra.
+func (mr *MockExtraServiceMockRecorder) MoveAutoExtra(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveAutoExtra", reflect.TypeOf((*MockExtraService)(nil).MoveAutoExtra), ctx, from, to)
+}
+
 // Remove mocks base method.
 func (m *MockExtraService) Remove(ctx context.Context, idlike string) error {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"iter"
+	"time"
 )
 
 // ErrRepositoryNotExists is returned when the repository does not exist
@@ -12,10 +13,22 @@ var ErrRepositoryNotExists = errors.New("repository not exists")
 // ErrRepositoryEmpty is returned when the repository is empty
 var ErrRepositoryEmpty = errors.New("repository is empty")
 
+// ErrWorktreeDirty is returned when the worktree has uncommitted changes
+var ErrWorktreeDirty = errors.New("worktree has uncommitted changes")
+
+// ErrDiverged is returned when the local branch cannot be fast-forwarded to the remote branch
+var ErrDiverged = errors.New("local branch has diverged from the remote branch")
+
+// ErrNoUpstream is returned when the current branch does not track a remote branch,
+// and the remote has no branch of the same name.
+var ErrNoUpstream = errors.New("current branch has no upstream")
+
 // GitService handles actual Git operations
 type GitService interface {
 	// AuthenticateWithUsernamePassword authenticates with a username and password
 	AuthenticateWithUsernamePassword(ctx context.Context, username, password string) (GitService, error)
+	// AuthenticateWithSSH authenticates with SSH keys for the SSH remotes
+	AuthenticateWithSSH(ctx context.Context, opts SSHAuthOptions) (GitService, error)
 
 	// Clone performs the actual git clone operation
 	Clone(ctx context.Context, remoteURL string, localPath string, opts CloneOptions) error
@@ -23,6 +36,27 @@ type GitService interface {
 	// Init initializes a new git repository at the specified local path
 	Init(ctx context.Context, remoteURL string, localPath string, isBare bool, opts InitOptions) error
 
+	// Fetch fetches the remote and returns whether any remote-tracking reference is updated
+	Fetch(ctx context.Context, localPath string, opts FetchOptions) (bool, error)
+
+	// Pull fetches the remote and fast-forwards the current branch.
+	// It returns whether the HEAD is moved.
+	// It pulls the upstream of the current branch, or the same-named branch of the remote if it has no upstream.
+	// It returns ErrWorktreeDirty if the worktree has uncommitted changes,
+	// ErrDiverged if the current branch cannot be fast-forwarded,
+	// and ErrNoUpstream if the current branch has nothing to pull from.
+	Pull(ctx context.Context, localPath string, opts PullOptions) (bool, error)
+
+	// GetStatus retrieves the status of the local repository: the current branch, changes and so on
+	GetStatus(ctx context.Context, localPath string) (*Status, error)
+
+	// ListPrunableBranches lists the local branches which are merged into the default branch of the default remote
+	// or whose upstream is gone. The current branch and the default branch are never listed.
+	ListPrunableBranches(ctx context.Context, localPath string) ([]PrunableBranch, error)
+
+	// DeleteBranch deletes the local branch even if it is not merged
+	DeleteBranch(ctx context.Context, localPath string, branch string) error
+
 	// SetRemote configures remote repositories in a git repo
 	SetRemotes(ctx context.Context, localPath string, name string, remotes []string) error
 	// SetDefaultRemote configures the default remote repositories (for usually 'origin') in a git repo
@@ -48,9 +82,95 @@ type GitService interface {
 	ListAllFiles(ctx context.Context, localPath string, filePatterns []string) iter.Seq2[string, error]
 }
 
+// SSHAuthOptions contains options for the SSH authentication
+type SSHAuthOptions struct {
+	// User is a user name to login to the SSH server (default: "git")
+	User string
+	// KeyFiles are paths of the private key files.
+	// If it is empty, the keys in the ssh-agent (SSH_AUTH_SOCK) will be used.
+	KeyFiles []string
+	// KnownHostsFiles are paths of the known_hosts files to verify the host keys.
+	// If it is empty, the files in SSH_KNOWN_HOSTS or the default ones (~/.ssh/known_hosts, /etc/ssh/ssh_known_hosts) will be used.
+	KnownHostsFiles []string
+}
+
 // CloneOptions contains options for the local clone operation
 type CloneOptions struct {
-	// Reserved for future use
+	// Depth limits the history to the specified number of the latest commits (0: full history)
+	Depth int
+	// Branch is a branch to check out instead of the default branch of the remote
+	Branch string
+	// SingleBranch fetches only the history of the branch (Branch or the default branch)
+	SingleBranch bool
+	// Filter is a filter for the partial clone (e.g.: "blob:none").
+	// It is ignored if the backend does not support the partial clone.
+	Filter string
+	// RecurseSubmodules clones the submodules recursively
+	RecurseSubmodules bool
+}
+
+// FetchOptions contains options for the fetch operation
+type FetchOptions struct {
+	// Remote is a name of the remote to fetch (default: "origin")
+	Remote string
+}
+
+// PullOptions contains options for the pull operation
+type PullOptions struct {
+	// Remote is a name of the remote to pull from (default: "origin")
+	Remote string
+}
+
+// Status is a status of a local repository
+type Status struct {
+	// Branch is the name of the current branch (empty if the HEAD is detached)
+	Branch string
+	// Head is the commit hash of the HEAD (empty if there's no commit)
+	Head string
+	// HeadTime is the committer time of the HEAD (zero if there's no commit)
+	HeadTime time.Time
+	// Upstream is the upstream of the current branch (e.g. "origin/main"; empty if it is not set)
+	Upstream string
+	// Ahead is the number of commits in the current branch which are not in the upstream
+	Ahead int
+	// Behind is the number of commits in the upstream which are not in the current branch
+	Behind int
+	// Dirty indicates that the tracked files have uncommitted changes
+	Dirty bool
+	// Untracked indicates that there are untracked files (excluding ignored ones)
+	Untracked bool
+	// Stashes is the number of the stashed changes
+	Stashes int
+	// NoUpstreamBranches are the local branches which have no upstream
+	NoUpstreamBranches []string
+	// AheadBranches are the local branches (including the current one) which have commits not in their upstream,
+	// with the number of the commits
+	AheadBranches map[string]int
+}
+
+// Clean returns whether the repository has nothing which exists only in the local:
+// no uncommitted changes, no untracked files, no unpushed commits in any branch, no stashes and no branches without upstream.
+func (s Status) Clean() bool {
+	return !s.Dirty && !s.Untracked && s.Ahead == 0 && len(s.AheadBranches) == 0 && s.Stashes == 0 && len(s.NoUpstreamBranches) == 0
+}
+
+// PruneReason is a reason why a branch can be pruned
+type PruneReason string
+
+const (
+	// PruneReasonMerged indicates that the branch is merged into the default branch
+	PruneReasonMerged PruneReason = "merged"
+	// PruneReasonUpstreamGone indicates that the upstream of the branch is deleted from the remote
+	PruneReasonUpstreamGone PruneReason = "upstream gone"
+)
+
+// PrunableBranch is a local branch which can be pruned
+type PrunableBranch struct {
+	// Name is a name of the branch
+	Name string
+	// Reason is a reason why the branch can be pruned.
+	// If the branch is merged and its upstream is gone, PruneReasonMerged is used.
+	Reason PruneReason
 }
 
 // InitOptions contains options for the local clone operation
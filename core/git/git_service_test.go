@@ -23,13 +23,51 @@ func TestGitServiceErrors(t *testing.T) {
 			t.Errorf("unexpected error message: %v", err)
 		}
 	})
+
+	t.Run("ErrWorktreeDirty", func(t *testing.T) {
+		err := git.ErrWorktreeDirty
+		if err.Error() != "worktree has uncommitted changes" {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("ErrDiverged", func(t *testing.T) {
+		err := git.ErrDiverged
+		if err.Error() != "local branch has diverged from the remote branch" {
+			t.Errorf("unexpected error message: %v", err)
+		}
+	})
 }
 
 func TestCloneOptions(t *testing.T) {
-	// Test that CloneOptions can be instantiated
+	// Test that the zero value means a full clone of the default branch
 	opts := git.CloneOptions{}
-	// Currently no fields, but this ensures the struct exists and can be used
-	_ = opts
+	if opts.Depth != 0 || opts.Branch != "" || opts.SingleBranch || opts.Filter != "" || opts.RecurseSubmodules {
+		t.Errorf("unexpected zero value: %+v", opts)
+	}
+}
+
+func TestStatusClean(t *testing.T) {
+	for _, testcase := range []struct {
+		title  string
+		status git.Status
+		want   bool
+	}{
+		{title: "zero", status: git.Status{}, want: true},
+		{title: "behind", status: git.Status{Branch: "main", Upstream: "origin/main", Behind: 2}, want: true},
+		{title: "dirty", status: git.Status{Dirty: true}, want: false},
+		{title: "untracked", status: git.Status{Untracked: true}, want: false},
+		{title: "ahead", status: git.Status{Ahead: 1}, want: false},
+		{title: "stashes", status: git.Status{Stashes: 1}, want: false},
+		{title: "no upstream", status: git.Status{NoUpstreamBranches: []string{"feature"}}, want: false},
+		{title: "other branch ahead", status: git.Status{Branch: "main", Upstream: "origin/main", AheadBranches: map[string]int{"feature": 1}}, want: false},
+	} {
+		t.Run(testcase.title, func(t *testing.T) {
+			if got := testcase.status.Clean(); got != testcase.want {
+				t.Errorf("expected %v, got %v", testcase.want, got)
+			}
+		})
+	}
 }
 
 func TestInitOptions(t *testing.T) {
@@ -42,7 +80,13 @@ func TestInitOptions(t *testing.T) {
 // MockGitService is a mock implementation of GitService for testing
 type MockGitService struct {
 	AuthenticateFunc      func(ctx context.Context, username, password string) (git.GitService, error)
+	AuthenticateSSHFunc   func(ctx context.Context, opts git.SSHAuthOptions) (git.GitService, error)
 	CloneFunc             func(ctx context.Context, remoteURL string, localPath string, opts git.CloneOptions) error
+	FetchFunc             func(ctx context.Context, localPath string, opts git.FetchOptions) (bool, error)
+	PullFunc              func(ctx context.Context, localPath string, opts git.PullOptions) (bool, error)
+	GetStatusFunc         func(ctx context.Context, localPath string) (*git.Status, error)
+	ListPrunableFunc      func(ctx context.Context, localPath string) ([]git.PrunableBranch, error)
+	DeleteBranchFunc      func(ctx context.Context, localPath string, branch string) error
 	InitFunc              func(ctx context.Context, remoteURL string, localPath string, isBare bool, opts git.InitOptions) error
 	SetRemotesFunc        func(ctx context.Context, localPath string, name string, remotes []string) error
 	SetDefaultRemotesFunc func(ctx context.Context, localPath string, remotes []string) error
@@ -59,6 +103,13 @@ func (m *MockGitService) AuthenticateWithUsernamePassword(ctx context.Context, u
 	return m, nil
 }
 
+func (m *MockGitService) AuthenticateWithSSH(ctx context.Context, opts git.SSHAuthOptions) (git.GitService, error) {
+	if m.AuthenticateSSHFunc != nil {
+		return m.AuthenticateSSHFunc(ctx, opts)
+	}
+	return m, nil
+}
+
 func (m *MockGitService) Clone(ctx context.Context, remoteURL string, localPath string, opts git.CloneOptions) error {
 	if m.CloneFunc != nil {
 		return m.CloneFunc(ctx, remoteURL, localPath, opts)
@@ -66,6 +117,41 @@ func (m *MockGitService) Clone(ctx context.Context, remoteURL string, localPath
 	return nil
 }
 
+func (m *MockGitService) Fetch(ctx context.Context, localPath string, opts git.FetchOptions) (bool, error) {
+	if m.FetchFunc != nil {
+		return m.FetchFunc(ctx, localPath, opts)
+	}
+	return false, nil
+}
+
+func (m *MockGitService) Pull(ctx context.Context, localPath string, opts git.PullOptions) (bool, error) {
+	if m.PullFunc != nil {
+		return m.PullFunc(ctx, localPath, opts)
+	}
+	return false, nil
+}
+
+func (m *MockGitService) GetStatus(ctx context.Context, localPath string) (*git.Status, error) {
+	if m.GetStatusFunc != nil {
+		return m.GetStatusFunc(ctx, localPath)
+	}
+	return &git.Status{}, nil
+}
+
+func (m *MockGitService) ListPrunableBranches(ctx context.Context, localPath string) ([]git.PrunableBranch, error) {
+	if m.ListPrunableFunc != nil {
+		return m.ListPrunableFunc(ctx, localPath)
+	}
+	return nil, nil
+}
+
+func (m *MockGitService) DeleteBranch(ctx context.Context, localPath string, branch string) error {
+	if m.DeleteBranchFunc != nil {
+		return m.DeleteBranchFunc(ctx, localPath, branch)
+	}
+	return nil
+}
+
 func (m *MockGitService) Init(ctx context.Context, remoteURL string, localPath string, isBare bool, opts git.InitOptions) error {
 	if m.InitFunc != nil {
 		return m.InitFunc(ctx, remoteURL, localPath, isBare, opts)
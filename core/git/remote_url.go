@@ -0,0 +1,66 @@
+package git
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// scpLikeURL matches the scp-like syntax of the SSH remote URL (e.g.: "git@github.com:kyoh86/gogh.git")
+var scpLikeURL = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.+)$`)
+
+// IsSSHURL returns whether the remote URL uses the SSH transport:
+// "ssh://[user@]host[:port]/path" or scp-like "[user@]host:path".
+func IsSSHURL(remoteURL string) bool {
+	if strings.HasPrefix(remoteURL, "ssh://") || strings.HasPrefix(remoteURL, "git+ssh://") {
+		return true
+	}
+	if strings.Contains(remoteURL, "://") {
+		return false
+	}
+	return scpLikeURL.MatchString(remoteURL)
+}
+
+// SSHUserOf returns the user name in the SSH remote URL, or an empty string if it is not specified.
+func SSHUserOf(remoteURL string) string {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.User == nil {
+			return ""
+		}
+		return u.User.Username()
+	}
+	if m := scpLikeURL.FindStringSubmatch(remoteURL); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ToSSHURL converts the HTTP(S) remote URL (e.g.: "https://github.com/kyoh86/gogh.git")
+// to the scp-like SSH one (e.g.: "git@github.com:kyoh86/gogh.git").
+// Other URLs are returned as is.
+func ToSSHURL(remoteURL string) string {
+	u, err := url.Parse(remoteURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return remoteURL
+	}
+	return "git@" + u.Hostname() + ":" + strings.TrimPrefix(u.Path, "/")
+}
+
+// ToHTTPSURL converts the SSH remote URL (e.g.: "git@github.com:kyoh86/gogh.git")
+// to the HTTPS one (e.g.: "https://github.com/kyoh86/gogh.git").
+// Other URLs are returned as is.
+func ToHTTPSURL(remoteURL string) string {
+	if !IsSSHURL(remoteURL) {
+		return remoteURL
+	}
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return remoteURL
+		}
+		return (&url.URL{Scheme: "https", Host: u.Hostname(), Path: u.Path}).String()
+	}
+	m := scpLikeURL.FindStringSubmatch(remoteURL)
+	return (&url.URL{Scheme: "https", Host: m[2], Path: "/" + strings.TrimPrefix(m[3], "/")}).String()
+}
@@ -0,0 +1,66 @@
+package git_test
+
+import (
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/core/git"
+)
+
+func TestIsSSHURL(t *testing.T) {
+	for remoteURL, want := range map[string]bool{
+		"git@github.com:kyoh86/gogh.git":              true,
+		"github.com:kyoh86/gogh.git":                  true,
+		"ssh://git@github.com/kyoh86/gogh.git":        true,
+		"ssh://git@git.example.com:2222/srv/repo":     true,
+		"https://github.com/kyoh86/gogh.git":          false,
+		"http://git.example.com/kyoh86/gogh.git":      false,
+		"file:///srv/git/kyoh86/gogh.git":             false,
+		"/srv/git/kyoh86/gogh.git":                    false,
+		"C:/srv/git/kyoh86/gogh.git":                  true, // indistinguishable from scp-like syntax with a host "C"
+		"https://user@github.com:443/kyoh86/gogh.git": false,
+	} {
+		if got := testtarget.IsSSHURL(remoteURL); got != want {
+			t.Errorf("IsSSHURL(%q) = %v, want %v", remoteURL, got, want)
+		}
+	}
+}
+
+func TestSSHUserOf(t *testing.T) {
+	for remoteURL, want := range map[string]string{
+		"git@github.com:kyoh86/gogh.git":      "git",
+		"github.com:kyoh86/gogh.git":          "",
+		"ssh://gitolite@git.example.com/repo": "gitolite",
+		"ssh://git.example.com/repo":          "",
+		"https://github.com/kyoh86/gogh.git":  "",
+	} {
+		if got := testtarget.SSHUserOf(remoteURL); got != want {
+			t.Errorf("SSHUserOf(%q) = %q, want %q", remoteURL, got, want)
+		}
+	}
+}
+
+func TestToSSHURL(t *testing.T) {
+	for remoteURL, want := range map[string]string{
+		"https://github.com/kyoh86/gogh.git":      "git@github.com:kyoh86/gogh.git",
+		"https://gitlab.example.com:8443/g/p.git": "git@gitlab.example.com:g/p.git",
+		"git@github.com:kyoh86/gogh.git":          "git@github.com:kyoh86/gogh.git",
+		"file:///srv/git/kyoh86/gogh.git":         "file:///srv/git/kyoh86/gogh.git",
+	} {
+		if got := testtarget.ToSSHURL(remoteURL); got != want {
+			t.Errorf("ToSSHURL(%q) = %q, want %q", remoteURL, got, want)
+		}
+	}
+}
+
+func TestToHTTPSURL(t *testing.T) {
+	for remoteURL, want := range map[string]string{
+		"git@github.com:kyoh86/gogh.git":         "https://github.com/kyoh86/gogh.git",
+		"ssh://git@git.example.com:2222/g/p.git": "https://git.example.com/g/p.git",
+		"https://github.com/kyoh86/gogh.git":     "https://github.com/kyoh86/gogh.git",
+		"file:///srv/git/kyoh86/gogh.git":        "file:///srv/git/kyoh86/gogh.git",
+	} {
+		if got := testtarget.ToHTTPSURL(remoteURL); got != want {
+			t.Errorf("ToHTTPSURL(%q) = %q, want %q", remoteURL, got, want)
+		}
+	}
+}
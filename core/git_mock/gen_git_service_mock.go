@@ -42,6 +42,21 @@ func (m *MockGitService) EXPECT() *MockGitServiceMockRecorder {
 	return m.recorder
 }
 
+// AuthenticateWithSSH mocks base method.
+func (m *MockGitService) AuthenticateWithSSH(ctx context.Context, opts git.SSHAuthOptions) (git.GitService, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateWithSSH", ctx, opts)
+	ret0, _ := ret[0].(git.GitService)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateWithSSH indicates an expected call of AuthenticateWithSSH.
+func (mr *MockGitServiceMockRecorder) AuthenticateWithSSH(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateWithSSH", reflect.TypeOf((*MockGitService)(nil).AuthenticateWithSSH), ctx, opts)
+}
+
 // AuthenticateWithUsernamePassword mocks base method.
 func (m *MockGitService) AuthenticateWithUsernamePassword(ctx context.Context, username, password string) (git.GitService, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +86,35 @@ func (mr *MockGitServiceMockRecorder) Clone(ctx, remoteURL, localPath, opts any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clone", reflect.TypeOf((*MockGitService)(nil).Clone), ctx, remoteURL, localPath, opts)
 }
 
+// DeleteBranch mocks base method.
+func (m *MockGitService) DeleteBranch(ctx context.Context, localPath, branch string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", ctx, localPath, branch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockGitServiceMockRecorder) DeleteBranch(ctx, localPath, branch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockGitService)(nil).DeleteBranch), ctx, localPath, branch)
+}
+
+// Fetch mocks base method.
+func (m *MockGitService) Fetch(ctx context.Context, localPath string, opts git.FetchOptions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", ctx, localPath, opts)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockGitServiceMockRecorder) Fetch(ctx, localPath, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockGitService)(nil).Fetch), ctx, localPath, opts)
+}
+
 // GetDefaultRemotes mocks base method.
 func (m *MockGitService) GetDefaultRemotes(ctx context.Context, localPath string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +145,21 @@ func (mr *MockGitServiceMockRecorder) GetRemotes(ctx, localPath, name any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemotes", reflect.TypeOf((*MockGitService)(nil).GetRemotes), ctx, localPath, name)
 }
 
+// GetStatus mocks base method.
+func (m *MockGitService) GetStatus(ctx context.Context, localPath string) (*git.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", ctx, localPath)
+	ret0, _ := ret[0].(*git.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockGitServiceMockRecorder) GetStatus(ctx, localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockGitService)(nil).GetStatus), ctx, localPath)
+}
+
 // Init mocks base method.
 func (m *MockGitService) Init(ctx context.Context, remoteURL, localPath string, isBare bool, opts git.InitOptions) error {
 	m.ctrl.T.Helper()
@@ -143,6 +202,36 @@ func (mr *MockGitServiceMockRecorder) ListExcludedFiles(ctx, localPath, filePatt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExcludedFiles", reflect.TypeOf((*MockGitService)(nil).ListExcludedFiles), ctx, localPath, filePatterns)
 }
 
+// ListPrunableBranches mocks base method.
+func (m *MockGitService) ListPrunableBranches(ctx context.Context, localPath string) ([]git.PrunableBranch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPrunableBranches", ctx, localPath)
+	ret0, _ := ret[0].([]git.PrunableBranch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPrunableBranches indicates an expected call of ListPrunableBranches.
+func (mr *MockGitServiceMockRecorder) ListPrunableBranches(ctx, localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPrunableBranches", reflect.TypeOf((*MockGitService)(nil).ListPrunableBranches), ctx, localPath)
+}
+
+// Pull mocks base method.
+func (m *MockGitService) Pull(ctx context.Context, localPath string, opts git.PullOptions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pull", ctx, localPath, opts)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pull indicates an expected call of Pull.
+func (mr *MockGitServiceMockRecorder) Pull(ctx, localPath, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pull", reflect.TypeOf((*MockGitService)(nil).Pull), ctx, localPath, opts)
+}
+
 // SetDefaultRemotes mocks base method.
 func (m *MockGitService) SetDefaultRemotes(ctx context.Context, localPath string, remotes []string) error {
 	m.ctrl.T.Helper()
@@ -48,6 +48,13 @@ type HostingService interface {
 
 // ListRepositoryOptions represents options for listing repositories
 type ListRepositoryOptions struct {
+	// Host limits the repositories to those on the host
+	// If empty, repositories on all hosts which have a token will be listed
+	Host string
+	// Owner limits the repositories to those owned by the owner (a user, an organization or a group).
+	// If empty, repositories which the user is affiliated with will be listed.
+	// OwnerAffiliations is ignored if it is set.
+	Owner string
 	// OrderBy specifies the ordering of the repositories
 	OrderBy RepositoryOrder
 	// Privacy specifies the privacy level of the repositories
@@ -0,0 +1,270 @@
+package hosting
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kyoh86/gogh/v4/core/git"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+)
+
+// ProviderKind is a kind of the hosting service which serves repositories on a host
+type ProviderKind string
+
+const (
+	// ProviderKindGitHub is GitHub (github.com or GitHub Enterprise Server)
+	ProviderKindGitHub ProviderKind = "github"
+	// ProviderKindGitLab is GitLab (gitlab.com or self-managed GitLab)
+	ProviderKindGitLab ProviderKind = "gitlab"
+	// ProviderKindGitea is Gitea or Forgejo
+	ProviderKindGitea ProviderKind = "gitea"
+	// ProviderKindGit is a plain git server without any API (e.g.: gitolite, or a host serving bare repositories over SSH)
+	ProviderKindGit ProviderKind = "git"
+)
+
+// ProviderKinds returns all of the supported provider kinds
+func ProviderKinds() []ProviderKind {
+	return []ProviderKind{
+		ProviderKindGitHub,
+		ProviderKindGitLab,
+		ProviderKindGitea,
+		ProviderKindGit,
+	}
+}
+
+// ErrInvalidProviderKind is returned when the provider kind is not supported
+var ErrInvalidProviderKind = errors.New("invalid provider kind")
+
+// ParseProviderKind parses a string as a ProviderKind
+func ParseProviderKind(s string) (ProviderKind, error) {
+	kind := ProviderKind(strings.ToLower(s))
+	if !slices.Contains(ProviderKinds(), kind) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProviderKind, s)
+	}
+	return kind, nil
+}
+
+// Protocol is a protocol preferred to access the repositories on a host
+type Protocol string
+
+const (
+	// ProtocolHTTPS accesses repositories over HTTPS with the access token (default)
+	ProtocolHTTPS Protocol = "https"
+	// ProtocolSSH accesses repositories over SSH with the SSH keys
+	ProtocolSSH Protocol = "ssh"
+)
+
+// Protocols returns all of the supported protocols
+func Protocols() []Protocol {
+	return []Protocol{ProtocolHTTPS, ProtocolSSH}
+}
+
+// ErrInvalidProtocol is returned when the protocol is not supported
+var ErrInvalidProtocol = errors.New("invalid protocol")
+
+// ParseProtocol parses a string as a Protocol
+func ParseProtocol(s string) (Protocol, error) {
+	protocol := Protocol(strings.ToLower(s))
+	if !slices.Contains(Protocols(), protocol) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProtocol, s)
+	}
+	return protocol, nil
+}
+
+// Provider describes the hosting service for a host
+type Provider struct {
+	// Host is a host name (e.g.: "github.example.com")
+	Host string
+	// Kind is a kind of the hosting service
+	Kind ProviderKind
+	// APIBase is a base URL of the REST API (e.g.: "https://github.example.com/api/v3").
+	// If it is empty, the default one for the kind will be used.
+	APIBase string
+	// CloneURL is a template of the URL to clone repositories from the host (e.g.: "git@git.example.com:{{.Owner}}/{{.Name}}.git").
+	// It is used only for ProviderKindGit. If it is empty, DefaultCloneURLTemplate will be used.
+	CloneURL string
+	// Protocol is the protocol preferred to clone repositories and to set remotes.
+	// If it is empty, ProtocolHTTPS will be used.
+	Protocol Protocol
+	// SSHKeyFile is a path of the private key file to access the host over SSH.
+	// If it is empty, the keys in the ssh-agent will be used.
+	SSHKeyFile string
+}
+
+// PreferredProtocol returns the protocol preferred for the host
+func (p Provider) PreferredProtocol() Protocol {
+	if p.Protocol == "" {
+		return ProtocolHTTPS
+	}
+	return p.Protocol
+}
+
+// DefaultCloneURLTemplate is the default template of the clone URL for the plain git servers
+const DefaultCloneURLTemplate = "https://{{.Host}}/{{.Owner}}/{{.Name}}.git"
+
+// CloneURLFor renders the clone URL for the repository from the provider's CloneURL template.
+func (p Provider) CloneURLFor(ref repository.Reference) (string, error) {
+	text := p.CloneURL
+	if text == "" {
+		text = DefaultCloneURLTemplate
+	}
+	tmpl, err := template.New("clone-url").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid clone URL template %q: %w", text, err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, struct {
+		Host  string
+		Owner string
+		Name  string
+	}{
+		Host:  ref.Host(),
+		Owner: ref.Owner(),
+		Name:  ref.Name(),
+	}); err != nil {
+		return "", fmt.Errorf("render clone URL template %q: %w", text, err)
+	}
+	return buf.String(), nil
+}
+
+// RemoteURLOf converts the clone URL to the form of the preferred protocol.
+// Clone URLs of the plain git servers are returned as is, because their templates decide the form.
+func (p Provider) RemoteURLOf(cloneURL string) string {
+	if p.Kind == ProviderKindGit {
+		return cloneURL
+	}
+	if p.PreferredProtocol() == ProtocolSSH {
+		return git.ToSSHURL(cloneURL)
+	}
+	return git.ToHTTPSURL(cloneURL)
+}
+
+// GuessProvider guesses the provider from the host name.
+// Hosts which cannot be guessed are treated as GitHub (Enterprise Server).
+func GuessProvider(host string) Provider {
+	switch {
+	case host == "gitlab.com", strings.HasPrefix(host, "gitlab."):
+		return Provider{Host: host, Kind: ProviderKindGitLab}
+	case host == "codeberg.org", strings.HasPrefix(host, "gitea."), strings.HasPrefix(host, "forgejo."):
+		return Provider{Host: host, Kind: ProviderKindGitea}
+	default:
+		return Provider{Host: host, Kind: ProviderKindGitHub}
+	}
+}
+
+// ProviderService manages the registry of the providers for each host
+type ProviderService interface {
+	// Get returns the provider registered for the host
+	Get(host string) (Provider, bool)
+	// Resolve returns the provider registered for the host,
+	// or guesses it from the host name if it is not registered
+	Resolve(host string) Provider
+	// Set registers the provider for the host (overwrites the existing one)
+	Set(provider Provider) error
+	// Remove removes the provider registered for the host
+	Remove(host string) error
+	// Entries returns all registered providers in the order of the host name
+	Entries() []Provider
+
+	store.Content
+}
+
+// ErrProviderNotFound is returned when no provider is registered for the host
+var ErrProviderNotFound = errors.New("provider not found")
+
+type providerServiceImpl struct {
+	providers map[string]Provider
+	changed   bool
+}
+
+// NewProviderService creates a new ProviderService instance
+func NewProviderService() ProviderService {
+	return &providerServiceImpl{
+		providers: map[string]Provider{},
+	}
+}
+
+// Get implements ProviderService.
+func (s *providerServiceImpl) Get(host string) (Provider, bool) {
+	p, ok := s.providers[host]
+	return p, ok
+}
+
+// Resolve implements ProviderService.
+func (s *providerServiceImpl) Resolve(host string) Provider {
+	if p, ok := s.providers[host]; ok {
+		return p
+	}
+	return GuessProvider(host)
+}
+
+// Set implements ProviderService.
+func (s *providerServiceImpl) Set(provider Provider) error {
+	if err := repository.ValidateHost(provider.Host); err != nil {
+		return err
+	}
+	if _, err := ParseProviderKind(string(provider.Kind)); err != nil {
+		return err
+	}
+	if provider.APIBase != "" {
+		u, err := url.Parse(provider.APIBase)
+		if err != nil {
+			return fmt.Errorf("invalid API base URL %q: %w", provider.APIBase, err)
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid API base URL %q: it must be an absolute http(s) URL", provider.APIBase)
+		}
+	}
+	if provider.Protocol != "" {
+		if _, err := ParseProtocol(string(provider.Protocol)); err != nil {
+			return err
+		}
+	}
+	if provider.CloneURL != "" {
+		if _, err := provider.CloneURLFor(repository.NewReference(provider.Host, "owner", "name")); err != nil {
+			return err
+		}
+	}
+	s.providers[provider.Host] = provider
+	s.changed = true
+	return nil
+}
+
+// Remove implements ProviderService.
+func (s *providerServiceImpl) Remove(host string) error {
+	if _, ok := s.providers[host]; !ok {
+		return fmt.Errorf("%w for %q", ErrProviderNotFound, host)
+	}
+	delete(s.providers, host)
+	s.changed = true
+	return nil
+}
+
+// Entries implements ProviderService.
+func (s *providerServiceImpl) Entries() []Provider {
+	entries := make([]Provider, 0, len(s.providers))
+	for _, p := range s.providers {
+		entries = append(entries, p)
+	}
+	slices.SortFunc(entries, func(a, b Provider) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+	return entries
+}
+
+// HasChanges implements ProviderService.
+func (s *providerServiceImpl) HasChanges() bool {
+	return s.changed
+}
+
+// MarkSaved implements ProviderService.
+func (s *providerServiceImpl) MarkSaved() {
+	s.changed = false
+}
+
+var _ ProviderService = (*providerServiceImpl)(nil)
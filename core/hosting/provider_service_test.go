@@ -0,0 +1,245 @@
+package hosting_test
+
+import (
+	"errors"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+)
+
+func TestParseProviderKind(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		want    testtarget.ProviderKind
+		wantErr bool
+	}{
+		{input: "github", want: testtarget.ProviderKindGitHub},
+		{input: "GitLab", want: testtarget.ProviderKindGitLab},
+		{input: "gitea", want: testtarget.ProviderKindGitea},
+		{input: "git", want: testtarget.ProviderKindGit},
+		{input: "bitbucket", wantErr: true},
+		{input: "", wantErr: true},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := testtarget.ParseProviderKind(tc.input)
+			if tc.wantErr {
+				if !errors.Is(err, testtarget.ErrInvalidProviderKind) {
+					t.Errorf("expected ErrInvalidProviderKind, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseProtocol(t *testing.T) {
+	for _, tc := range []struct {
+		input   string
+		want    testtarget.Protocol
+		wantErr bool
+	}{
+		{input: "https", want: testtarget.ProtocolHTTPS},
+		{input: "SSH", want: testtarget.ProtocolSSH},
+		{input: "git", wantErr: true},
+		{input: "", wantErr: true},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := testtarget.ParseProtocol(tc.input)
+			if tc.wantErr {
+				if !errors.Is(err, testtarget.ErrInvalidProtocol) {
+					t.Errorf("expected ErrInvalidProtocol, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProviderPreferredProtocol(t *testing.T) {
+	if got := (testtarget.Provider{}).PreferredProtocol(); got != testtarget.ProtocolHTTPS {
+		t.Errorf("expected https by default, got %q", got)
+	}
+	if got := (testtarget.Provider{Protocol: testtarget.ProtocolSSH}).PreferredProtocol(); got != testtarget.ProtocolSSH {
+		t.Errorf("expected ssh, got %q", got)
+	}
+}
+
+func TestProviderRemoteURLOf(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		provider testtarget.Provider
+		cloneURL string
+		want     string
+	}{
+		{
+			title:    "https by default",
+			provider: testtarget.Provider{Host: "github.com", Kind: testtarget.ProviderKindGitHub},
+			cloneURL: "https://github.com/kyoh86/gogh.git",
+			want:     "https://github.com/kyoh86/gogh.git",
+		},
+		{
+			title:    "prefer ssh",
+			provider: testtarget.Provider{Host: "github.com", Kind: testtarget.ProviderKindGitHub, Protocol: testtarget.ProtocolSSH},
+			cloneURL: "https://github.com/kyoh86/gogh.git",
+			want:     "git@github.com:kyoh86/gogh.git",
+		},
+		{
+			title:    "prefer https",
+			provider: testtarget.Provider{Host: "gitlab.com", Kind: testtarget.ProviderKindGitLab, Protocol: testtarget.ProtocolHTTPS},
+			cloneURL: "git@gitlab.com:group/project.git",
+			want:     "https://gitlab.com/group/project.git",
+		},
+		{
+			title:    "plain git server",
+			provider: testtarget.Provider{Host: "git.example.com", Kind: testtarget.ProviderKindGit},
+			cloneURL: "git@git.example.com:kyoh86/gogh",
+			want:     "git@git.example.com:kyoh86/gogh",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			if got := tc.provider.RemoteURLOf(tc.cloneURL); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGuessProvider(t *testing.T) {
+	for host, want := range map[string]testtarget.ProviderKind{
+		"github.com":         testtarget.ProviderKindGitHub,
+		"github.example.com": testtarget.ProviderKindGitHub,
+		"gitlab.com":         testtarget.ProviderKindGitLab,
+		"gitlab.example.com": testtarget.ProviderKindGitLab,
+		"codeberg.org":       testtarget.ProviderKindGitea,
+		"gitea.example.com":  testtarget.ProviderKindGitea,
+		"forgejo.example.jp": testtarget.ProviderKindGitea,
+		"git.example.com":    testtarget.ProviderKindGitHub,
+	} {
+		if got := testtarget.GuessProvider(host); got.Kind != want || got.Host != host {
+			t.Errorf("GuessProvider(%q) = %+v, want kind %q", host, got, want)
+		}
+	}
+}
+
+func TestProviderCloneURLFor(t *testing.T) {
+	ref := repository.NewReference("git.example.com", "kyoh86", "gogh")
+	for _, tc := range []struct {
+		title    string
+		cloneURL string
+		want     string
+		wantErr  bool
+	}{
+		{title: "default", want: "https://git.example.com/kyoh86/gogh.git"},
+		{title: "scp-like", cloneURL: "git@{{.Host}}:{{.Owner}}/{{.Name}}.git", want: "git@git.example.com:kyoh86/gogh.git"},
+		{title: "ssh", cloneURL: "ssh://git@git.example.com:2222/srv/{{.Owner}}/{{.Name}}", want: "ssh://git@git.example.com:2222/srv/kyoh86/gogh"},
+		{title: "invalid syntax", cloneURL: "https://{{.Host", wantErr: true},
+		{title: "unknown field", cloneURL: "https://{{.Hostname}}/{{.Name}}", wantErr: true},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			p := testtarget.Provider{Host: ref.Host(), Kind: testtarget.ProviderKindGit, CloneURL: tc.cloneURL}
+			got, err := p.CloneURLFor(ref)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProviderService(t *testing.T) {
+	svc := testtarget.NewProviderService()
+	if svc.HasChanges() {
+		t.Error("new service should not have changes")
+	}
+
+	t.Run("resolve unregistered host", func(t *testing.T) {
+		if _, ok := svc.Get("git.example.com"); ok {
+			t.Error("expected no provider")
+		}
+		if got := svc.Resolve("gitlab.example.com"); got.Kind != testtarget.ProviderKindGitLab {
+			t.Errorf("expected guessed gitlab, got %+v", got)
+		}
+	})
+
+	t.Run("set and resolve", func(t *testing.T) {
+		want := testtarget.Provider{
+			Host:    "git.example.com",
+			Kind:    testtarget.ProviderKindGitLab,
+			APIBase: "https://git.example.com/gitlab/api/v4",
+		}
+		if err := svc.Set(want); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !svc.HasChanges() {
+			t.Error("expected changes")
+		}
+		if got, ok := svc.Get("git.example.com"); !ok || got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+		if got := svc.Resolve("git.example.com"); got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("set invalid", func(t *testing.T) {
+		for _, p := range []testtarget.Provider{
+			{Host: "", Kind: testtarget.ProviderKindGitHub},
+			{Host: "git.example.com", Kind: "svn"},
+			{Host: "git.example.com", Kind: testtarget.ProviderKindGitHub, APIBase: "/api/v3"},
+			{Host: "git.example.com", Kind: testtarget.ProviderKindGitHub, APIBase: "ftp://git.example.com"},
+			{Host: "git.example.com", Kind: testtarget.ProviderKindGit, CloneURL: "git@{{.Host:{{.Owner}}/{{.Name}}"},
+			{Host: "git.example.com", Kind: testtarget.ProviderKindGitHub, Protocol: "git"},
+		} {
+			if err := svc.Set(p); err == nil {
+				t.Errorf("expected error for %+v", p)
+			}
+		}
+	})
+
+	t.Run("entries are sorted", func(t *testing.T) {
+		if err := svc.Set(testtarget.Provider{Host: "a.example.com", Kind: testtarget.ProviderKindGitea}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entries := svc.Entries()
+		if len(entries) != 2 || entries[0].Host != "a.example.com" || entries[1].Host != "git.example.com" {
+			t.Errorf("unexpected entries: %+v", entries)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		svc.MarkSaved()
+		if err := svc.Remove("a.example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !svc.HasChanges() {
+			t.Error("expected changes")
+		}
+		if err := svc.Remove("a.example.com"); !errors.Is(err, testtarget.ErrProviderNotFound) {
+			t.Errorf("expected ErrProviderNotFound, got %v", err)
+		}
+		if len(svc.Entries()) != 1 {
+			t.Errorf("unexpected entries: %+v", svc.Entries())
+		}
+	})
+}
@@ -26,12 +26,23 @@ type DefaultNameService interface {
 	// SetDefaultOwnerFor sets the default owner for the specified host
 	SetDefaultOwnerFor(host, owner string) error
 
+	// GetHostAliases returns a map of the host aliases and their corresponding hosts
+	GetHostAliases() map[string]string
+
+	// ResolveHostAlias returns the host for the alias
+	ResolveHostAlias(alias string) (host string, ok bool)
+
+	// SetHostAlias sets the alias for the host (e.g. "gh" for "github.com"),
+	// which can be used in a reference like "gh:owner/name"
+	SetHostAlias(alias, host string) error
+
 	store.Content
 }
 
 // defaultNameServiceImpl implements the DefaultNameService interface
 type defaultNameServiceImpl struct {
 	hosts       typ.Map[string, string]
+	hostAliases typ.Map[string, string]
 	defaultHost string
 	changed     bool
 }
@@ -40,6 +51,7 @@ type defaultNameServiceImpl struct {
 func NewDefaultNameService() DefaultNameService {
 	return &defaultNameServiceImpl{
 		hosts:       typ.Map[string, string]{},
+		hostAliases: typ.Map[string, string]{},
 		defaultHost: gogh.DefaultHost,
 	}
 }
@@ -88,7 +100,7 @@ func (d *defaultNameServiceImpl) SetDefaultOwnerFor(host, owner string) error {
 	if err := ValidateHost(host); err != nil {
 		return err
 	}
-	if err := ValidateOwner(owner); err != nil {
+	if err := validateSingleOwner(owner); err != nil {
 		return err
 	}
 	d.hosts.Set(host, owner)
@@ -96,6 +108,35 @@ func (d *defaultNameServiceImpl) SetDefaultOwnerFor(host, owner string) error {
 	return nil
 }
 
+// GetHostAliases implements DefaultNameService
+func (d defaultNameServiceImpl) GetHostAliases() map[string]string {
+	if d.hostAliases == nil {
+		return nil
+	}
+	return d.hostAliases
+}
+
+// ResolveHostAlias implements DefaultNameService
+func (d defaultNameServiceImpl) ResolveHostAlias(alias string) (string, bool) {
+	return d.hostAliases.TryGet(alias)
+}
+
+// SetHostAlias implements DefaultNameService
+func (d *defaultNameServiceImpl) SetHostAlias(alias, host string) error {
+	if err := ValidateHostAlias(alias); err != nil {
+		return err
+	}
+	if err := ValidateHost(host); err != nil {
+		return err
+	}
+	if d.hostAliases == nil {
+		d.hostAliases = typ.Map[string, string]{}
+	}
+	d.hostAliases.Set(alias, host)
+	d.changed = true
+	return nil
+}
+
 // HasChanges implements DefaultNameService.
 func (d *defaultNameServiceImpl) HasChanges() bool {
 	return d.changed
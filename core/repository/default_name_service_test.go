@@ -292,3 +292,35 @@ func TestHasChangesAndMarkSaved(t *testing.T) {
 		t.Error("Expected HasChanges to return true after another change")
 	}
 }
+
+func TestHostAliases(t *testing.T) {
+	service := testtarget.NewDefaultNameService()
+	if aliases := service.GetHostAliases(); len(aliases) != 0 {
+		t.Errorf("Expected no host aliases, got %v", aliases)
+	}
+
+	if err := service.SetHostAlias("work", "github.example-corp.com"); err != nil {
+		t.Fatalf("Failed to set host alias: %v", err)
+	}
+	if !service.HasChanges() {
+		t.Error("Expected HasChanges to return true after setting a host alias")
+	}
+	if host, ok := service.ResolveHostAlias("work"); !ok || host != "github.example-corp.com" {
+		t.Errorf("Expected the alias to be resolved to github.example-corp.com, got %q (%v)", host, ok)
+	}
+	if _, ok := service.ResolveHostAlias("gh"); ok {
+		t.Error("Expected an unknown alias not to be resolved")
+	}
+	if aliases := service.GetHostAliases(); len(aliases) != 1 || aliases["work"] != "github.example-corp.com" {
+		t.Errorf("Unexpected host aliases: %v", aliases)
+	}
+
+	for _, alias := range []string{"", "github.com", "git@host", "1st", "has space"} {
+		if err := service.SetHostAlias(alias, "github.com"); err == nil {
+			t.Errorf("Expected an error for the invalid alias %q", alias)
+		}
+	}
+	if err := service.SetHostAlias("gh", "invalid host"); err == nil {
+		t.Error("Expected an error for the invalid host")
+	}
+}
@@ -3,6 +3,9 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/kyoh86/gogh/v4/typ"
@@ -10,6 +13,9 @@ import (
 
 var ErrTooManySlashes = errors.New("too many slashes")
 
+// ErrNotRepositoryURL is returned when the URL points to a page in the repository (e.g. "/tree/main")
+var ErrNotRepositoryURL = errors.New("not a URL of the repository")
+
 // ReferenceParser will parse any string as a Reference.
 //
 // If it is clear that the string has host, user and name explicitly,
@@ -22,6 +28,7 @@ type ReferenceParser interface {
 type referenceParserImpl struct {
 	defaultHost  string
 	defaultOwner string
+	defaultNames DefaultNameService
 }
 
 // ParseWithAlias parses string as a Reference and following alias.
@@ -57,16 +64,18 @@ func (p *referenceParserImpl) ParseWithAlias(s string) (*ReferenceWithAlias, err
 
 // parseSiblingReference parses string as a repository ref and following alias
 // in the same host and same owner.
+// The alias may have a multi-segment owner like "<group>/<subgroup>/<name>".
 func parseSiblingReference(base Reference, s string) (*Reference, error) {
 	parts := strings.Split(s, "/")
 	var owner, name string
 	switch len(parts) {
 	case 1:
 		owner, name = base.Owner(), parts[0]
-	case 2:
-		owner, name = parts[0], parts[1]
 	default:
-		return nil, ErrTooManySlashes
+		if slices.Contains(parts, "") && len(parts) > 2 {
+			return nil, ErrTooManySlashes
+		}
+		owner, name = strings.Join(parts[:len(parts)-1], "/"), parts[len(parts)-1]
 	}
 	if err := ValidateOwner(owner); err != nil {
 		return nil, err
@@ -77,12 +86,98 @@ func parseSiblingReference(base Reference, s string) (*Reference, error) {
 	return typ.Ptr(NewReference(base.Host(), owner, name)), nil
 }
 
+// scpLikeRegexp matches an SCP-like address of git: "[<user>@]<host>:<path>"
+var scpLikeRegexp = regexp.MustCompile(`^(?:[^@/:]+@)?([^@/:]+):([^/].*)$`)
+
+// portPrefixRegexp matches a port number following the host: "<port>/..."
+var portPrefixRegexp = regexp.MustCompile(`^\d+(?:/|$)`)
+
+// normalizeAddress converts a URL (e.g. "https://github.com/kyoh86/gogh.git", "ssh://git@github.com/kyoh86/gogh")
+// or an SCP-like address (e.g. "git@github.com:kyoh86/gogh.git") into "<host>/<owner>/<name>".
+// A trailing slash of the URL and a ".git" suffix of any string are removed.
+// The path of the URL is cut at the "/-/" separator of GitLab (e.g. "https://gitlab.com/group/project/-/tree/main"),
+// and the URL of github.com must not have segments after the name (e.g. "https://github.com/kyoh86/gogh/tree/main").
+func normalizeAddress(s string) (string, error) {
+	switch {
+	case strings.Contains(s, "://"):
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid ref: %w", err)
+		}
+		host := u.Host
+		if u.Scheme != "http" && u.Scheme != "https" {
+			// The port of ssh or git protocol is not a part of the host of the web
+			host = u.Hostname()
+		}
+		if host == "" {
+			return "", ErrEmptyHost
+		}
+		path := strings.TrimSuffix(strings.TrimPrefix(u.Path, "/"), "/")
+		if repo, _, ok := strings.Cut(path+"/", "/-/"); ok {
+			path = repo
+		}
+		if host == "github.com" && strings.Count(path, "/") > 1 {
+			return "", fmt.Errorf("%w: %s", ErrNotRepositoryURL, s)
+		}
+		s = host + "/" + path
+	default:
+		if match := scpLikeRegexp.FindStringSubmatch(s); match != nil && !portPrefixRegexp.MatchString(match[2]) {
+			s = match[1] + "/" + match[2]
+		}
+	}
+	if name := s[strings.LastIndex(s, "/")+1:]; name != ".git" {
+		s = strings.TrimSuffix(s, ".git")
+	}
+	return s, nil
+}
+
+// expandHostAlias expands the host alias in a string like "<alias>:[<owner>/]<name>"
+// into "<host>/<owner>/<name>".
+// If the owner is omitted, the default owner for the host is used.
+func (p *referenceParserImpl) expandHostAlias(s string) (string, error) {
+	if p.defaultNames == nil {
+		return s, nil
+	}
+	alias, rest, ok := strings.Cut(s, ":")
+	if !ok || ValidateHostAlias(alias) != nil {
+		return s, nil
+	}
+	host, ok := p.defaultNames.ResolveHostAlias(alias)
+	if !ok {
+		return s, nil
+	}
+	if strings.Contains(rest, "/") {
+		return host + "/" + rest, nil
+	}
+	owner, err := p.defaultNames.GetDefaultOwnerFor(host)
+	if err != nil {
+		return "", err
+	}
+	if owner == "" {
+		return "", fmt.Errorf("%w for %s", ErrEmptyOwner, host)
+	}
+	return host + "/" + owner + "/" + rest, nil
+}
+
 // Parse a string and build a Reference.
 //
+// The string may start with a host alias like "<alias>:[<owner>/]<name>" (see DefaultNameService.SetHostAlias).
+// The string may be a URL or an SCP-like address of the repository
+// (e.g. "https://github.com/kyoh86/gogh.git" or "git@github.com:kyoh86/gogh.git").
 // The string will be separated host/owner/name.
 // If it does not have a host or a user explicitly, they will be
 // replaced with a default-host and default-owner.
+// If it has more than three segments, the segments between the host and the name
+// are joined as a multi-segment owner (e.g. "gitlab.com/group/subgroup/project").
 func (p *referenceParserImpl) Parse(s string) (*Reference, error) {
+	s, err := p.expandHostAlias(s)
+	if err != nil {
+		return nil, err
+	}
+	s, err = normalizeAddress(s)
+	if err != nil {
+		return nil, err
+	}
 	parts := strings.Split(s, "/")
 	var host, owner, name string
 	switch len(parts) {
@@ -94,7 +189,10 @@ func (p *referenceParserImpl) Parse(s string) (*Reference, error) {
 		host, owner, name = parts[0], parts[1], parts[2]
 
 	default:
-		return nil, ErrTooManySlashes
+		if slices.Contains(parts, "") {
+			return nil, ErrTooManySlashes
+		}
+		host, owner, name = parts[0], strings.Join(parts[1:len(parts)-1], "/"), parts[len(parts)-1]
 	}
 	if err := ValidateName(name); err != nil {
 		return nil, err
@@ -113,4 +211,11 @@ func NewReferenceParser(defaultHost, defaultOwner string) ReferenceParser {
 	return &referenceParserImpl{defaultHost: defaultHost, defaultOwner: defaultOwner}
 }
 
+// NewReferenceParserFor will build Reference with the default host and owner in the DefaultNameService,
+// and expand the host aliases in it.
+func NewReferenceParserFor(defaultNames DefaultNameService) ReferenceParser {
+	host, owner := defaultNames.GetDefaultHostAndOwner()
+	return &referenceParserImpl{defaultHost: host, defaultOwner: owner, defaultNames: defaultNames}
+}
+
 var _ ReferenceParser = (*referenceParserImpl)(nil)
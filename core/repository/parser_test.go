@@ -197,6 +197,11 @@ func TestReferenceParser(t *testing.T) {
 					input:  "/" + host1 + "/" + owner1 + "/" + name + "/",
 					expect: testtarget.ErrTooManySlashes,
 				},
+				{
+					title:  "empty-segment-in-nested-owner",
+					input:  host1 + "/" + owner1 + "//" + name,
+					expect: testtarget.ErrTooManySlashes,
+				},
 			} {
 				t.Run(testcase.title, func(t *testing.T) {
 					res, err := parser.ParseWithAlias(testcase.input)
@@ -262,6 +267,18 @@ func TestReferenceParser(t *testing.T) {
 				expectHost: host2,
 				expectUser: owner2,
 				expectName: name,
+			}, {
+				title:      "valid-host,nested-owner,valid-name",
+				source:     host2 + "/" + owner1 + "/" + owner2 + "/" + name,
+				expectHost: host2,
+				expectUser: owner1 + "/" + owner2,
+				expectName: name,
+			}, {
+				title:      "valid-host,deeply-nested-owner,valid-name",
+				source:     host2 + "/group/sub1/sub2/" + name,
+				expectHost: host2,
+				expectUser: "group/sub1/sub2",
+				expectName: name,
 			}} {
 				t.Run(testcase.title, func(t *testing.T) {
 					ref, err := parser.Parse(testcase.source)
@@ -339,6 +356,22 @@ func TestReferenceParser(t *testing.T) {
 				wantRepoName:  name,
 				wantAliasUser: owner2,
 				wantAliasName: "alias",
+			}, {
+				title:         "with-nested-owner-and-alias-name",
+				source:        host1 + "/group/sub/" + name + "=alias",
+				wantHost:      host1,
+				wantRepoUser:  "group/sub",
+				wantRepoName:  name,
+				wantAliasUser: "group/sub",
+				wantAliasName: "alias",
+			}, {
+				title:         "with-nested-alias-owner",
+				source:        host1 + "/" + owner1 + "/" + name + "=group/sub/alias",
+				wantHost:      host1,
+				wantRepoUser:  owner1,
+				wantRepoName:  name,
+				wantAliasUser: "group/sub",
+				wantAliasName: "alias",
 			}} {
 				t.Run(testcase.title, func(t *testing.T) {
 					res, err := parser.ParseWithAlias(testcase.source)
@@ -392,6 +425,9 @@ func TestReferenceParser(t *testing.T) {
 			}, {
 				title:  "invalid owner starts with hyphen",
 				source: name + "xxx=-baz/many",
+			}, {
+				title:  "empty-segment-in-nested-owner",
+				source: name + "xxx=group//many",
 			}} {
 				t.Run(testcase.title, func(t *testing.T) {
 					_, err := parser.ParseWithAlias(testcase.source)
@@ -404,3 +440,139 @@ func TestReferenceParser(t *testing.T) {
 		})
 	})
 }
+
+func TestReferenceParser_Addresses(t *testing.T) {
+	parser := testtarget.NewReferenceParser("github.com", "kyoh86")
+	for _, testcase := range []struct {
+		input  string
+		expect string
+	}{
+		{input: "https://github.com/kyoh86/gogh", expect: "github.com/kyoh86/gogh"},
+		{input: "https://github.com/kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "https://github.com/kyoh86/gogh/", expect: "github.com/kyoh86/gogh"},
+		{input: "http://example.com:8080/kyoh86/gogh", expect: "example.com:8080/kyoh86/gogh"},
+		{input: "https://gitlab.com/group/subgroup/project.git", expect: "gitlab.com/group/subgroup/project"},
+		{input: "https://gitlab.com/group/subgroup/project/-/tree/main", expect: "gitlab.com/group/subgroup/project"},
+		{input: "https://gitlab.com/group/project/-/merge_requests/1", expect: "gitlab.com/group/project"},
+		{input: "https://gitlab.com/group/project/-", expect: "gitlab.com/group/project"},
+		{input: "ssh://git@github.com/kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "ssh://git@example.com:2222/kyoh86/gogh.git", expect: "example.com/kyoh86/gogh"},
+		{input: "git+ssh://git@github.com/kyoh86/gogh", expect: "github.com/kyoh86/gogh"},
+		{input: "git://github.com/kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "git@github.com:kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "git@gitlab.com:group/subgroup/project.git", expect: "gitlab.com/group/subgroup/project"},
+		{input: "github.com:kyoh86/gogh", expect: "github.com/kyoh86/gogh"},
+		{input: "github.com/kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "kyoh86/gogh.git", expect: "github.com/kyoh86/gogh"},
+		{input: "example.com:8080/kyoh86/gogh", expect: "example.com:8080/kyoh86/gogh"},
+	} {
+		t.Run(testcase.input, func(t *testing.T) {
+			ref, err := parser.Parse(testcase.input)
+			if err != nil {
+				t.Fatalf("unexpected error to parse %q: %s", testcase.input, err)
+			}
+			if ref.String() != testcase.expect {
+				t.Errorf("expect %q to be parsed to %q but %q", testcase.input, testcase.expect, ref.String())
+			}
+		})
+	}
+
+	t.Run("WithAlias", func(t *testing.T) {
+		for _, input := range []string{
+			"https://github.com/kyoh86/gogh.git=kyoh86-tryouts/sample",
+			"git@github.com:kyoh86/gogh.git=kyoh86-tryouts/sample",
+		} {
+			ref, err := parser.ParseWithAlias(input)
+			if err != nil {
+				t.Fatalf("unexpected error to parse %q: %s", input, err)
+			}
+			if expect := "github.com/kyoh86/gogh=github.com/kyoh86-tryouts/sample"; ref.String() != expect {
+				t.Errorf("expect %q to be parsed to %q but %q", input, expect, ref.String())
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, input := range []string{
+			"https:///kyoh86/gogh",
+			"https://github.com/kyoh86",
+			"git@github.com:",
+		} {
+			if ref, err := parser.Parse(input); err == nil {
+				t.Errorf("expect failure to parse %q but parsed to %s", input, ref)
+			}
+		}
+	})
+
+	t.Run("PageInRepository", func(t *testing.T) {
+		for _, input := range []string{
+			"https://github.com/kyoh86/gogh/tree/main",
+			"https://github.com/kyoh86/gogh/blob/main/README.md",
+			"https://github.com/kyoh86/gogh/pull/1",
+		} {
+			if ref, err := parser.Parse(input); !errors.Is(err, testtarget.ErrNotRepositoryURL) {
+				t.Errorf("expect ErrNotRepositoryURL to parse %q but got %v (%s)", input, err, ref)
+			}
+		}
+	})
+}
+
+func TestReferenceParser_HostAliases(t *testing.T) {
+	names := testtarget.NewDefaultNameService()
+	for alias, host := range map[string]string{
+		"gh":   "github.com",
+		"work": "github.example-corp.com",
+		"gl":   "gitlab.com",
+	} {
+		if err := names.SetHostAlias(alias, host); err != nil {
+			t.Fatalf("failed to set host alias: %s", err)
+		}
+	}
+	if err := names.SetDefaultOwnerFor("github.com", "kyoh86"); err != nil {
+		t.Fatalf("failed to set default owner: %s", err)
+	}
+	if err := names.SetDefaultOwnerFor("github.example-corp.com", "platform"); err != nil {
+		t.Fatalf("failed to set default owner: %s", err)
+	}
+	parser := testtarget.NewReferenceParserFor(names)
+
+	for _, testcase := range []struct {
+		input  string
+		expect string
+	}{
+		{input: "gogh", expect: "github.com/kyoh86/gogh"},
+		{input: "gh:kyoh86-tryouts/gogh", expect: "github.com/kyoh86-tryouts/gogh"},
+		{input: "gh:gogh", expect: "github.com/kyoh86/gogh"},
+		{input: "work:api", expect: "github.example-corp.com/platform/api"},
+		{input: "work:api.git", expect: "github.example-corp.com/platform/api"},
+		{input: "gl:group/subgroup/project", expect: "gitlab.com/group/subgroup/project"},
+		{input: "git@gitlab.com:group/project.git", expect: "gitlab.com/group/project"},
+		{input: "https://gitlab.com/group/project", expect: "gitlab.com/group/project"},
+	} {
+		t.Run(testcase.input, func(t *testing.T) {
+			ref, err := parser.Parse(testcase.input)
+			if err != nil {
+				t.Fatalf("unexpected error to parse %q: %s", testcase.input, err)
+			}
+			if ref.String() != testcase.expect {
+				t.Errorf("expect %q to be parsed to %q but %q", testcase.input, testcase.expect, ref.String())
+			}
+		})
+	}
+
+	t.Run("WithAlias", func(t *testing.T) {
+		ref, err := parser.ParseWithAlias("work:api=api-v2")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if expect := "github.example-corp.com/platform/api=github.example-corp.com/platform/api-v2"; ref.String() != expect {
+			t.Errorf("expect to be parsed to %q but %q", expect, ref.String())
+		}
+	})
+
+	t.Run("NoDefaultOwner", func(t *testing.T) {
+		if _, err := parser.Parse("gl:project"); !errors.Is(err, testtarget.ErrEmptyOwner) {
+			t.Errorf("expect ErrEmptyOwner, but %v", err)
+		}
+	})
+}
@@ -15,7 +15,8 @@ type Reference struct {
 // Host is a hostname (e.g.: "github.com")
 func (r Reference) Host() string { return r.host }
 
-// Owner is a owner name (e.g.: "kyoh86")
+// Owner is a owner name (e.g.: "kyoh86").
+// It may have multiple segments for nested namespaces (e.g.: "group/subgroup").
 func (r Reference) Owner() string { return r.owner }
 
 // Name of the repository (e.g.: "gogh")
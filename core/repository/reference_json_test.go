@@ -40,6 +40,11 @@ func TestReferenceJSON(t *testing.T) {
 			}{Reference: &ref},
 			want: `{"Reference":{"host":"github.com","owner":"kyoh86","name":"gogh"}}`,
 		},
+		{
+			title: "nested owner",
+			input: testtarget.NewReference("gitlab.com", "group/subgroup", "project"),
+			want:  `{"host":"gitlab.com","owner":"group/subgroup","name":"project"}`,
+		},
 	} {
 		t.Run(testcase.title, func(t *testing.T) {
 			buf, err := json.Marshal(testcase.input)
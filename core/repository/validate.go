@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -29,7 +30,31 @@ func ValidateHost(host string) error {
 
 var validOwnerRegexp = regexp.MustCompile(`^[a-zA-Z0-9]+(?:-[a-zA-Z0-9]+)*$`)
 
+// MaxOwnerSegments is the maximum number of the segments of an owner.
+// GitLab allows 20 levels of subgroups under a top-level group.
+const MaxOwnerSegments = 21
+
+// ValidateOwner validates an owner string.
+// The owner may have multiple segments separated by "/" for nested namespaces
+// (e.g. GitLab subgroups: "group/subgroup"), and each of them must be a valid owner.
 func ValidateOwner(owner string) error {
+	if owner == "" {
+		return ErrEmptyOwner
+	}
+	if strings.Count(owner, "/") >= MaxOwnerSegments {
+		return errors.New("too deeply nested owner: " + owner)
+	}
+	for segment := range strings.SplitSeq(owner, "/") {
+		if !validOwnerRegexp.MatchString(segment) {
+			return errors.New("invalid owner: " + owner)
+		}
+	}
+	return nil
+}
+
+// validateSingleOwner validates an owner string which must not have multiple segments
+// (e.g. the default owner which may be used as a user name).
+func validateSingleOwner(owner string) error {
 	if owner == "" {
 		return ErrEmptyOwner
 	}
@@ -39,6 +64,18 @@ func ValidateOwner(owner string) error {
 	return nil
 }
 
+var validHostAliasRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// ValidateHostAlias validates an alias of a host.
+// It must start with a letter and consist of letters, digits, hyphens and underscores,
+// not to be confused with a host (having dots) or an SCP-like address (having "@").
+func ValidateHostAlias(alias string) error {
+	if !validHostAliasRegexp.MatchString(alias) {
+		return errors.New("invalid host alias: " + alias)
+	}
+	return nil
+}
+
 var invalidNameRegexp = regexp.MustCompile(`[^\w\-\.]`)
 
 func ValidateName(name string) error {
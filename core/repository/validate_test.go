@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	testtarget "github.com/kyoh86/gogh/v4/core/repository"
@@ -147,6 +148,36 @@ func TestValidateOwner(t *testing.T) {
 			owner:       "user.name",
 			expectError: true,
 		},
+		{
+			name:        "valid nested",
+			owner:       "group/sub-group",
+			expectError: false,
+		},
+		{
+			name:        "invalid nested with empty segment",
+			owner:       "group//sub",
+			expectError: true,
+		},
+		{
+			name:        "invalid nested with trailing slash",
+			owner:       "group/",
+			expectError: true,
+		},
+		{
+			name:        "invalid nested with invalid segment",
+			owner:       "group/-sub",
+			expectError: true,
+		},
+		{
+			name:        "valid nested at the max depth",
+			owner:       strings.Repeat("group/", testtarget.MaxOwnerSegments-1) + "group",
+			expectError: false,
+		},
+		{
+			name:        "invalid nested deeper than the max depth",
+			owner:       strings.Repeat("group/", testtarget.MaxOwnerSegments) + "group",
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -83,6 +83,20 @@ func (mr *MockDefaultNameServiceMockRecorder) GetDefaultOwnerFor(host any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultOwnerFor", reflect.TypeOf((*MockDefaultNameService)(nil).GetDefaultOwnerFor), host)
 }
 
+// GetHostAliases mocks base method.
+func (m *MockDefaultNameService) GetHostAliases() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostAliases")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetHostAliases indicates an expected call of GetHostAliases.
+func (mr *MockDefaultNameServiceMockRecorder) GetHostAliases() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostAliases", reflect.TypeOf((*MockDefaultNameService)(nil).GetHostAliases))
+}
+
 // GetMap mocks base method.
 func (m *MockDefaultNameService) GetMap() map[string]string {
 	m.ctrl.T.Helper()
@@ -123,6 +137,21 @@ func (mr *MockDefaultNameServiceMockRecorder) MarkSaved() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSaved", reflect.TypeOf((*MockDefaultNameService)(nil).MarkSaved))
 }
 
+// ResolveHostAlias mocks base method.
+func (m *MockDefaultNameService) ResolveHostAlias(alias string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveHostAlias", alias)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ResolveHostAlias indicates an expected call of ResolveHostAlias.
+func (mr *MockDefaultNameServiceMockRecorder) ResolveHostAlias(alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveHostAlias", reflect.TypeOf((*MockDefaultNameService)(nil).ResolveHostAlias), alias)
+}
+
 // SetDefaultHost mocks base method.
 func (m *MockDefaultNameService) SetDefaultHost(host string) error {
 	m.ctrl.T.Helper()
@@ -150,3 +179,17 @@ func (mr *MockDefaultNameServiceMockRecorder) SetDefaultOwnerFor(host, owner any
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultOwnerFor", reflect.TypeOf((*MockDefaultNameService)(nil).SetDefaultOwnerFor), host, owner)
 }
+
+// SetHostAlias mocks base method.
+func (m *MockDefaultNameService) SetHostAlias(alias, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHostAlias", alias, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHostAlias indicates an expected call of SetHostAlias.
+func (mr *MockDefaultNameServiceMockRecorder) SetHostAlias(alias, host any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHostAlias", reflect.TypeOf((*MockDefaultNameService)(nil).SetHostAlias), alias, host)
+}
@@ -15,6 +15,9 @@ type ListOptions struct {
 	// Patterns to match repository paths
 	// If empty, all repositories will be returned
 	Patterns []string
+	// Tags to match repositories (see TagService); a repository having any of them matches.
+	// If empty, all repositories will be returned
+	Tags []string
 }
 
 // FinderService is a service for searching repositories
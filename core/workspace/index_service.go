@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+)
+
+// IndexService manages an index of the local repositories
+// to find them without walking all directories under the roots.
+// The index is disabled until it is built by Reset, and the entries may be stale:
+// users of the index should validate them against the filesystem.
+type IndexService interface {
+	store.Content
+
+	// Built returns whether the index is built
+	Built() bool
+
+	// Reset replaces all entries with the locations and builds the index
+	Reset(locations []*repository.Location)
+
+	// Drop removes all entries and disables the index
+	Drop()
+
+	// Entries returns all indexed locations in the order of the full path
+	Entries() []*repository.Location
+
+	// Get returns the indexed location at the full path
+	Get(fullPath string) (*repository.Location, bool)
+
+	// Add adds the location into the index.
+	// It does nothing if the index is not built.
+	Add(location *repository.Location)
+
+	// Remove removes the location at the full path from the index.
+	// It does nothing if the index is not built.
+	Remove(fullPath string)
+}
+
+type indexServiceImpl struct {
+	mu        sync.RWMutex
+	locations map[string]*repository.Location // key: full path
+	built     bool
+	changed   bool
+}
+
+// NewIndexService creates a new IndexService which is not built yet
+func NewIndexService() IndexService {
+	return &indexServiceImpl{
+		locations: map[string]*repository.Location{},
+	}
+}
+
+// HasChanges implements IndexService.
+func (s *indexServiceImpl) HasChanges() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.changed
+}
+
+// MarkSaved implements IndexService.
+func (s *indexServiceImpl) MarkSaved() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed = false
+}
+
+// Built implements IndexService.
+func (s *indexServiceImpl) Built() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.built
+}
+
+// Reset implements IndexService.
+func (s *indexServiceImpl) Reset(locations []*repository.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locations = make(map[string]*repository.Location, len(locations))
+	for _, location := range locations {
+		s.locations[location.FullPath()] = location
+	}
+	s.built = true
+	s.changed = true
+}
+
+// Drop implements IndexService.
+func (s *indexServiceImpl) Drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.locations = map[string]*repository.Location{}
+	s.built = false
+	s.changed = true
+}
+
+// Entries implements IndexService.
+func (s *indexServiceImpl) Entries() []*repository.Location {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]*repository.Location, 0, len(s.locations))
+	for _, location := range s.locations {
+		entries = append(entries, location)
+	}
+	slices.SortFunc(entries, func(a, b *repository.Location) int {
+		return strings.Compare(a.FullPath(), b.FullPath())
+	})
+	return entries
+}
+
+// Get implements IndexService.
+func (s *indexServiceImpl) Get(fullPath string) (*repository.Location, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	location, ok := s.locations[fullPath]
+	return location, ok
+}
+
+// Add implements IndexService.
+func (s *indexServiceImpl) Add(location *repository.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.built {
+		return
+	}
+	if current, ok := s.locations[location.FullPath()]; ok && *current == *location {
+		return
+	}
+	s.locations[location.FullPath()] = location
+	s.changed = true
+}
+
+// Remove implements IndexService.
+func (s *indexServiceImpl) Remove(fullPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.built {
+		return
+	}
+	if _, ok := s.locations[fullPath]; !ok {
+		return
+	}
+	delete(s.locations, fullPath)
+	s.changed = true
+}
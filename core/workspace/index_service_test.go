@@ -0,0 +1,61 @@
+package workspace_test
+
+import (
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestIndexService(t *testing.T) {
+	gogh := repository.NewLocation("/root/github.com/kyoh86/gogh", "github.com", "kyoh86", "gogh")
+	dotfiles := repository.NewLocation("/root/github.com/kyoh86/dotfiles", "github.com", "kyoh86", "dotfiles")
+
+	service := workspace.NewIndexService()
+	if service.Built() {
+		t.Error("Expected a new index not to be built")
+	}
+
+	// Updates are ignored until the index is built
+	service.Add(gogh)
+	if len(service.Entries()) != 0 || service.HasChanges() {
+		t.Error("Expected Add to be ignored before the index is built")
+	}
+
+	service.Reset([]*repository.Location{gogh})
+	if !service.Built() || !service.HasChanges() {
+		t.Error("Expected the index to be built and changed after Reset")
+	}
+	service.MarkSaved()
+
+	service.Add(gogh)
+	if service.HasChanges() {
+		t.Error("Expected no changes after adding the same location")
+	}
+	service.Add(dotfiles)
+	if !service.HasChanges() {
+		t.Error("Expected changes after adding a location")
+	}
+	entries := service.Entries()
+	if len(entries) != 2 || entries[0] != dotfiles || entries[1] != gogh {
+		t.Errorf("Expected entries in the order of the full path, got %v", entries)
+	}
+	if got, ok := service.Get(gogh.FullPath()); !ok || got != gogh {
+		t.Errorf("Expected Get to return %v, got %v", gogh, got)
+	}
+
+	service.MarkSaved()
+	service.Remove("/root/github.com/kyoh86/unknown")
+	if service.HasChanges() {
+		t.Error("Expected no changes after removing an unknown location")
+	}
+	service.Remove(gogh.FullPath())
+	if _, ok := service.Get(gogh.FullPath()); ok || !service.HasChanges() {
+		t.Error("Expected the location to be removed")
+	}
+
+	service.Drop()
+	if service.Built() || len(service.Entries()) != 0 {
+		t.Error("Expected the index to be dropped")
+	}
+}
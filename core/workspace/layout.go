@@ -0,0 +1,112 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+)
+
+// Placeholders in the layout template
+const (
+	PlaceholderHost  = "{host}"
+	PlaceholderOwner = "{owner}"
+	PlaceholderName  = "{name}"
+)
+
+// DefaultLayoutTemplate is the layout template used when a root does not declare it
+const DefaultLayoutTemplate = PlaceholderHost + "/" + PlaceholderOwner + "/" + PlaceholderName
+
+var (
+	// ErrInvalidLayout is an error when the layout is invalid
+	ErrInvalidLayout = errors.New("invalid layout")
+)
+
+// Layout is a directory structure of the repositories under a root.
+type Layout struct {
+	// Template is a path template of the repositories from the root (default: DefaultLayoutTemplate).
+	// Each segment separated by "/" must be one of "{host}", "{owner}" and "{name}".
+	// For example, "{owner}/{name}" or "{name}".
+	Template string
+	// Host is the host of the repositories in the root.
+	// It is required if the template does not have "{host}".
+	Host string
+	// Owner is the owner of the repositories in the root.
+	// It is required if the template does not have "{owner}".
+	Owner string
+}
+
+// IsDefault returns whether the layout is the default one
+func (l Layout) IsDefault() bool {
+	return (l.Template == "" || l.Template == DefaultLayoutTemplate) && l.Host == "" && l.Owner == ""
+}
+
+// Segments returns the placeholders in the template
+func (l Layout) Segments() []string {
+	template := l.Template
+	if template == "" {
+		template = DefaultLayoutTemplate
+	}
+	return strings.Split(template, "/")
+}
+
+// Has returns whether the template has the placeholder
+func (l Layout) Has(placeholder string) bool {
+	return slices.Contains(l.Segments(), placeholder)
+}
+
+// CanHold returns whether the layout can place the repository:
+// the host and the owner fixed by the layout must be the same as the ones of the reference.
+func (l Layout) CanHold(ref repository.Reference) bool {
+	if !l.Has(PlaceholderHost) && l.Host != ref.Host() {
+		return false
+	}
+	if !l.Has(PlaceholderOwner) && l.Owner != ref.Owner() {
+		return false
+	}
+	return true
+}
+
+// Validate checks that the layout is valid
+func (l Layout) Validate() error {
+	segments := l.Segments()
+	for i, segment := range segments {
+		switch segment {
+		case PlaceholderHost, PlaceholderOwner, PlaceholderName:
+		default:
+			return fmt.Errorf("%w: segment %q must be one of %s, %s and %s", ErrInvalidLayout, segment, PlaceholderHost, PlaceholderOwner, PlaceholderName)
+		}
+		if slices.Contains(segments[:i], segment) {
+			return fmt.Errorf("%w: %s is duplicated", ErrInvalidLayout, segment)
+		}
+	}
+	if !slices.Contains(segments, PlaceholderName) {
+		return fmt.Errorf("%w: %s is required", ErrInvalidLayout, PlaceholderName)
+	}
+	if !slices.Contains(segments, PlaceholderHost) && l.Host == "" {
+		return fmt.Errorf("%w: host is required for the template without %s", ErrInvalidLayout, PlaceholderHost)
+	}
+	if !slices.Contains(segments, PlaceholderOwner) && l.Owner == "" {
+		return fmt.Errorf("%w: owner is required for the template without %s", ErrInvalidLayout, PlaceholderOwner)
+	}
+	return nil
+}
+
+// String returns the template of the layout with the fixed host and owner
+func (l Layout) String() string {
+	segments := l.Segments()
+	s := strings.Join(segments, "/")
+	var fixed []string
+	if !slices.Contains(segments, PlaceholderHost) {
+		fixed = append(fixed, "host="+l.Host)
+	}
+	if !slices.Contains(segments, PlaceholderOwner) {
+		fixed = append(fixed, "owner="+l.Owner)
+	}
+	if len(fixed) > 0 {
+		s += " (" + strings.Join(fixed, ", ") + ")"
+	}
+	return s
+}
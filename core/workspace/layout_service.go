@@ -27,6 +27,10 @@ type LayoutService interface {
 	// ExactMatch("github.com/owner/repo/foo") returns `repository.ErrNotMatched`
 	ExactMatch(path string) (*repository.Reference, error)
 
+	// CanHold returns whether the layout can place the repository of the given reference
+	// (e.g. false for another host than the one fixed by the layout)
+	CanHold(ref repository.Reference) bool
+
 	// PathFor returns the path corresponding to the given reference
 	PathFor(ref repository.Reference) string
 
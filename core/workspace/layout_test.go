@@ -0,0 +1,78 @@
+package workspace_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestLayout_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		layout  workspace.Layout
+		wantErr bool
+	}{
+		{name: "zero value", layout: workspace.Layout{}},
+		{name: "default", layout: workspace.Layout{Template: workspace.DefaultLayoutTemplate}},
+		{name: "owner and name", layout: workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}},
+		{name: "name only", layout: workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}},
+		{name: "reordered", layout: workspace.Layout{Template: "{owner}/{host}/{name}"}},
+		{name: "missing host", layout: workspace.Layout{Template: "{owner}/{name}"}, wantErr: true},
+		{name: "missing owner", layout: workspace.Layout{Template: "{host}/{name}"}, wantErr: true},
+		{name: "missing name", layout: workspace.Layout{Template: "{host}/{owner}"}, wantErr: true},
+		{name: "duplicated", layout: workspace.Layout{Template: "{host}/{owner}/{name}/{name}"}, wantErr: true},
+		{name: "unknown placeholder", layout: workspace.Layout{Template: "{host}/{owner}/{repo}"}, wantErr: true},
+		{name: "literal segment", layout: workspace.Layout{Template: "src/{host}/{owner}/{name}"}, wantErr: true},
+		{name: "empty segment", layout: workspace.Layout{Template: "{host}//{owner}/{name}"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.layout.Validate()
+			if tc.wantErr {
+				if !errors.Is(err, workspace.ErrInvalidLayout) {
+					t.Errorf("Expected ErrInvalidLayout, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLayout_String(t *testing.T) {
+	for _, tc := range []struct {
+		layout workspace.Layout
+		want   string
+	}{
+		{layout: workspace.Layout{}, want: "{host}/{owner}/{name}"},
+		{layout: workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}, want: "{owner}/{name} (host=github.com)"},
+		{layout: workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}, want: "{name} (host=github.com, owner=kyoh86)"},
+	} {
+		if got := tc.layout.String(); got != tc.want {
+			t.Errorf("Expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestLayout_CanHold(t *testing.T) {
+	ref := repository.NewReference("github.com", "kyoh86", "gogh")
+	for _, tc := range []struct {
+		layout workspace.Layout
+		want   bool
+	}{
+		{layout: workspace.Layout{}, want: true},
+		{layout: workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}, want: true},
+		{layout: workspace.Layout{Template: "{owner}/{name}", Host: "gitlab.com"}, want: false},
+		{layout: workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}, want: true},
+		{layout: workspace.Layout{Template: "{name}", Host: "github.com", Owner: "other"}, want: false},
+		{layout: workspace.Layout{Template: "{host}/{name}", Owner: "other"}, want: false},
+	} {
+		if got := tc.layout.CanHold(ref); got != tc.want {
+			t.Errorf("Expected %v for %s, got %v", tc.want, tc.layout, got)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package workspace
+
+import "errors"
+
+var (
+	// ErrRouteNotFound is an error when the route is not found
+	ErrRouteNotFound = errors.New("route not found")
+
+	// ErrRouteAlreadyExists is an error when the route for the pattern already exists
+	ErrRouteAlreadyExists = errors.New("route already exists")
+)
+
+// Route is a rule to choose the root where new repositories are placed.
+type Route struct {
+	// Pattern is a doublestar pattern matched against the reference "<host>/<owner>/<name>"
+	// (e.g. "github.com/our-org/**").
+	Pattern string
+	// Root is the root for the repositories matching the pattern
+	Root Root
+}
@@ -0,0 +1,217 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+)
+
+// ErrInvalidTag is returned when the tag is empty or contains invalid characters
+var ErrInvalidTag = errors.New("invalid tag")
+
+// ValidateTag checks that the tag is not empty and has no spaces or commas
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("%w: empty", ErrInvalidTag)
+	}
+	if strings.ContainsFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return fmt.Errorf("%w: %q has spaces or commas", ErrInvalidTag, tag)
+	}
+	return nil
+}
+
+// TagService manages user-defined tags of the repositories
+// to group them across the owners (e.g. by the product).
+// The tags are bound to the reference of the repository, not to the local path.
+type TagService interface {
+	store.Content
+
+	// Add adds the tags to the repository
+	Add(ref repository.Reference, tags ...string) error
+
+	// Remove removes the tags from the repository
+	Remove(ref repository.Reference, tags ...string)
+
+	// Clear removes all tags from the repository
+	Clear(ref repository.Reference)
+
+	// Move moves the tags of the repository to another reference, merging them into its tags
+	Move(from, to repository.Reference)
+
+	// Tags returns the tags of the repository in the lexical order
+	Tags(ref repository.Reference) []string
+
+	// HasAny returns whether the repository has any of the tags
+	HasAny(ref repository.Reference, tags ...string) bool
+
+	// Entries yields the tagged repositories and their tags in the order of the references
+	Entries() iter.Seq2[repository.Reference, []string]
+}
+
+type tagEntry struct {
+	ref  repository.Reference
+	tags map[string]struct{}
+}
+
+type tagServiceImpl struct {
+	mu      sync.RWMutex
+	entries map[string]*tagEntry // key: reference string
+	changed bool
+}
+
+// NewTagService creates a new TagService without tags
+func NewTagService() TagService {
+	return &tagServiceImpl{
+		entries: map[string]*tagEntry{},
+	}
+}
+
+// HasChanges implements TagService.
+func (s *tagServiceImpl) HasChanges() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.changed
+}
+
+// MarkSaved implements TagService.
+func (s *tagServiceImpl) MarkSaved() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed = false
+}
+
+// Add implements TagService.
+func (s *tagServiceImpl) Add(ref repository.Reference, tags ...string) error {
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		entry = &tagEntry{ref: ref, tags: map[string]struct{}{}}
+	}
+	for _, tag := range tags {
+		if _, ok := entry.tags[tag]; ok {
+			continue
+		}
+		entry.tags[tag] = struct{}{}
+		s.changed = true
+	}
+	if len(entry.tags) > 0 {
+		s.entries[ref.String()] = entry
+	}
+	return nil
+}
+
+// Remove implements TagService.
+func (s *tagServiceImpl) Remove(ref repository.Reference, tags ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		return
+	}
+	for _, tag := range tags {
+		if _, ok := entry.tags[tag]; !ok {
+			continue
+		}
+		delete(entry.tags, tag)
+		s.changed = true
+	}
+	if len(entry.tags) == 0 {
+		delete(s.entries, ref.String())
+	}
+}
+
+// Clear implements TagService.
+func (s *tagServiceImpl) Clear(ref repository.Reference) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[ref.String()]; !ok {
+		return
+	}
+	delete(s.entries, ref.String())
+	s.changed = true
+}
+
+// Move implements TagService.
+func (s *tagServiceImpl) Move(from, to repository.Reference) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[from.String()]
+	if !ok || from == to {
+		return
+	}
+	delete(s.entries, from.String())
+	if dest, ok := s.entries[to.String()]; ok {
+		for tag := range entry.tags {
+			dest.tags[tag] = struct{}{}
+		}
+	} else {
+		entry.ref = to
+		s.entries[to.String()] = entry
+	}
+	s.changed = true
+}
+
+// Tags implements TagService.
+func (s *tagServiceImpl) Tags(ref repository.Reference) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		return nil
+	}
+	return slices.Sorted(maps.Keys(entry.tags))
+}
+
+// HasAny implements TagService.
+func (s *tagServiceImpl) HasAny(ref repository.Reference, tags ...string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		return false
+	}
+	for _, tag := range tags {
+		if _, ok := entry.tags[tag]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries implements TagService.
+func (s *tagServiceImpl) Entries() iter.Seq2[repository.Reference, []string] {
+	return func(yield func(repository.Reference, []string) bool) {
+		s.mu.RLock()
+		keys := slices.Sorted(maps.Keys(s.entries))
+		s.mu.RUnlock()
+		for _, key := range keys {
+			s.mu.RLock()
+			entry, ok := s.entries[key]
+			var tags []string
+			if ok {
+				tags = slices.Sorted(maps.Keys(entry.tags))
+			}
+			s.mu.RUnlock()
+			if !ok {
+				continue
+			}
+			if !yield(entry.ref, tags) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package workspace_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestValidateTag(t *testing.T) {
+	for _, tag := range []string{"billing", "infra-2", "日本語"} {
+		if err := workspace.ValidateTag(tag); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", tag, err)
+		}
+	}
+	for _, tag := range []string{"", "has space", "a,b", "tab\t"} {
+		if err := workspace.ValidateTag(tag); !errors.Is(err, workspace.ErrInvalidTag) {
+			t.Errorf("Expected %q to be invalid, got %v", tag, err)
+		}
+	}
+}
+
+func TestTagService(t *testing.T) {
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	dotfiles := repository.NewReference("github.com", "kyoh86", "dotfiles")
+
+	service := workspace.NewTagService()
+	if err := service.Add(gogh, "tools", "go"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.Add(dotfiles, "config"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !service.HasChanges() {
+		t.Error("Expected changes after adding tags")
+	}
+	service.MarkSaved()
+
+	if err := service.Add(gogh, "go"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if service.HasChanges() {
+		t.Error("Expected no changes after adding the same tag")
+	}
+	if err := service.Add(gogh, "invalid tag"); !errors.Is(err, workspace.ErrInvalidTag) {
+		t.Errorf("Expected ErrInvalidTag, got %v", err)
+	}
+
+	if got := service.Tags(gogh); !slices.Equal(got, []string{"go", "tools"}) {
+		t.Errorf("Expected tags in order, got %v", got)
+	}
+	if !service.HasAny(gogh, "unknown", "go") || service.HasAny(dotfiles, "go") {
+		t.Error("Unexpected result of HasAny")
+	}
+
+	var refs []string
+	for ref, tags := range service.Entries() {
+		refs = append(refs, ref.String()+":"+tags[0])
+	}
+	if want := []string{"github.com/kyoh86/dotfiles:config", "github.com/kyoh86/gogh:go"}; !slices.Equal(refs, want) {
+		t.Errorf("Expected entries %v, got %v", want, refs)
+	}
+
+	service.Remove(gogh, "go", "unknown")
+	if got := service.Tags(gogh); !slices.Equal(got, []string{"tools"}) {
+		t.Errorf("Expected the tag to be removed, got %v", got)
+	}
+	service.Remove(gogh, "tools")
+	service.Clear(dotfiles)
+	if !service.HasChanges() {
+		t.Error("Expected changes after removing tags")
+	}
+	for ref := range service.Entries() {
+		t.Errorf("Expected no entries, got %s", ref)
+	}
+}
+
+func TestTagService_Move(t *testing.T) {
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	fork := repository.NewReference("github.com", "our-org", "gogh")
+	other := repository.NewReference("github.com", "our-org", "other")
+
+	service := workspace.NewTagService()
+	if err := service.Add(gogh, "tools", "go"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.Add(other, "go", "infra"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	service.MarkSaved()
+
+	service.Move(fork, gogh)
+	if service.HasChanges() {
+		t.Error("Expected no changes after moving a repository without tags")
+	}
+
+	service.Move(gogh, fork)
+	if !service.HasChanges() {
+		t.Error("Expected changes after moving tags")
+	}
+	if got := service.Tags(gogh); len(got) != 0 {
+		t.Errorf("Expected no tags for the old reference, got %v", got)
+	}
+	if got := service.Tags(fork); !slices.Equal(got, []string{"go", "tools"}) {
+		t.Errorf("Expected the tags to be moved, got %v", got)
+	}
+	for ref := range service.Entries() {
+		if ref == gogh {
+			t.Errorf("Expected no entry for the old reference")
+		}
+	}
+
+	// The tags are merged into the tags of the destination
+	service.Move(fork, other)
+	if got := service.Tags(other); !slices.Equal(got, []string{"go", "infra", "tools"}) {
+		t.Errorf("Expected the tags to be merged, got %v", got)
+	}
+	if got := service.Tags(fork); len(got) != 0 {
+		t.Errorf("Expected no tags for the moved reference, got %v", got)
+	}
+}
@@ -0,0 +1,168 @@
+package workspace
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/store"
+)
+
+// MaxUsageCount is the limit of the total count of the visits.
+// When the total exceeds it, all counts are aged and rarely visited repositories are forgotten.
+const MaxUsageCount = 1000
+
+// Usage is the record of the visits to a repository
+type Usage struct {
+	Count     float64
+	LastVisit time.Time
+}
+
+// Frecency returns the score of the usage at the time,
+// weighting the count of the visits by the recency of the last visit.
+func (u Usage) Frecency(now time.Time) float64 {
+	elapsed := now.Sub(u.LastVisit)
+	switch {
+	case elapsed < time.Hour:
+		return u.Count * 4
+	case elapsed < 24*time.Hour:
+		return u.Count * 2
+	case elapsed < 7*24*time.Hour:
+		return u.Count / 2
+	default:
+		return u.Count / 4
+	}
+}
+
+// UsageService records the visits to the repositories
+// to rank them by frecency (frequency and recency of the visits).
+// The records are bound to the reference of the repository, not to the local path.
+type UsageService interface {
+	store.Content
+
+	// Visit records a visit to the repository at the time
+	Visit(ref repository.Reference, at time.Time)
+
+	// Set replaces the record of the repository
+	Set(ref repository.Reference, usage Usage)
+
+	// Get returns the record of the repository
+	Get(ref repository.Reference) (Usage, bool)
+
+	// Entries yields the visited repositories and their records in the order of the references
+	Entries() iter.Seq2[repository.Reference, Usage]
+}
+
+type usageEntry struct {
+	ref   repository.Reference
+	usage Usage
+}
+
+type usageServiceImpl struct {
+	mu      sync.RWMutex
+	entries map[string]*usageEntry // key: reference string
+	changed bool
+}
+
+// NewUsageService creates a new UsageService without records
+func NewUsageService() UsageService {
+	return &usageServiceImpl{
+		entries: map[string]*usageEntry{},
+	}
+}
+
+// HasChanges implements UsageService.
+func (s *usageServiceImpl) HasChanges() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.changed
+}
+
+// MarkSaved implements UsageService.
+func (s *usageServiceImpl) MarkSaved() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed = false
+}
+
+// Visit implements UsageService.
+func (s *usageServiceImpl) Visit(ref repository.Reference, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		entry = &usageEntry{ref: ref}
+		s.entries[ref.String()] = entry
+	}
+	entry.usage.Count++
+	if at.After(entry.usage.LastVisit) {
+		entry.usage.LastVisit = at
+	}
+	s.changed = true
+	s.age()
+}
+
+// age scales down all counts when the total exceeds MaxUsageCount,
+// and forgets the repositories whose count falls below one.
+func (s *usageServiceImpl) age() {
+	var total float64
+	for _, entry := range s.entries {
+		total += entry.usage.Count
+	}
+	if total <= MaxUsageCount {
+		return
+	}
+	factor := 0.9 * MaxUsageCount / total
+	for key, entry := range s.entries {
+		entry.usage.Count *= factor
+		if entry.usage.Count < 1 {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// Set implements UsageService.
+func (s *usageServiceImpl) Set(ref repository.Reference, usage Usage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[ref.String()] = &usageEntry{ref: ref, usage: usage}
+	s.changed = true
+}
+
+// Get implements UsageService.
+func (s *usageServiceImpl) Get(ref repository.Reference) (Usage, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[ref.String()]
+	if !ok {
+		return Usage{}, false
+	}
+	return entry.usage, true
+}
+
+// Entries implements UsageService.
+func (s *usageServiceImpl) Entries() iter.Seq2[repository.Reference, Usage] {
+	return func(yield func(repository.Reference, Usage) bool) {
+		s.mu.RLock()
+		keys := slices.Sorted(maps.Keys(s.entries))
+		s.mu.RUnlock()
+		for _, key := range keys {
+			s.mu.RLock()
+			entry, ok := s.entries[key]
+			var usage Usage
+			if ok {
+				usage = entry.usage
+			}
+			s.mu.RUnlock()
+			if !ok {
+				continue
+			}
+			if !yield(entry.ref, usage) {
+				return
+			}
+		}
+	}
+}
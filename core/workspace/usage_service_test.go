@@ -0,0 +1,72 @@
+package workspace_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+)
+
+func TestUsageFrecency(t *testing.T) {
+	now := time.Now()
+	for _, testcase := range []struct {
+		elapsed time.Duration
+		want    float64
+	}{
+		{elapsed: time.Minute, want: 16},
+		{elapsed: 2 * time.Hour, want: 8},
+		{elapsed: 3 * 24 * time.Hour, want: 2},
+		{elapsed: 30 * 24 * time.Hour, want: 1},
+	} {
+		usage := workspace.Usage{Count: 4, LastVisit: now.Add(-testcase.elapsed)}
+		if got := usage.Frecency(now); got != testcase.want {
+			t.Errorf("Expected frecency %v after %s, got %v", testcase.want, testcase.elapsed, got)
+		}
+	}
+}
+
+func TestUsageService(t *testing.T) {
+	gogh := repository.NewReference("github.com", "kyoh86", "gogh")
+	dotfiles := repository.NewReference("github.com", "kyoh86", "dotfiles")
+	now := time.Now()
+
+	service := workspace.NewUsageService()
+	service.Visit(gogh, now.Add(-time.Hour))
+	service.Visit(gogh, now.Add(-2*time.Hour))
+	if !service.HasChanges() {
+		t.Error("Expected changes after visiting")
+	}
+	service.MarkSaved()
+
+	usage, ok := service.Get(gogh)
+	if !ok {
+		t.Fatal("Expected the usage to be recorded")
+	}
+	if usage.Count != 2 || !usage.LastVisit.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Unexpected usage: %+v", usage)
+	}
+	if _, ok := service.Get(dotfiles); ok {
+		t.Error("Expected no usage for a repository never visited")
+	}
+
+	t.Run("Aging", func(t *testing.T) {
+		service.Set(gogh, workspace.Usage{Count: 1, LastVisit: now})
+		service.Set(dotfiles, workspace.Usage{Count: workspace.MaxUsageCount, LastVisit: now})
+		service.Visit(dotfiles, now)
+		usage, ok := service.Get(dotfiles)
+		if !ok || usage.Count >= workspace.MaxUsageCount {
+			t.Errorf("Expected the count to be aged, got %+v", usage)
+		}
+		if _, ok := service.Get(gogh); ok {
+			t.Error("Expected the rarely visited repository to be forgotten")
+		}
+		var refs []string
+		for ref := range service.Entries() {
+			refs = append(refs, ref.String())
+		}
+		if len(refs) != 1 || refs[0] != dotfiles.String() {
+			t.Errorf("Unexpected entries: %v", refs)
+		}
+	})
+}
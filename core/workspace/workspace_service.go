@@ -3,6 +3,7 @@ package workspace
 import (
 	"errors"
 
+	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/store"
 )
 
@@ -42,5 +43,26 @@ type WorkspaceService interface {
 	// RemoveRoot removes a root
 	RemoveRoot(root Root) error
 
+	// GetRootLayout returns the layout declared for the root (the zero value means the default layout)
+	GetRootLayout(root Root) Layout
+
+	// SetRootLayout declares the layout for the root
+	SetRootLayout(root Root, layout Layout) error
+
+	// GetRoutes returns all routes in the order of priority
+	GetRoutes() []Route
+
+	// AddRoute adds a route with the lowest priority
+	AddRoute(route Route) error
+
+	// RemoveRoute removes the route for the pattern
+	RemoveRoute(pattern string) error
+
+	// GetLayoutForReference returns a Layout for the root which the first matching route points to.
+	// If no route matches the reference, it returns the Layout for the primary root.
+	// Roots whose layout cannot hold the reference are skipped, falling back to the first root which can.
+	// If no root can hold it, it returns the Layout for the primary root: check it with LayoutService.CanHold.
+	GetLayoutForReference(ref repository.Reference) LayoutService
+
 	store.Content
 }
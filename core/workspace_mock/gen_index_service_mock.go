@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace/index_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source workspace/index_service.go -destination workspace_mock/gen_index_service_mock.go -package workspace_mock
+//
+
+// Package workspace_mock is a generated GoMock package.
+package workspace_mock
+
+import (
+	reflect "reflect"
+
+	repository "github.com/kyoh86/gogh/v4/core/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIndexService is a mock of IndexService interface.
+type MockIndexService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndexServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockIndexServiceMockRecorder is the mock recorder for MockIndexService.
+type MockIndexServiceMockRecorder struct {
+	mock *MockIndexService
+}
+
+// NewMockIndexService creates a new mock instance.
+func NewMockIndexService(ctrl *gomock.Controller) *MockIndexService {
+	mock := &MockIndexService{ctrl: ctrl}
+	mock.recorder = &MockIndexServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndexService) EXPECT() *MockIndexServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockIndexService) Add(location *repository.Location) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Add", location)
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockIndexServiceMockRecorder) Add(location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockIndexService)(nil).Add), location)
+}
+
+// Built mocks base method.
+func (m *MockIndexService) Built() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Built")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Built indicates an expected call of Built.
+func (mr *MockIndexServiceMockRecorder) Built() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Built", reflect.TypeOf((*MockIndexService)(nil).Built))
+}
+
+// Drop mocks base method.
+func (m *MockIndexService) Drop() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drop")
+}
+
+// Drop indicates an expected call of Drop.
+func (mr *MockIndexServiceMockRecorder) Drop() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drop", reflect.TypeOf((*MockIndexService)(nil).Drop))
+}
+
+// Entries mocks base method.
+func (m *MockIndexService) Entries() []*repository.Location {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].([]*repository.Location)
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockIndexServiceMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockIndexService)(nil).Entries))
+}
+
+// Get mocks base method.
+func (m *MockIndexService) Get(fullPath string) (*repository.Location, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", fullPath)
+	ret0, _ := ret[0].(*repository.Location)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIndexServiceMockRecorder) Get(fullPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIndexService)(nil).Get), fullPath)
+}
+
+// HasChanges mocks base method.
+func (m *MockIndexService) HasChanges() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChanges")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasChanges indicates an expected call of HasChanges.
+func (mr *MockIndexServiceMockRecorder) HasChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChanges", reflect.TypeOf((*MockIndexService)(nil).HasChanges))
+}
+
+// MarkSaved mocks base method.
+func (m *MockIndexService) MarkSaved() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkSaved")
+}
+
+// MarkSaved indicates an expected call of MarkSaved.
+func (mr *MockIndexServiceMockRecorder) MarkSaved() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSaved", reflect.TypeOf((*MockIndexService)(nil).MarkSaved))
+}
+
+// Remove mocks base method.
+func (m *MockIndexService) Remove(fullPath string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", fullPath)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockIndexServiceMockRecorder) Remove(fullPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockIndexService)(nil).Remove), fullPath)
+}
+
+// Reset mocks base method.
+func (m *MockIndexService) Reset(locations []*repository.Location) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset", locations)
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockIndexServiceMockRecorder) Reset(locations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockIndexService)(nil).Reset), locations)
+}
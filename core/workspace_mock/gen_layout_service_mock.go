@@ -40,6 +40,20 @@ func (m *MockLayoutService) EXPECT() *MockLayoutServiceMockRecorder {
 	return m.recorder
 }
 
+// CanHold mocks base method.
+func (m *MockLayoutService) CanHold(ref repository.Reference) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanHold", ref)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanHold indicates an expected call of CanHold.
+func (mr *MockLayoutServiceMockRecorder) CanHold(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanHold", reflect.TypeOf((*MockLayoutService)(nil).CanHold), ref)
+}
+
 // CreateRepositoryFolder mocks base method.
 func (m *MockLayoutService) CreateRepositoryFolder(reference repository.Reference) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace/tag_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source workspace/tag_service.go -destination workspace_mock/gen_tag_service_mock.go -package workspace_mock
+//
+
+// Package workspace_mock is a generated GoMock package.
+package workspace_mock
+
+import (
+	iter "iter"
+	reflect "reflect"
+
+	repository "github.com/kyoh86/gogh/v4/core/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagService is a mock of TagService interface.
+type MockTagService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTagServiceMockRecorder is the mock recorder for MockTagService.
+type MockTagServiceMockRecorder struct {
+	mock *MockTagService
+}
+
+// NewMockTagService creates a new mock instance.
+func NewMockTagService(ctrl *gomock.Controller) *MockTagService {
+	mock := &MockTagService{ctrl: ctrl}
+	mock.recorder = &MockTagServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagService) EXPECT() *MockTagServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTagService) Add(ref repository.Reference, tags ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ref}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Add", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTagServiceMockRecorder) Add(ref any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ref}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTagService)(nil).Add), varargs...)
+}
+
+// Clear mocks base method.
+func (m *MockTagService) Clear(ref repository.Reference) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Clear", ref)
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockTagServiceMockRecorder) Clear(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockTagService)(nil).Clear), ref)
+}
+
+// Entries mocks base method.
+func (m *MockTagService) Entries() iter.Seq2[repository.Reference, []string] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].(iter.Seq2[repository.Reference, []string])
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockTagServiceMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockTagService)(nil).Entries))
+}
+
+// HasAny mocks base method.
+func (m *MockTagService) HasAny(ref repository.Reference, tags ...string) bool {
+	m.ctrl.T.Helper()
+	varargs := []any{ref}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "HasAny", varargs...)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasAny indicates an expected call of HasAny.
+func (mr *MockTagServiceMockRecorder) HasAny(ref any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ref}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAny", reflect.TypeOf((*MockTagService)(nil).HasAny), varargs...)
+}
+
+// HasChanges mocks base method.
+func (m *MockTagService) HasChanges() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChanges")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasChanges indicates an expected call of HasChanges.
+func (mr *MockTagServiceMockRecorder) HasChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChanges", reflect.TypeOf((*MockTagService)(nil).HasChanges))
+}
+
+// MarkSaved mocks base method.
+func (m *MockTagService) MarkSaved() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkSaved")
+}
+
+// MarkSaved indicates an expected call of MarkSaved.
+func (mr *MockTagServiceMockRecorder) MarkSaved() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSaved", reflect.TypeOf((*MockTagService)(nil).MarkSaved))
+}
+
+// Move mocks base method.
+func (m *MockTagService) Move(from, to repository.Reference) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Move", from, to)
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTagServiceMockRecorder) Move(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTagService)(nil).Move), from, to)
+}
+
+// Remove mocks base method.
+func (m *MockTagService) Remove(ref repository.Reference, tags ...string) {
+	m.ctrl.T.Helper()
+	varargs := []any{ref}
+	for _, a := range tags {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Remove", varargs...)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTagServiceMockRecorder) Remove(ref any, tags ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ref}, tags...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTagService)(nil).Remove), varargs...)
+}
+
+// Tags mocks base method.
+func (m *MockTagService) Tags(ref repository.Reference) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", ref)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockTagServiceMockRecorder) Tags(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockTagService)(nil).Tags), ref)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace/usage_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source workspace/usage_service.go -destination workspace_mock/gen_usage_service_mock.go -package workspace_mock
+//
+
+// Package workspace_mock is a generated GoMock package.
+package workspace_mock
+
+import (
+	iter "iter"
+	reflect "reflect"
+	time "time"
+
+	repository "github.com/kyoh86/gogh/v4/core/repository"
+	workspace "github.com/kyoh86/gogh/v4/core/workspace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsageService is a mock of UsageService interface.
+type MockUsageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUsageServiceMockRecorder is the mock recorder for MockUsageService.
+type MockUsageServiceMockRecorder struct {
+	mock *MockUsageService
+}
+
+// NewMockUsageService creates a new mock instance.
+func NewMockUsageService(ctrl *gomock.Controller) *MockUsageService {
+	mock := &MockUsageService{ctrl: ctrl}
+	mock.recorder = &MockUsageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageService) EXPECT() *MockUsageServiceMockRecorder {
+	return m.recorder
+}
+
+// Entries mocks base method.
+func (m *MockUsageService) Entries() iter.Seq2[repository.Reference, workspace.Usage] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].(iter.Seq2[repository.Reference, workspace.Usage])
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockUsageServiceMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockUsageService)(nil).Entries))
+}
+
+// Get mocks base method.
+func (m *MockUsageService) Get(ref repository.Reference) (workspace.Usage, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ref)
+	ret0, _ := ret[0].(workspace.Usage)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUsageServiceMockRecorder) Get(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsageService)(nil).Get), ref)
+}
+
+// HasChanges mocks base method.
+func (m *MockUsageService) HasChanges() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChanges")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasChanges indicates an expected call of HasChanges.
+func (mr *MockUsageServiceMockRecorder) HasChanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChanges", reflect.TypeOf((*MockUsageService)(nil).HasChanges))
+}
+
+// MarkSaved mocks base method.
+func (m *MockUsageService) MarkSaved() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkSaved")
+}
+
+// MarkSaved indicates an expected call of MarkSaved.
+func (mr *MockUsageServiceMockRecorder) MarkSaved() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSaved", reflect.TypeOf((*MockUsageService)(nil).MarkSaved))
+}
+
+// Set mocks base method.
+func (m *MockUsageService) Set(ref repository.Reference, usage workspace.Usage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Set", ref, usage)
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockUsageServiceMockRecorder) Set(ref, usage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockUsageService)(nil).Set), ref, usage)
+}
+
+// Visit mocks base method.
+func (m *MockUsageService) Visit(ref repository.Reference, at time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Visit", ref, at)
+}
+
+// Visit indicates an expected call of Visit.
+func (mr *MockUsageServiceMockRecorder) Visit(ref, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Visit", reflect.TypeOf((*MockUsageService)(nil).Visit), ref, at)
+}
@@ -12,6 +12,7 @@ package workspace_mock
 import (
 	reflect "reflect"
 
+	repository "github.com/kyoh86/gogh/v4/core/repository"
 	workspace "github.com/kyoh86/gogh/v4/core/workspace"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,6 +55,20 @@ func (mr *MockWorkspaceServiceMockRecorder) AddRoot(root, asPrimary any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoot", reflect.TypeOf((*MockWorkspaceService)(nil).AddRoot), root, asPrimary)
 }
 
+// AddRoute mocks base method.
+func (m *MockWorkspaceService) AddRoute(route workspace.Route) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoute", route)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRoute indicates an expected call of AddRoute.
+func (mr *MockWorkspaceServiceMockRecorder) AddRoute(route any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoute", reflect.TypeOf((*MockWorkspaceService)(nil).AddRoute), route)
+}
+
 // GetLayoutFor mocks base method.
 func (m *MockWorkspaceService) GetLayoutFor(root workspace.Root) workspace.LayoutService {
 	m.ctrl.T.Helper()
@@ -68,6 +83,20 @@ func (mr *MockWorkspaceServiceMockRecorder) GetLayoutFor(root any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayoutFor", reflect.TypeOf((*MockWorkspaceService)(nil).GetLayoutFor), root)
 }
 
+// GetLayoutForReference mocks base method.
+func (m *MockWorkspaceService) GetLayoutForReference(ref repository.Reference) workspace.LayoutService {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLayoutForReference", ref)
+	ret0, _ := ret[0].(workspace.LayoutService)
+	return ret0
+}
+
+// GetLayoutForReference indicates an expected call of GetLayoutForReference.
+func (mr *MockWorkspaceServiceMockRecorder) GetLayoutForReference(ref any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLayoutForReference", reflect.TypeOf((*MockWorkspaceService)(nil).GetLayoutForReference), ref)
+}
+
 // GetPrimaryLayout mocks base method.
 func (m *MockWorkspaceService) GetPrimaryLayout() workspace.LayoutService {
 	m.ctrl.T.Helper()
@@ -96,6 +125,20 @@ func (mr *MockWorkspaceServiceMockRecorder) GetPrimaryRoot() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrimaryRoot", reflect.TypeOf((*MockWorkspaceService)(nil).GetPrimaryRoot))
 }
 
+// GetRootLayout mocks base method.
+func (m *MockWorkspaceService) GetRootLayout(root workspace.Root) workspace.Layout {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRootLayout", root)
+	ret0, _ := ret[0].(workspace.Layout)
+	return ret0
+}
+
+// GetRootLayout indicates an expected call of GetRootLayout.
+func (mr *MockWorkspaceServiceMockRecorder) GetRootLayout(root any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRootLayout", reflect.TypeOf((*MockWorkspaceService)(nil).GetRootLayout), root)
+}
+
 // GetRoots mocks base method.
 func (m *MockWorkspaceService) GetRoots() []workspace.Root {
 	m.ctrl.T.Helper()
@@ -110,6 +153,20 @@ func (mr *MockWorkspaceServiceMockRecorder) GetRoots() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoots", reflect.TypeOf((*MockWorkspaceService)(nil).GetRoots))
 }
 
+// GetRoutes mocks base method.
+func (m *MockWorkspaceService) GetRoutes() []workspace.Route {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoutes")
+	ret0, _ := ret[0].([]workspace.Route)
+	return ret0
+}
+
+// GetRoutes indicates an expected call of GetRoutes.
+func (mr *MockWorkspaceServiceMockRecorder) GetRoutes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoutes", reflect.TypeOf((*MockWorkspaceService)(nil).GetRoutes))
+}
+
 // HasChanges mocks base method.
 func (m *MockWorkspaceService) HasChanges() bool {
 	m.ctrl.T.Helper()
@@ -150,6 +207,20 @@ func (mr *MockWorkspaceServiceMockRecorder) RemoveRoot(root any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoot", reflect.TypeOf((*MockWorkspaceService)(nil).RemoveRoot), root)
 }
 
+// RemoveRoute mocks base method.
+func (m *MockWorkspaceService) RemoveRoute(pattern string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRoute", pattern)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRoute indicates an expected call of RemoveRoute.
+func (mr *MockWorkspaceServiceMockRecorder) RemoveRoute(pattern any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoute", reflect.TypeOf((*MockWorkspaceService)(nil).RemoveRoute), pattern)
+}
+
 // SetPrimaryRoot mocks base method.
 func (m *MockWorkspaceService) SetPrimaryRoot(arg0 workspace.Root) error {
 	m.ctrl.T.Helper()
@@ -163,3 +234,17 @@ func (mr *MockWorkspaceServiceMockRecorder) SetPrimaryRoot(arg0 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryRoot", reflect.TypeOf((*MockWorkspaceService)(nil).SetPrimaryRoot), arg0)
 }
+
+// SetRootLayout mocks base method.
+func (m *MockWorkspaceService) SetRootLayout(root workspace.Root, layout workspace.Layout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRootLayout", root, layout)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRootLayout indicates an expected call of SetRootLayout.
+func (mr *MockWorkspaceServiceMockRecorder) SetRootLayout(root, layout any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRootLayout", reflect.TypeOf((*MockWorkspaceService)(nil).SetRootLayout), root, layout)
+}
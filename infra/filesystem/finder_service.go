@@ -6,6 +6,7 @@ import (
 	"iter"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
@@ -13,10 +14,82 @@ import (
 	"github.com/kyoh86/gogh/v4/core/workspace"
 )
 
-type FinderService struct{}
+// FinderService finds the repositories in the roots.
+// If it has a built index, it lists and finds the repositories from the index
+// instead of walking the roots, validating each entry against the filesystem.
+type FinderService struct {
+	index       workspace.IndexService
+	tags        workspace.TagService
+	concurrency int
+}
+
+// FinderOption is an option for the FinderService
+type FinderOption func(*FinderService)
+
+// FinderIndex sets the index of the repositories
+var FinderIndex = func(index workspace.IndexService) FinderOption {
+	return func(f *FinderService) {
+		f.index = index
+	}
+}
 
-func NewFinderService() *FinderService {
-	return &FinderService{}
+// FinderTags sets the tags of the repositories to filter them by workspace.ListOptions.Tags.
+// Without it, no repository matches the tags.
+var FinderTags = func(tags workspace.TagService) FinderOption {
+	return func(f *FinderService) {
+		f.tags = tags
+	}
+}
+
+// FinderConcurrency sets the maximum number of the directories read at the same time
+// while walking the roots (default: DefaultFinderConcurrency).
+var FinderConcurrency = func(concurrency int) FinderOption {
+	return func(f *FinderService) {
+		if concurrency > 0 {
+			f.concurrency = concurrency
+		}
+	}
+}
+
+// DefaultFinderConcurrency is the default maximum number of the directories read at the same time
+var DefaultFinderConcurrency = 4 * runtime.GOMAXPROCS(0)
+
+func NewFinderService(options ...FinderOption) *FinderService {
+	f := &FinderService{concurrency: DefaultFinderConcurrency}
+	for _, o := range options {
+		o(f)
+	}
+	return f
+}
+
+// indexed returns whether the index is available
+func (f *FinderService) indexed() bool {
+	return f.index != nil && f.index.Built()
+}
+
+// validate checks that the indexed location still exists as the repository in the layout.
+// A location whose directory is gone is removed from the index.
+// A location which does not match the layout is just skipped,
+// because it may belong to another root (e.g. nested roots).
+func (f *FinderService) validate(l workspace.LayoutService, location *repository.Location) (bool, error) {
+	ref, err := l.ExactMatch(location.FullPath())
+	switch {
+	case errors.Is(err, workspace.ErrNotMatched):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	if *ref != location.Ref() {
+		return false, nil
+	}
+	isDir, err := f.isDir(location.FullPath())
+	if err != nil {
+		return false, err
+	}
+	if !isDir {
+		f.index.Remove(location.FullPath())
+	}
+	return isDir, nil
 }
 
 func (f *FinderService) isDir(path string) (bool, error) {
@@ -33,18 +106,38 @@ func (f *FinderService) isDir(path string) (bool, error) {
 // FindByReference implements workspace.FinderService.
 func (f *FinderService) FindByReference(ctx context.Context, ws workspace.WorkspaceService, ref repository.Reference) (*repository.Location, error) {
 	for _, root := range ws.GetRoots() {
-		abs := filepath.Join(root, ref.String())
+		layout := ws.GetLayoutFor(root)
+		abs := layout.PathFor(ref)
+		if matched, err := layout.ExactMatch(abs); err != nil || *matched != ref {
+			// The root cannot hold the repository (e.g. the host or owner is fixed to another one)
+			continue
+		}
+		if f.indexed() {
+			if location, ok := f.index.Get(abs); ok {
+				valid, err := f.validate(layout, location)
+				if err != nil {
+					return nil, err
+				}
+				if valid {
+					return location, nil
+				}
+			}
+		}
 		isDir, err := f.isDir(abs)
 		if err != nil {
 			return nil, err
 		}
 		if isDir {
-			return repository.NewLocation(
+			location := repository.NewLocation(
 				abs,
 				ref.Host(),
 				ref.Owner(),
 				ref.Name(),
-			), nil
+			)
+			if f.indexed() {
+				f.index.Add(location)
+			}
+			return location, nil
 		}
 	}
 	return nil, workspace.ErrNotMatched
@@ -92,12 +185,20 @@ func (f *FinderService) FindByPath(ctx context.Context, ws workspace.WorkspaceSe
 }
 
 // ListAllRepository implements workspace.FinderService.
+// It walks the roots concurrently, and lists the repositories in the order of the roots
+// and the lexical order of the paths in each root.
 func (f *FinderService) ListAllRepository(ctx context.Context, ws workspace.WorkspaceService, opts workspace.ListOptions) iter.Seq2[*repository.Location, error] {
+	var layouts []workspace.LayoutService
+	for _, root := range ws.GetRoots() {
+		layouts = append(layouts, ws.GetLayoutFor(root))
+	}
+	if !f.indexed() {
+		return walk(ctx, f.concurrency, layouts, f.matcher(opts), opts.Limit)
+	}
 	return func(yield func(*repository.Location, error) bool) {
 		var i int
-		for _, root := range ws.GetRoots() {
-			layout := ws.GetLayoutFor(root)
-			for ref, err := range f.ListRepositoryInRoot(ctx, layout, opts) {
+		for _, layout := range layouts {
+			for ref, err := range f.listIndexedRepositoryInRoot(layout, opts) {
 				if err != nil {
 					yield(nil, err)
 					return
@@ -116,57 +217,62 @@ func (f *FinderService) ListAllRepository(ctx context.Context, ws workspace.Work
 
 // ListRepositoryInRoot implements workspace.FinderService.
 func (f *FinderService) ListRepositoryInRoot(ctx context.Context, l workspace.LayoutService, opts workspace.ListOptions) iter.Seq2[*repository.Location, error] {
-	var i int
+	if f.indexed() {
+		return f.listIndexedRepositoryInRoot(l, opts)
+	}
+	return walk(ctx, f.concurrency, []workspace.LayoutService{l}, f.matcher(opts), opts.Limit)
+}
+
+// listIndexedRepositoryInRoot lists the repositories in the root from the index
+func (f *FinderService) listIndexedRepositoryInRoot(l workspace.LayoutService, opts workspace.ListOptions) iter.Seq2[*repository.Location, error] {
 	return func(yield func(*repository.Location, error) bool) {
-		if err := filepath.Walk(l.GetRoot(), func(p string, info os.FileInfo, err error) error {
-			if os.IsNotExist(err) {
-				return nil
+		prefix := filepath.Clean(l.GetRoot()) + string(filepath.Separator)
+		match := f.matcher(opts)
+		var i int
+		for _, location := range f.index.Entries() {
+			if !strings.HasPrefix(location.FullPath(), prefix) {
+				continue
 			}
+			matched, err := match(location.Ref())
 			if err != nil {
-				return err
+				yield(nil, err)
+				return
 			}
-			if !info.IsDir() {
-				return nil
+			if !matched {
+				continue
 			}
-			ref, err := l.ExactMatch(p)
-			switch {
-			case errors.Is(err, workspace.ErrNotMatched):
-				// Ignore directories that do not match the layout
-			case err == nil:
-				if ref == nil {
-					return filepath.SkipDir
-				}
-				match, err := matchPattern(opts.Patterns, *ref)
-				if err != nil {
-					return err
-				}
-				if !match {
-					return filepath.SkipDir
-				}
-				if !yield(repository.NewLocation(
-					p,
-					ref.Host(),
-					ref.Owner(),
-					ref.Name(),
-				), nil) {
-					return filepath.SkipAll
-				}
-				return filepath.SkipDir
-			default:
-				return err
+			valid, err := f.validate(l, location)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !valid {
+				continue
+			}
+			if !yield(location, nil) {
+				return
 			}
 			i++
 			if opts.Limit > 0 && i >= opts.Limit {
-				return filepath.SkipAll
+				return
 			}
-			return nil
-		}); err != nil {
-			yield(nil, err)
-			return
 		}
 	}
 }
 
+// matcher checks that the reference matches the options
+type matcher func(ref repository.Reference) (bool, error)
+
+// matcher builds the matcher for the patterns and the tags in the options
+func (f *FinderService) matcher(opts workspace.ListOptions) matcher {
+	return func(ref repository.Reference) (bool, error) {
+		if len(opts.Tags) > 0 && (f.tags == nil || !f.tags.HasAny(ref, opts.Tags...)) {
+			return false, nil
+		}
+		return matchPattern(opts.Patterns, ref)
+	}
+}
+
 func matchPattern(patterns []string, ref repository.Reference) (bool, error) {
 	if len(patterns) == 0 {
 		return true, nil
@@ -3,8 +3,11 @@ package filesystem_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/kyoh86/gogh/v4/core/repository"
@@ -310,3 +313,435 @@ func TestListAllRepository(t *testing.T) {
 		t.Errorf("Expected to find 2 repositories, got %d: %v", len(found), found)
 	}
 }
+
+func TestFinderWithLayoutTemplate(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	root1 := filepath.Join(tmpDir, "root1")
+	root2 := filepath.Join(tmpDir, "root2")
+	for _, dir := range []string{
+		filepath.Join(root1, "github.com", "kyoh86", "gogh"),
+		filepath.Join(root2, "dotfiles"),
+		filepath.Join(root2, "gogh-ext", "nested"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create test repository directory: %v", err)
+		}
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	if err := ws.AddRoot(root1, true); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+	if err := ws.AddRoot(root2, false); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+	if err := ws.SetRootLayout(root2, workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}); err != nil {
+		t.Fatalf("Failed to set layout: %v", err)
+	}
+
+	finder := filesystem.NewFinderService()
+	ctx := context.Background()
+
+	var found []string
+	for loc, err := range finder.ListAllRepository(ctx, ws, workspace.ListOptions{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		found = append(found, loc.Path()+"@"+loc.FullPath())
+	}
+	want := []string{
+		"github.com/kyoh86/gogh@" + filepath.Join(root1, "github.com", "kyoh86", "gogh"),
+		"github.com/kyoh86/dotfiles@" + filepath.Join(root2, "dotfiles"),
+		"github.com/kyoh86/gogh-ext@" + filepath.Join(root2, "gogh-ext"),
+	}
+	if len(found) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, found)
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Errorf("Expected %s, got %s", want[i], found[i])
+		}
+	}
+
+	loc, err := finder.FindByReference(ctx, ws, repository.NewReference("github.com", "kyoh86", "dotfiles"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.FullPath() != filepath.Join(root2, "dotfiles") {
+		t.Errorf("Expected %s, got %s", filepath.Join(root2, "dotfiles"), loc.FullPath())
+	}
+	if _, err := finder.FindByReference(ctx, ws, repository.NewReference("github.com", "other", "dotfiles")); !errors.Is(err, workspace.ErrNotMatched) {
+		t.Errorf("Expected ErrNotMatched for another owner, got %v", err)
+	}
+
+	loc, err = finder.FindByPath(ctx, ws, filepath.Join(root2, "gogh-ext", "nested"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.Path() != "github.com/kyoh86/gogh-ext" {
+		t.Errorf("Expected github.com/kyoh86/gogh-ext, got %s", loc.Path())
+	}
+}
+
+func TestFinderWithIndex(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "root")
+	goghPath := filepath.Join(root, "github.com", "kyoh86", "gogh")
+	dotfilesPath := filepath.Join(root, "github.com", "kyoh86", "dotfiles")
+	for _, dir := range []string{goghPath, dotfilesPath} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create test repository directory: %v", err)
+		}
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	if err := ws.AddRoot(root, true); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+
+	index := workspace.NewIndexService()
+	finder := filesystem.NewFinderService(filesystem.FinderIndex(index))
+	ctx := context.Background()
+
+	listAll := func() []string {
+		var found []string
+		for loc, err := range finder.ListAllRepository(ctx, ws, workspace.ListOptions{}) {
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			found = append(found, loc.Path())
+		}
+		return found
+	}
+
+	// Without the index built, it walks the directories and does not touch the index
+	if found := listAll(); len(found) != 2 {
+		t.Fatalf("Expected 2 repositories, got %v", found)
+	}
+	if index.Built() {
+		t.Fatal("Index should not be built by listing")
+	}
+
+	index.Reset([]*repository.Location{
+		repository.NewLocation(goghPath, "github.com", "kyoh86", "gogh"),
+		repository.NewLocation(dotfilesPath, "github.com", "kyoh86", "dotfiles"),
+	})
+
+	// Repositories put outside gogh are not listed until reindexing
+	if err := os.MkdirAll(filepath.Join(root, "github.com", "kyoh86", "unindexed"), 0o755); err != nil {
+		t.Fatalf("Failed to create test repository directory: %v", err)
+	}
+	found := listAll()
+	if want := []string{"github.com/kyoh86/dotfiles", "github.com/kyoh86/gogh"}; strings.Join(found, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, found)
+	}
+
+	// But they can be found by reference and are added to the index
+	loc, err := finder.FindByReference(ctx, ws, repository.NewReference("github.com", "kyoh86", "unindexed"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := index.Get(loc.FullPath()); !ok {
+		t.Error("Found repository should be added to the index")
+	}
+
+	// Removed repositories are dropped from the index lazily
+	if err := os.RemoveAll(dotfilesPath); err != nil {
+		t.Fatalf("Failed to remove test repository directory: %v", err)
+	}
+	found = listAll()
+	if want := []string{"github.com/kyoh86/gogh", "github.com/kyoh86/unindexed"}; strings.Join(found, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, found)
+	}
+	if _, ok := index.Get(dotfilesPath); ok {
+		t.Error("Removed repository should be dropped from the index")
+	}
+	if _, err := finder.FindByReference(ctx, ws, repository.NewReference("github.com", "kyoh86", "dotfiles")); !errors.Is(err, workspace.ErrNotMatched) {
+		t.Errorf("Expected ErrNotMatched for removed repository, got %v", err)
+	}
+}
+
+func TestListAllRepositoryConcurrently(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	roots := []string{filepath.Join(tmpDir, "root2"), filepath.Join(tmpDir, "root1")}
+	var want []string
+	for _, root := range roots {
+		for _, host := range []string{"github.com", "gitlab.com"} {
+			for _, owner := range []string{"alice", "bob", "carol"} {
+				for _, name := range []string{"a", "b", "c", "d"} {
+					if err := os.MkdirAll(filepath.Join(root, host, owner, name, "sub"), 0o755); err != nil {
+						t.Fatalf("Failed to create test repository directory: %v", err)
+					}
+					want = append(want, filepath.Join(root, host, owner, name))
+				}
+			}
+		}
+		// Files and too shallow directories are not repositories
+		if err := os.WriteFile(filepath.Join(root, "github.com", "file"), nil, 0o644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Join(root, "example.com", "empty"), 0o755); err != nil {
+			t.Fatalf("Failed to create test directory: %v", err)
+		}
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	for _, root := range roots {
+		if err := ws.AddRoot(root, false); err != nil {
+			t.Fatalf("Failed to add root: %v", err)
+		}
+	}
+
+	list := func(ctx context.Context, finder *filesystem.FinderService, opts workspace.ListOptions) ([]string, error) {
+		var found []string
+		for loc, err := range finder.ListAllRepository(ctx, ws, opts) {
+			if err != nil {
+				return found, err
+			}
+			found = append(found, loc.FullPath())
+		}
+		return found, nil
+	}
+
+	for _, concurrency := range []int{1, 2, 16} {
+		finder := filesystem.NewFinderService(filesystem.FinderConcurrency(concurrency))
+
+		t.Run(fmt.Sprintf("deterministic order with %d workers", concurrency), func(t *testing.T) {
+			for range 5 {
+				found, err := list(context.Background(), finder, workspace.ListOptions{})
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if strings.Join(found, "\n") != strings.Join(want, "\n") {
+					t.Fatalf("Expected %v, got %v", want, found)
+				}
+			}
+		})
+
+		t.Run(fmt.Sprintf("limit and patterns with %d workers", concurrency), func(t *testing.T) {
+			found, err := list(context.Background(), finder, workspace.ListOptions{
+				Limit:    3,
+				Patterns: []string{"gitlab.com/bob/*"},
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expected := []string{
+				filepath.Join(roots[0], "gitlab.com", "bob", "a"),
+				filepath.Join(roots[0], "gitlab.com", "bob", "b"),
+				filepath.Join(roots[0], "gitlab.com", "bob", "c"),
+			}
+			if strings.Join(found, "\n") != strings.Join(expected, "\n") {
+				t.Errorf("Expected %v, got %v", expected, found)
+			}
+		})
+
+		t.Run(fmt.Sprintf("stop iterating with %d workers", concurrency), func(t *testing.T) {
+			var count int
+			for _, err := range finder.ListAllRepository(context.Background(), ws, workspace.ListOptions{}) {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				count++
+				if count == 5 {
+					break
+				}
+			}
+			if count != 5 {
+				t.Errorf("Expected to stop at 5, got %d", count)
+			}
+		})
+
+		t.Run(fmt.Sprintf("cancelled context with %d workers", concurrency), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := list(ctx, finder, workspace.ListOptions{}); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFinderWithNestedOwner(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "root")
+	for _, dir := range []string{
+		filepath.Join(root, "github.com", "kyoh86", "gogh", ".git"),
+		filepath.Join(root, "github.com", "kyoh86", "gogh", "cmd"),
+		filepath.Join(root, "gitlab.com", "group", "project", ".git"),
+		filepath.Join(root, "gitlab.com", "group", "sub", "deep", ".git"),
+		filepath.Join(root, "gitlab.com", "group", "sub", "deeper", "leaf", ".git"),
+		filepath.Join(root, "gitlab.com", "group", "sub", "node_modules", "x", ".git"),
+		filepath.Join(root, "gitlab.com", "group", "empty"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create test repository directory: %v", err)
+		}
+	}
+	// Namespaces are not walked deeper than the max segments of the owner
+	deepest := slices.Repeat([]string{"max"}, repository.MaxOwnerSegments)
+	tooDeep := slices.Repeat([]string{"over"}, repository.MaxOwnerSegments+1)
+	for _, segments := range [][]string{deepest, tooDeep} {
+		dir := filepath.Join(append(append([]string{root, "gitlab.com"}, segments...), "leaf", ".git")...)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create test repository directory: %v", err)
+		}
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	if err := ws.AddRoot(root, true); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+	finder := filesystem.NewFinderService()
+	ctx := context.Background()
+
+	var found []string
+	for loc, err := range finder.ListAllRepository(ctx, ws, workspace.ListOptions{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		found = append(found, loc.Owner()+" "+loc.Name())
+	}
+	want := []string{
+		"kyoh86 gogh",
+		"group empty",
+		"group project",
+		"group/sub deep",
+		"group/sub/deeper leaf",
+		strings.Join(deepest, "/") + " leaf",
+		"over " + tooDeep[1],
+	}
+	if strings.Join(found, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected %v, got %v", want, found)
+	}
+
+	loc, err := finder.FindByReference(ctx, ws, repository.NewReference("gitlab.com", "group/sub/deeper", "leaf"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.FullPath() != filepath.Join(root, "gitlab.com", "group", "sub", "deeper", "leaf") {
+		t.Errorf("Unexpected location: %s", loc.FullPath())
+	}
+
+	loc, err = finder.FindByPath(ctx, ws, filepath.Join(root, "gitlab.com", "group", "sub", "deep"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loc.Ref() != repository.NewReference("gitlab.com", "group/sub", "deep") {
+		t.Errorf("Unexpected reference: %s", loc.Ref())
+	}
+}
+
+func TestListRepositoryWithSymlink(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "root")
+	outside := filepath.Join(tmpDir, "outside", "gogh")
+	if err := os.MkdirAll(filepath.Join(outside, ".git"), 0o755); err != nil {
+		t.Fatalf("Failed to create test repository directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "github.com", "kyoh86"), 0o755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "github.com", "kyoh86", "gogh")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	// Links to other than repositories are ignored
+	if err := os.Symlink(filepath.Join(tmpDir, "outside"), filepath.Join(root, "github.com", "kyoh86", "outside")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	if err := ws.AddRoot(root, true); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+	var found []string
+	for loc, err := range filesystem.NewFinderService().ListAllRepository(context.Background(), ws, workspace.ListOptions{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		found = append(found, loc.Path())
+	}
+	if len(found) != 1 || found[0] != "github.com/kyoh86/gogh" {
+		t.Errorf("Expected [github.com/kyoh86/gogh], got %v", found)
+	}
+}
+
+func TestFinderWithTags(t *testing.T) {
+	tmpDir := setupTestEnvironment(t)
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "root")
+	var locations []*repository.Location
+	for _, name := range []string{"billing-api", "billing-web", "dotfiles"} {
+		path := filepath.Join(root, "github.com", "kyoh86", name)
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatalf("Failed to create test repository directory: %v", err)
+		}
+		locations = append(locations, repository.NewLocation(path, "github.com", "kyoh86", name))
+	}
+
+	ws := filesystem.NewWorkspaceService()
+	if err := ws.AddRoot(root, true); err != nil {
+		t.Fatalf("Failed to add root: %v", err)
+	}
+	tags := workspace.NewTagService()
+	if err := tags.Add(repository.NewReference("github.com", "kyoh86", "billing-api"), "billing", "api"); err != nil {
+		t.Fatalf("Failed to add tags: %v", err)
+	}
+	if err := tags.Add(repository.NewReference("github.com", "kyoh86", "billing-web"), "billing"); err != nil {
+		t.Fatalf("Failed to add tags: %v", err)
+	}
+	ctx := context.Background()
+
+	list := func(finder *filesystem.FinderService, opts workspace.ListOptions) string {
+		var found []string
+		for loc, err := range finder.ListAllRepository(ctx, ws, opts) {
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			found = append(found, loc.Name())
+		}
+		return strings.Join(found, ",")
+	}
+
+	index := workspace.NewIndexService()
+	for _, testcase := range []struct {
+		title string
+		build func()
+	}{
+		{title: "walk", build: func() {}},
+		{title: "index", build: func() { index.Reset(locations) }},
+	} {
+		t.Run(testcase.title, func(t *testing.T) {
+			testcase.build()
+			finder := filesystem.NewFinderService(filesystem.FinderIndex(index), filesystem.FinderTags(tags))
+			if got, want := list(finder, workspace.ListOptions{Tags: []string{"billing"}}), "billing-api,billing-web"; got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+			if got, want := list(finder, workspace.ListOptions{Tags: []string{"api", "unknown"}}), "billing-api"; got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+			if got, want := list(finder, workspace.ListOptions{Tags: []string{"billing"}, Patterns: []string{"*/*/*-web"}}), "billing-web"; got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+			if got, want := list(finder, workspace.ListOptions{}), "billing-api,billing-web,dotfiles"; got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		})
+	}
+
+	// Without the tags, no repository matches the tags
+	if got := list(filesystem.NewFinderService(), workspace.ListOptions{Tags: []string{"billing"}}); got != "" {
+		t.Errorf("Expected no repository, got %s", got)
+	}
+}
@@ -10,14 +10,30 @@ import (
 	"github.com/kyoh86/gogh/v4/typ"
 )
 
-// LayoutService is a filesystem-based standard repository layout implementation
+// LayoutService is a filesystem-based repository layout implementation.
+// By default, repositories are placed in "<root>/<host>/<owner>/<name>".
 type LayoutService struct {
-	root workspace.Root
+	root   workspace.Root
+	layout workspace.Layout
+}
+
+// LayoutOption is an option for the LayoutService
+type LayoutOption func(*LayoutService)
+
+// LayoutTemplate sets the layout of the repositories in the root
+var LayoutTemplate = func(layout workspace.Layout) LayoutOption {
+	return func(l *LayoutService) {
+		l.layout = layout
+	}
 }
 
 // NewLayoutService creates a new instance of LayoutService
-func NewLayoutService(root workspace.Root) *LayoutService {
-	return &LayoutService{root: root}
+func NewLayoutService(root workspace.Root, options ...LayoutOption) *LayoutService {
+	l := &LayoutService{root: root}
+	for _, o := range options {
+		o(l)
+	}
+	return l
 }
 
 // GetRoot returns the root of the layout
@@ -25,47 +41,127 @@ func (l *LayoutService) GetRoot() string {
 	return l.root
 }
 
-// Match returns the reference corresponding to the given path
+// Depth returns the depth of the repositories from the root.
+// Repositories with a multi-segment owner are placed deeper than it.
+func (l *LayoutService) Depth() int {
+	return len(l.layout.Segments())
+}
+
+// Match returns the reference corresponding to the given path.
+// If the directory at the depth of the layout is a namespace (a directory which is not a repository),
+// it looks for a repository with a multi-segment owner under it.
 func (l *LayoutService) Match(path string) (*repository.Reference, error) {
-	// Get the relative path from the root
-	relPath, err := filepath.Rel(l.root, path)
+	parts, err := l.split(path)
 	if err != nil {
+		return nil, err
+	}
+	if len(parts) < l.Depth() {
 		return nil, workspace.ErrNotMatched
 	}
+	if l.layout.Has(workspace.PlaceholderOwner) {
+		for depth := l.Depth(); depth < len(parts); depth++ {
+			if !isNamespace(l.join(parts[:depth])) {
+				break
+			}
+			if isRepository(l.join(parts[:depth+1])) {
+				return l.parse(parts[:depth+1])
+			}
+		}
+	}
+	return l.parse(parts[:l.Depth()])
+}
 
-	// Split the path components
-	parts := strings.Split(filepath.ToSlash(relPath), "/")
-	if len(parts) < 3 {
-		return nil, workspace.ErrNotMatched
+// ExactMatch returns the reference corresponding exactly to the given path.
+// A path deeper than the layout matches a repository with a multi-segment owner
+// only if the directories between the depth of the layout and the path are namespaces.
+func (l *LayoutService) ExactMatch(path string) (*repository.Reference, error) {
+	parts, err := l.split(path)
+	if err != nil {
+		return nil, err
 	}
-	if parts[0] == ".." || parts[0] == "." {
+	if len(parts) < l.Depth() {
 		return nil, workspace.ErrNotMatched
 	}
-
-	// Create a reference in the format host/owner/name
-	return typ.Ptr(repository.NewReference(parts[0], parts[1], parts[2])), nil
+	for depth := l.Depth(); depth < len(parts); depth++ {
+		if !isNamespace(l.join(parts[:depth])) {
+			return nil, workspace.ErrNotMatched
+		}
+	}
+	return l.parse(parts)
 }
 
-// ExactMatch returns the reference corresponding exactly to the given path
-func (l *LayoutService) ExactMatch(path string) (*repository.Reference, error) {
-	// Get the relative path from the root
+// split splits the path relative to the root into its components
+func (l *LayoutService) split(path string) ([]string, error) {
 	relPath, err := filepath.Rel(l.root, path)
 	if err != nil {
 		return nil, workspace.ErrNotMatched
 	}
+	return strings.Split(filepath.ToSlash(relPath), "/"), nil
+}
 
-	// Split the path components
-	parts := strings.Split(filepath.ToSlash(relPath), "/")
-	if len(parts) != 3 {
+// join builds the path from the components relative to the root
+func (l *LayoutService) join(parts []string) string {
+	return filepath.Join(append([]string{l.root}, parts...)...)
+}
+
+// parse builds a reference from the path components placed by the template.
+// If it has more components than the template, the owner takes the extra ones.
+func (l *LayoutService) parse(parts []string) (*repository.Reference, error) {
+	segments := l.layout.Segments()
+	extra := len(parts) - len(segments)
+	if extra < 0 || (extra > 0 && !l.layout.Has(workspace.PlaceholderOwner)) {
 		return nil, workspace.ErrNotMatched
 	}
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			return nil, workspace.ErrNotMatched
+		}
+	}
+	host, owner, name := l.layout.Host, l.layout.Owner, ""
+	for _, segment := range segments {
+		switch segment {
+		case workspace.PlaceholderHost:
+			host, parts = parts[0], parts[1:]
+		case workspace.PlaceholderOwner:
+			owner, parts = strings.Join(parts[:extra+1], "/"), parts[extra+1:]
+		case workspace.PlaceholderName:
+			name, parts = parts[0], parts[1:]
+		}
+	}
+	return typ.Ptr(repository.NewReference(host, owner, name)), nil
+}
+
+// isRepository returns whether the directory is a repository (it has ".git")
+func isRepository(path string) bool {
+	_, err := os.Lstat(filepath.Join(path, ".git"))
+	return err == nil
+}
 
-	// Create a reference in the format host/owner/name
-	return typ.Ptr(repository.NewReference(parts[0], parts[1], parts[2])), nil
+// isNamespace returns whether the path is an existing directory which is not a repository
+func isNamespace(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir() && !isRepository(path)
 }
 
+// CanHold returns whether the layout can place the repository of the reference
+func (l *LayoutService) CanHold(ref repository.Reference) bool {
+	return l.layout.CanHold(ref)
+}
+
+// PathFor returns the path of the repository for the reference
 func (l *LayoutService) PathFor(ref repository.Reference) string {
-	return filepath.Join(l.root, ref.Host(), ref.Owner(), ref.Name())
+	elems := []string{l.root}
+	for _, segment := range l.layout.Segments() {
+		switch segment {
+		case workspace.PlaceholderHost:
+			elems = append(elems, ref.Host())
+		case workspace.PlaceholderOwner:
+			elems = append(elems, ref.Owner())
+		case workspace.PlaceholderName:
+			elems = append(elems, ref.Name())
+		}
+	}
+	return filepath.Join(elems...)
 }
 
 func (l *LayoutService) CreateRepositoryFolder(ref repository.Reference) (string, error) {
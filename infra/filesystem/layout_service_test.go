@@ -219,3 +219,132 @@ func TestLayoutServiceCreateAndDeleteRepository(t *testing.T) {
 		t.Errorf("Repository directory still exists after deletion")
 	}
 }
+
+func TestLayoutServiceTemplate(t *testing.T) {
+	root := "/test/root"
+	ref := repository.NewReference("github.com", "kyoh86", "gogh")
+
+	testCases := []struct {
+		name       string
+		layout     workspace.Layout
+		path       string
+		other      repository.Reference // a reference which is placed as is
+		otherMatch bool
+	}{
+		{
+			name:       "owner and name",
+			layout:     workspace.Layout{Template: "{owner}/{name}", Host: "github.com"},
+			path:       "/test/root/kyoh86/gogh",
+			other:      repository.NewReference("github.com", "other", "repo"),
+			otherMatch: true,
+		},
+		{
+			name:       "name only",
+			layout:     workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"},
+			path:       "/test/root/gogh",
+			other:      repository.NewReference("github.com", "other", "repo"),
+			otherMatch: false,
+		},
+		{
+			name:       "reordered",
+			layout:     workspace.Layout{Template: "{owner}/{host}/{name}"},
+			path:       "/test/root/kyoh86/github.com/gogh",
+			other:      repository.NewReference("gitlab.com", "other", "repo"),
+			otherMatch: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			layout := testtarget.NewLayoutService(root, testtarget.LayoutTemplate(tc.layout))
+
+			if got := layout.PathFor(ref); got != filepath.FromSlash(tc.path) {
+				t.Errorf("Expected path %s, got %s", tc.path, got)
+			}
+
+			exact, err := layout.ExactMatch(tc.path)
+			if err != nil {
+				t.Fatalf("Expected success for ExactMatch(%s), got error: %v", tc.path, err)
+			}
+			if *exact != ref {
+				t.Errorf("Expected %s, got %s", ref, exact)
+			}
+
+			matched, err := layout.Match(tc.path + "/cmd/gogh")
+			if err != nil {
+				t.Fatalf("Expected success for Match(%s/cmd/gogh), got error: %v", tc.path, err)
+			}
+			if *matched != ref {
+				t.Errorf("Expected %s, got %s", ref, matched)
+			}
+
+			if _, err := layout.ExactMatch(tc.path + "/cmd"); err != workspace.ErrNotMatched {
+				t.Errorf("Expected ErrNotMatched for a deeper path, got %v", err)
+			}
+
+			other, err := layout.ExactMatch(layout.PathFor(tc.other))
+			if err != nil {
+				t.Fatalf("Expected success for ExactMatch of %s, got error: %v", tc.other, err)
+			}
+			if (*other == tc.other) != tc.otherMatch {
+				t.Errorf("Expected the round trip of %s to be %v, got %s", tc.other, tc.otherMatch, other)
+			}
+		})
+	}
+}
+
+func TestLayoutServiceNestedOwner(t *testing.T) {
+	root := t.TempDir()
+	layout := testtarget.NewLayoutService(root)
+	project := filepath.Join(root, "gitlab.com", "group", "subgroup", "project")
+	plain := filepath.Join(root, "github.com", "kyoh86", "gogh")
+	for _, dir := range []string{
+		filepath.Join(project, ".git"),
+		filepath.Join(project, "src"),
+		filepath.Join(plain, ".git"),
+		filepath.Join(plain, "cmd"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+	nested := repository.NewReference("gitlab.com", "group/subgroup", "project")
+
+	if got := layout.PathFor(nested); got != project {
+		t.Errorf("Expected path %s, got %s", project, got)
+	}
+
+	ref, err := layout.ExactMatch(project)
+	if err != nil {
+		t.Fatalf("Expected success for ExactMatch(%s), got error: %v", project, err)
+	}
+	if *ref != nested {
+		t.Errorf("Expected %s, got %s", nested, ref)
+	}
+
+	ref, err = layout.Match(filepath.Join(project, "src"))
+	if err != nil {
+		t.Fatalf("Expected success for Match, got error: %v", err)
+	}
+	if *ref != nested {
+		t.Errorf("Expected %s, got %s", nested, ref)
+	}
+
+	// A directory in a repository is not a repository with a nested owner
+	if _, err := layout.ExactMatch(filepath.Join(plain, "cmd")); err != workspace.ErrNotMatched {
+		t.Errorf("Expected ErrNotMatched for a subdirectory of a repository, got %v", err)
+	}
+	ref, err = layout.Match(filepath.Join(plain, "cmd"))
+	if err != nil {
+		t.Fatalf("Expected success for Match, got error: %v", err)
+	}
+	if ref.String() != "github.com/kyoh86/gogh" {
+		t.Errorf("Expected github.com/kyoh86/gogh, got %s", ref)
+	}
+
+	// A layout without the owner cannot hold nested owners
+	fixed := testtarget.NewLayoutService(root, testtarget.LayoutTemplate(workspace.Layout{Template: "{host}/{name}", Owner: "kyoh86"}))
+	if _, err := fixed.ExactMatch(filepath.Join(root, "gitlab.com", "group", "subgroup")); err != workspace.ErrNotMatched {
+		t.Errorf("Expected ErrNotMatched for a layout without the owner, got %v", err)
+	}
+}
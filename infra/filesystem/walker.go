@@ -0,0 +1,242 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"iter"
+	"os"
+	"path/filepath"
+
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
+	"golang.org/x/sync/errgroup"
+)
+
+// walker walks the directories under the roots concurrently with a bounded number of workers.
+// Each directory is read by a task, and the sub-directories shallower than the layout
+// are walked by other tasks. The results are emitted in the order of the roots and
+// the lexical order of the paths in each root, as the sequential walk does.
+//
+// A directory at the depth of the layout which is not a repository (it does not have ".git")
+// may be a namespace holding repositories with a multi-segment owner (e.g. GitLab subgroups).
+// The walker looks for repositories under it, and treats the directory itself as a repository
+// only if no repository is found under it. It does not look for them deeper than the
+// repository.MaxOwnerSegments allows.
+type walker struct {
+	ctx   context.Context
+	group *errgroup.Group
+	match matcher
+}
+
+// walkTask is a directory to read.
+// The items are available after the done is closed.
+type walkTask struct {
+	done chan struct{}
+	// segments is the number of the owner segments of the repositories in a namespace,
+	// or 0 if the directory is shallower than the layout.
+	segments int
+	items    []walkItem
+	err      error
+}
+
+// walkItem is a found repository or a sub-directory to walk in the order of the path.
+// The location is nil if the found repository does not match the options.
+// If the item has both of the sub and the location, the location is used
+// only when no repository is found in the sub.
+type walkItem struct {
+	location *repository.Location
+	sub      *walkTask
+}
+
+// walk lists the repositories under the roots of the layouts which match with the matcher
+func walk(ctx context.Context, concurrency int, layouts []workspace.LayoutService, match matcher, limit int) iter.Seq2[*repository.Location, error] {
+	return func(yield func(*repository.Location, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		group := &errgroup.Group{}
+		group.SetLimit(concurrency)
+		w := &walker{ctx: ctx, group: group, match: match}
+		defer func() {
+			// Stop the workers which are not needed anymore
+			cancel()
+			_ = group.Wait()
+		}()
+
+		tasks := make([]*walkTask, 0, len(layouts))
+		for _, l := range layouts {
+			tasks = append(tasks, w.spawn(l, l.GetRoot(), 0))
+		}
+		var count int
+		for _, task := range tasks {
+			cont, err := w.emit(task, func(location *repository.Location) bool {
+				if !yield(location, nil) {
+					return false
+				}
+				count++
+				return limit <= 0 || count < limit
+			})
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !cont {
+				return
+			}
+		}
+	}
+}
+
+// spawn starts reading the directory.
+// If the segments is not 0, the directory is a namespace deeper than the layout
+// and the repositories in it have the owner with the segments.
+// If all workers are busy, it reads the directory in the current goroutine.
+func (w *walker) spawn(l workspace.LayoutService, dir string, segments int) *walkTask {
+	task := &walkTask{done: make(chan struct{}), segments: segments}
+	if !w.group.TryGo(func() error {
+		w.run(task, l, dir)
+		return nil
+	}) {
+		w.run(task, l, dir)
+	}
+	return task
+}
+
+// run reads the directory and finds the repositories and the sub-directories to walk
+func (w *walker) run(task *walkTask, l workspace.LayoutService, dir string) {
+	defer close(task.done)
+	if err := w.ctx.Err(); err != nil {
+		task.err = err
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			task.err = err
+		}
+		return
+	}
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			if entry.Type()&fs.ModeSymlink == 0 {
+				continue
+			}
+			// Symbolic links to repositories (e.g. adopted with a link) are listed, but not walked into
+			if ref, err := l.ExactMatch(p); err == nil && isRepository(p) {
+				location, err := w.locate(p, *ref)
+				if err != nil {
+					task.err = err
+					return
+				}
+				task.items = append(task.items, walkItem{location: location})
+			}
+			continue
+		}
+		ref, err := l.ExactMatch(p)
+		switch {
+		case errors.Is(err, workspace.ErrNotMatched):
+			if task.segments > 0 {
+				continue
+			}
+			if _, err := l.Match(p); !errors.Is(err, workspace.ErrNotMatched) {
+				// Ignore directories deeper than the layout
+				continue
+			}
+			task.items = append(task.items, walkItem{sub: w.spawn(l, p, 0)})
+		case err != nil:
+			task.err = err
+			return
+		case isRepository(p):
+			location, err := w.locate(p, *ref)
+			if err != nil {
+				task.err = err
+				return
+			}
+			task.items = append(task.items, walkItem{location: location})
+		case task.segments > 0:
+			if task.segments < repository.MaxOwnerSegments && repository.ValidateOwner(entry.Name()) == nil {
+				task.items = append(task.items, walkItem{sub: w.spawn(l, p, task.segments+1)})
+			}
+		default:
+			location, err := w.locate(p, *ref)
+			if err != nil {
+				task.err = err
+				return
+			}
+			task.items = append(task.items, walkItem{location: location, sub: w.spawn(l, p, 2)})
+		}
+	}
+}
+
+// locate builds the location of the found repository if it matches the options
+func (w *walker) locate(p string, ref repository.Reference) (*repository.Location, error) {
+	match, err := w.match(ref)
+	if err != nil || !match {
+		return nil, err
+	}
+	return repository.NewLocation(
+		p,
+		ref.Host(),
+		ref.Owner(),
+		ref.Name(),
+	), nil
+}
+
+// emit waits for the task and yields the found repositories in order.
+// It returns false if the yield requests to stop.
+func (w *walker) emit(task *walkTask, yield func(*repository.Location) bool) (bool, error) {
+	select {
+	case <-task.done:
+	case <-w.ctx.Done():
+		return false, w.ctx.Err()
+	}
+	if task.err != nil {
+		return false, task.err
+	}
+	for _, item := range task.items {
+		if item.sub != nil {
+			descend := true
+			if item.location != nil {
+				// The location is used only if no repository is found in the sub
+				var err error
+				if descend, err = w.found(item.sub); err != nil {
+					return false, err
+				}
+			}
+			if descend {
+				cont, err := w.emit(item.sub, yield)
+				if err != nil || !cont {
+					return cont, err
+				}
+				continue
+			}
+		}
+		if item.location != nil && !yield(item.location) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// found waits for the task and returns whether any repository is found in it,
+// regardless of the options.
+func (w *walker) found(task *walkTask) (bool, error) {
+	select {
+	case <-task.done:
+	case <-w.ctx.Done():
+		return false, w.ctx.Err()
+	}
+	if task.err != nil {
+		return false, task.err
+	}
+	for _, item := range task.items {
+		if item.sub == nil {
+			return true, nil
+		}
+		found, err := w.found(item.sub)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
@@ -2,10 +2,13 @@ package filesystem
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"slices"
 	"sync"
 
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/kyoh86/gogh/v4/core/repository"
 	"github.com/kyoh86/gogh/v4/core/workspace"
 )
 
@@ -13,6 +16,8 @@ import (
 type WorkspaceService struct {
 	roots       []workspace.Root
 	primaryRoot workspace.Root
+	layouts     map[workspace.Root]workspace.Layout
+	routes      []workspace.Route
 	changed     bool
 	mu          sync.RWMutex
 	// You might need a config file path or other storage mechanism
@@ -21,7 +26,8 @@ type WorkspaceService struct {
 // NewWorkspaceService creates a new instance of RootService
 func NewWorkspaceService() workspace.WorkspaceService {
 	return &WorkspaceService{
-		roots: []workspace.Root{},
+		roots:   []workspace.Root{},
+		layouts: map[workspace.Root]workspace.Layout{},
 	}
 }
 
@@ -44,14 +50,48 @@ func (s *WorkspaceService) GetPrimaryRoot() workspace.Root {
 
 // GetLayoutFor returns a Layout for the root
 func (s *WorkspaceService) GetLayoutFor(root workspace.Root) workspace.LayoutService {
-	return NewLayoutService(root)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewLayoutService(root, LayoutTemplate(s.layouts[root]))
 }
 
 // GetPrimaryLayout returns a Layout for the primary root
 func (s *WorkspaceService) GetPrimaryLayout() workspace.LayoutService {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return NewLayoutService(s.primaryRoot)
+	return NewLayoutService(s.primaryRoot, LayoutTemplate(s.layouts[s.primaryRoot]))
+}
+
+// GetRootLayout returns the layout declared for the root
+func (s *WorkspaceService) GetRootLayout(root workspace.Root) workspace.Layout {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.layouts[root]
+}
+
+// SetRootLayout declares the layout for the root
+func (s *WorkspaceService) SetRootLayout(root workspace.Root, layout workspace.Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.Contains(s.roots, absPath) {
+		return errors.New("specified path is not registered as a root")
+	}
+	if layout.IsDefault() {
+		delete(s.layouts, absPath)
+	} else {
+		s.layouts[absPath] = layout
+	}
+	s.changed = true
+	return nil
 }
 
 // SetPrimaryRoot sets the specified path as the primary workspace root
@@ -106,6 +146,10 @@ func (s *WorkspaceService) RemoveRoot(path workspace.Root) error {
 		if root == path {
 			// Remove from slice
 			s.roots = slices.Delete(s.roots, i, i+1)
+			delete(s.layouts, path)
+			s.routes = slices.DeleteFunc(s.routes, func(route workspace.Route) bool {
+				return route.Root == path
+			})
 
 			// If we removed the primary root, update it
 			if path == s.primaryRoot {
@@ -123,6 +167,77 @@ func (s *WorkspaceService) RemoveRoot(path workspace.Root) error {
 	return errors.New("root not found")
 }
 
+// GetRoutes returns all routes in the order of priority
+func (s *WorkspaceService) GetRoutes() []workspace.Route {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]workspace.Route, len(s.routes))
+	copy(result, s.routes)
+	return result
+}
+
+// AddRoute adds a route with the lowest priority
+func (s *WorkspaceService) AddRoute(route workspace.Route) error {
+	if !doublestar.ValidatePattern(route.Pattern) {
+		return fmt.Errorf("invalid pattern %q: %w", route.Pattern, doublestar.ErrBadPattern)
+	}
+	absPath, err := filepath.Abs(route.Root)
+	if err != nil {
+		return err
+	}
+	route.Root = absPath
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !slices.Contains(s.roots, absPath) {
+		return fmt.Errorf("%w: %s", workspace.ErrRootNotFound, absPath)
+	}
+	if slices.ContainsFunc(s.routes, func(r workspace.Route) bool { return r.Pattern == route.Pattern }) {
+		return fmt.Errorf("%w: %s", workspace.ErrRouteAlreadyExists, route.Pattern)
+	}
+	s.routes = append(s.routes, route)
+	s.changed = true
+	return nil
+}
+
+// RemoveRoute removes the route for the pattern
+func (s *WorkspaceService) RemoveRoute(pattern string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.routes, func(r workspace.Route) bool { return r.Pattern == pattern })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", workspace.ErrRouteNotFound, pattern)
+	}
+	s.routes = slices.Delete(s.routes, i, i+1)
+	s.changed = true
+	return nil
+}
+
+// GetLayoutForReference returns a Layout for the root which the first matching route points to.
+// Roots whose layout cannot hold the reference are skipped,
+// and it falls back to the primary root or the first root which can hold it.
+func (s *WorkspaceService) GetLayoutForReference(ref repository.Reference) workspace.LayoutService {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, route := range s.routes {
+		// Patterns are validated in AddRoute, so the error is never returned
+		if match, _ := doublestar.Match(route.Pattern, ref.String()); match && s.layouts[route.Root].CanHold(ref) {
+			return NewLayoutService(route.Root, LayoutTemplate(s.layouts[route.Root]))
+		}
+	}
+	for _, root := range append([]string{s.primaryRoot}, s.roots...) {
+		if s.layouts[root].CanHold(ref) {
+			return NewLayoutService(root, LayoutTemplate(s.layouts[root]))
+		}
+	}
+	// No root can hold it: the caller rejects it with LayoutService.CanHold
+	return NewLayoutService(s.primaryRoot, LayoutTemplate(s.layouts[s.primaryRoot]))
+}
+
 // HasChanges implements workspace.WorkspaceService.
 func (s *WorkspaceService) HasChanges() bool {
 	s.mu.RLock()
@@ -1,9 +1,12 @@
 package filesystem_test
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/workspace"
 	testtarget "github.com/kyoh86/gogh/v4/infra/filesystem"
 )
 
@@ -261,3 +264,146 @@ func TestGetPrimaryLayout(t *testing.T) {
 		t.Error("Expected non-nil primary layout")
 	}
 }
+
+func TestSetRootLayout(t *testing.T) {
+	service := testtarget.NewWorkspaceService()
+	root1, err := filepath.Abs("/test/path1")
+	if err != nil {
+		t.Fatalf("Error getting absolute path: %v", err)
+	}
+	_ = service.AddRoot(root1, true)
+	service.MarkSaved()
+
+	if err := service.SetRootLayout(root1, workspace.Layout{Template: "{host}/{name}"}); !errors.Is(err, workspace.ErrInvalidLayout) {
+		t.Errorf("Expected ErrInvalidLayout for the template without owner, got %v", err)
+	}
+	if err := service.SetRootLayout("/test/unknown", workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}); err == nil {
+		t.Error("Expected an error for an unregistered root")
+	}
+	if service.HasChanges() {
+		t.Error("Expected no changes after failed updates")
+	}
+
+	layout := workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}
+	if err := service.SetRootLayout(root1, layout); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !service.HasChanges() {
+		t.Error("Expected changes after setting layout")
+	}
+	if got := service.GetRootLayout(root1); got != layout {
+		t.Errorf("Expected layout %v, got %v", layout, got)
+	}
+	want := filepath.Join(root1, "kyoh86", "gogh")
+	if got := service.GetPrimaryLayout().PathFor(repository.NewReference("github.com", "kyoh86", "gogh")); got != want {
+		t.Errorf("Expected path %s, got %s", want, got)
+	}
+
+	if err := service.RemoveRoot(root1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := service.GetRootLayout(root1); !got.IsDefault() {
+		t.Errorf("Expected the layout to be removed with the root, got %v", got)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	service := testtarget.NewWorkspaceService()
+	primary, _ := filepath.Abs("/test/primary")
+	secure, _ := filepath.Abs("/test/secure")
+	oss, _ := filepath.Abs("/test/oss")
+	for _, root := range []string{primary, secure, oss} {
+		if err := service.AddRoot(root, root == primary); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := service.SetRootLayout(oss, workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := service.AddRoute(workspace.Route{Pattern: "github.com/our-org/**", Root: secure}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.AddRoute(workspace.Route{Pattern: "github.com/**", Root: oss}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.AddRoute(workspace.Route{Pattern: "github.com/**", Root: secure}); !errors.Is(err, workspace.ErrRouteAlreadyExists) {
+		t.Errorf("Expected ErrRouteAlreadyExists, got %v", err)
+	}
+	if err := service.AddRoute(workspace.Route{Pattern: "gitlab.com/**", Root: "/test/unknown"}); !errors.Is(err, workspace.ErrRootNotFound) {
+		t.Errorf("Expected ErrRootNotFound, got %v", err)
+	}
+	if err := service.AddRoute(workspace.Route{Pattern: "gitlab.com/[", Root: oss}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+
+	for _, tc := range []struct {
+		ref  repository.Reference
+		want string
+	}{
+		{ref: repository.NewReference("github.com", "our-org", "app"), want: filepath.Join(secure, "github.com", "our-org", "app")},
+		{ref: repository.NewReference("github.com", "kyoh86", "gogh"), want: filepath.Join(oss, "kyoh86", "gogh")},
+		{ref: repository.NewReference("gitlab.com", "kyoh86", "gogh"), want: filepath.Join(primary, "gitlab.com", "kyoh86", "gogh")},
+	} {
+		if got := service.GetLayoutForReference(tc.ref).PathFor(tc.ref); got != tc.want {
+			t.Errorf("Expected %s to be placed in %s, got %s", tc.ref, tc.want, got)
+		}
+	}
+
+	if err := service.RemoveRoute("github.com/our-org/**"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.RemoveRoute("github.com/our-org/**"); !errors.Is(err, workspace.ErrRouteNotFound) {
+		t.Errorf("Expected ErrRouteNotFound, got %v", err)
+	}
+	if err := service.RemoveRoot(oss); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if routes := service.GetRoutes(); len(routes) != 0 {
+		t.Errorf("Expected the routes to the removed root to be removed, got %v", routes)
+	}
+}
+
+func TestGetLayoutForReference_FixedLayout(t *testing.T) {
+	service := testtarget.NewWorkspaceService()
+	primary, _ := filepath.Abs("/test/primary")
+	other, _ := filepath.Abs("/test/other")
+	for _, root := range []string{primary, other} {
+		if err := service.AddRoot(root, root == primary); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := service.SetRootLayout(primary, workspace.Layout{Template: "{owner}/{name}", Host: "github.com"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.AddRoute(workspace.Route{Pattern: "gitlab.com/**", Root: primary}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		ref  repository.Reference
+		want string
+	}{
+		{ref: repository.NewReference("github.com", "a", "b"), want: filepath.Join(primary, "a", "b")},
+		// The route and the primary root cannot hold it, so it falls back to the other root
+		{ref: repository.NewReference("gitlab.com", "a", "b"), want: filepath.Join(other, "gitlab.com", "a", "b")},
+		{ref: repository.NewReference("example.com", "a", "b"), want: filepath.Join(other, "example.com", "a", "b")},
+	} {
+		layout := service.GetLayoutForReference(tc.ref)
+		if !layout.CanHold(tc.ref) {
+			t.Errorf("Expected a layout which can hold %s", tc.ref)
+		}
+		if got := layout.PathFor(tc.ref); got != tc.want {
+			t.Errorf("Expected %s to be placed in %s, got %s", tc.ref, tc.want, got)
+		}
+	}
+
+	// No root can hold it
+	if err := service.SetRootLayout(other, workspace.Layout{Template: "{name}", Host: "github.com", Owner: "kyoh86"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ref := repository.NewReference("gitlab.com", "a", "b")
+	if layout := service.GetLayoutForReference(ref); layout.CanHold(ref) {
+		t.Errorf("Expected no layout to hold %s, got %s", ref, layout.GetRoot())
+	}
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+)
+
+// defaultBranchRef finds the remote-tracking reference of the default branch of the remote.
+// It uses the HEAD of the remote (refs/remotes/<remote>/HEAD) set by the clone,
+// and falls back to "main" or "master".
+// It returns nil if it is not found.
+func defaultBranchRef(repo *git.Repository, remote string) *plumbing.Reference {
+	if head, err := repo.Reference(plumbing.NewRemoteHEADReferenceName(remote), true); err == nil {
+		return head
+	}
+	for _, name := range []string{"main", "master"} {
+		if ref, err := repo.Reference(plumbing.NewRemoteReferenceName(remote, name), true); err == nil {
+			return ref
+		}
+	}
+	return nil
+}
+
+// ListPrunableBranches lists the local branches which are merged into the default branch
+// or whose upstream is gone.
+func (s *GitService) ListPrunableBranches(_ context.Context, localPath string) ([]coregit.PrunableBranch, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, err
+	}
+	var current string
+	if head, err := repo.Reference(plumbing.HEAD, false); err == nil && head.Type() == plumbing.SymbolicReference {
+		current = head.Target().Short()
+	}
+	defaultRef := defaultBranchRef(repo, git.DefaultRemoteName)
+	var defaultName string
+	if defaultRef != nil {
+		defaultName = strings.TrimPrefix(defaultRef.Name().Short(), git.DefaultRemoteName+"/")
+	}
+
+	branches, err := repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	var prunable []coregit.PrunableBranch
+	if err := branches.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if name == current || name == defaultName {
+			return nil
+		}
+		if defaultRef != nil {
+			merged, err := isMerged(repo, ref.Hash(), defaultRef.Hash())
+			if err != nil {
+				return err
+			}
+			if merged {
+				prunable = append(prunable, coregit.PrunableBranch{Name: name, Reason: coregit.PruneReasonMerged})
+				return nil
+			}
+		}
+		b, ok := cfg.Branches[name]
+		if !ok || b.Remote == "" || b.Remote == "." || b.Merge == "" {
+			return nil
+		}
+		if _, err := repo.Reference(plumbing.NewRemoteReferenceName(b.Remote, b.Merge.Short()), true); errors.Is(err, plumbing.ErrReferenceNotFound) {
+			prunable = append(prunable, coregit.PrunableBranch{Name: name, Reason: coregit.PruneReasonUpstreamGone})
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	return prunable, nil
+}
+
+// isMerged checks whether the commit is reachable from the target.
+func isMerged(repo *git.Repository, commit, target plumbing.Hash) (bool, error) {
+	if commit == target {
+		return true, nil
+	}
+	c, err := repo.CommitObject(commit)
+	if err != nil {
+		return false, fmt.Errorf("getting commit %s: %w", commit, err)
+	}
+	t, err := repo.CommitObject(target)
+	if err != nil {
+		return false, fmt.Errorf("getting commit %s: %w", target, err)
+	}
+	return c.IsAncestor(t)
+}
+
+// DeleteBranch deletes the local branch and its configuration even if it is not merged.
+func (s *GitService) DeleteBranch(_ context.Context, localPath string, branch string) error {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return err
+	}
+	name := plumbing.NewBranchReferenceName(branch)
+	if _, err := repo.Reference(name, false); err != nil {
+		return fmt.Errorf("getting branch %s: %w", branch, err)
+	}
+	if err := repo.Storer.RemoveReference(name); err != nil {
+		return fmt.Errorf("deleting branch %s: %w", branch, err)
+	}
+	if err := repo.DeleteBranch(branch); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		return fmt.Errorf("deleting configuration of branch %s: %w", branch, err)
+	}
+	return nil
+}
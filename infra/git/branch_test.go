@@ -0,0 +1,92 @@
+package git_test
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+	testtarget "github.com/kyoh86/gogh/v4/infra/git"
+)
+
+func TestPruneBranches(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+	service := testtarget.NewService()
+
+	if _, err := git.PlainInit(sourceDir, false); err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	commitFile(t, sourceDir, "a.txt")
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	repo, err := git.PlainOpen(destDir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+
+	// "merged" is merged into the default branch
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("merged"), head.Hash())); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	// "wip" has a commit which is not merged and has no upstream
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("wip"), Create: true}); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	commitFile(t, destDir, "wip.txt")
+	// "gone" has a commit which is not merged and its upstream is deleted
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("gone"), Create: true}); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	commitFile(t, destDir, "gone.txt")
+	if err := repo.CreateBranch(&config.Branch{Name: "gone", Remote: "origin", Merge: plumbing.NewBranchReferenceName("gone")}); err != nil {
+		t.Fatalf("Failed to configure branch: %v", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")}); err != nil {
+		t.Fatalf("Failed to checkout: %v", err)
+	}
+
+	branches, err := service.ListPrunableBranches(ctx, destDir)
+	if err != nil {
+		t.Fatalf("Failed to list prunable branches: %v", err)
+	}
+	want := []coregit.PrunableBranch{
+		{Name: "gone", Reason: coregit.PruneReasonUpstreamGone},
+		{Name: "merged", Reason: coregit.PruneReasonMerged},
+	}
+	slices.SortFunc(branches, func(a, b coregit.PrunableBranch) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	if !slices.Equal(branches, want) {
+		t.Errorf("Expected %v, got %v", want, branches)
+	}
+
+	if err := service.DeleteBranch(ctx, destDir, "gone"); err != nil {
+		t.Fatalf("Failed to delete branch: %v", err)
+	}
+	if _, err := repo.Reference(plumbing.NewBranchReferenceName("gone"), false); err == nil {
+		t.Error("Expected the branch to be deleted")
+	}
+	if _, err := repo.Branch("gone"); err == nil {
+		t.Error("Expected the branch configuration to be deleted")
+	}
+	if err := service.DeleteBranch(ctx, destDir, "missing"); err == nil {
+		t.Error("Expected an error for a missing branch")
+	}
+}
@@ -13,10 +13,13 @@ import (
 
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	coregit "github.com/kyoh86/gogh/v4/core/git"
+	"golang.org/x/crypto/ssh"
 )
 
 type GitService struct {
@@ -42,7 +45,12 @@ func NewService(options ...Option) *GitService {
 }
 
 // AuthenticateWithUsernamePassword implements git.GitService.
+// If both of the username and the password are empty, the default authentication of the transport
+// (e.g. ssh-agent for SSH remotes) will be used.
 func (s *GitService) AuthenticateWithUsernamePassword(_ context.Context, username string, password string) (coregit.GitService, error) {
+	if username == "" && password == "" {
+		return &GitService{}, nil
+	}
 	return &GitService{
 		auth: &http.BasicAuth{
 			Username: username,
@@ -51,13 +59,83 @@ func (s *GitService) AuthenticateWithUsernamePassword(_ context.Context, usernam
 	}, nil
 }
 
+// AuthenticateWithSSH implements git.GitService.
+// The host keys are verified with the known_hosts files.
+func (s *GitService) AuthenticateWithSSH(_ context.Context, opts coregit.SSHAuthOptions) (coregit.GitService, error) {
+	user := opts.User
+	if user == "" {
+		user = "git"
+	}
+	hostKeyCallback, err := gitssh.NewKnownHostsCallback(opts.KnownHostsFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("loading known_hosts: %w", err)
+	}
+	if len(opts.KeyFiles) == 0 {
+		auth, err := gitssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to ssh-agent: %w", err)
+		}
+		auth.HostKeyCallback = hostKeyCallback
+		return &GitService{auth: auth}, nil
+	}
+	signers := make([]ssh.Signer, 0, len(opts.KeyFiles))
+	for _, keyFile := range opts.KeyFiles {
+		signer, err := loadSigner(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+	return &GitService{auth: &gitssh.PublicKeysCallback{
+		User:                  user,
+		Callback:              func() ([]ssh.Signer, error) { return signers, nil },
+		HostKeyCallbackHelper: gitssh.HostKeyCallbackHelper{HostKeyCallback: hostKeyCallback},
+	}}, nil
+}
+
+// loadSigner loads a private key file.
+// Keys protected with a passphrase are not supported: add them to the ssh-agent instead.
+func loadSigner(keyFile string) (ssh.Signer, error) {
+	if rest, ok := strings.CutPrefix(keyFile, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("search home directory: %w", err)
+		}
+		keyFile = filepath.Join(home, rest)
+	}
+	pem, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSH key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, fmt.Errorf("SSH key %s is protected with a passphrase; add it to the ssh-agent instead", keyFile)
+		}
+		return nil, fmt.Errorf("parsing SSH key %s: %w", keyFile, err)
+	}
+	return signer, nil
+}
+
 // Clone clones a remote repository to a local path.
+// go-git does not support the partial clone, so the opts.Filter is ignored.
 func (s *GitService) Clone(ctx context.Context, remoteURL string, localPath string, opts coregit.CloneOptions) error {
-	_, err := git.PlainCloneContext(ctx, localPath, false, &git.CloneOptions{
-		URL:      remoteURL,
-		Auth:     s.auth,
-		Progress: s.cloneProgressWriter,
-	})
+	cloneOpts := &git.CloneOptions{
+		URL:          remoteURL,
+		Auth:         s.auth,
+		Progress:     s.cloneProgressWriter,
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+	}
+	if opts.Branch != "" {
+		cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(opts.Branch)
+	}
+	if opts.RecurseSubmodules {
+		cloneOpts.RecurseSubmodules = git.DefaultSubmoduleRecursionDepth
+		cloneOpts.ShallowSubmodules = opts.Depth > 0
+	}
+	_, err := git.PlainCloneContext(ctx, localPath, false, cloneOpts)
 	switch {
 	case errors.Is(err, git.ErrRepositoryNotExists) || errors.Is(err, transport.ErrAuthenticationRequired) || errors.Is(err, transport.ErrAuthorizationFailed) || errors.Is(err, transport.ErrRepositoryNotFound):
 		return coregit.ErrRepositoryNotExists
@@ -67,6 +145,126 @@ func (s *GitService) Clone(ctx context.Context, remoteURL string, localPath stri
 	return err
 }
 
+func remoteNameOf(name string) string {
+	if name == "" {
+		return git.DefaultRemoteName
+	}
+	return name
+}
+
+// Fetch fetches the remote and returns whether any remote-tracking reference is updated.
+func (s *GitService) Fetch(ctx context.Context, localPath string, opts coregit.FetchOptions) (bool, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return false, err
+	}
+	switch err := repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteNameOf(opts.Remote),
+		Auth:       s.auth,
+	}); {
+	case errors.Is(err, git.NoErrAlreadyUpToDate):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// Pull fetches the remote and fast-forwards the current branch.
+// Untracked files are not regarded as uncommitted changes.
+func (s *GitService) Pull(ctx context.Context, localPath string, opts coregit.PullOptions) (bool, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return false, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return false, fmt.Errorf("getting status: %w", err)
+	}
+	for _, st := range status {
+		if st.Worktree == git.Untracked {
+			continue
+		}
+		if st.Worktree != git.Unmodified || st.Staging != git.Unmodified {
+			return false, coregit.ErrWorktreeDirty
+		}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return false, fmt.Errorf("getting HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return false, errors.New("HEAD is detached")
+	}
+
+	remoteName, mergeRef, configured, err := upstreamOf(repo, head.Name(), opts.Remote)
+	if err != nil {
+		return false, err
+	}
+	switch err := worktree.PullContext(ctx, &git.PullOptions{
+		RemoteName:    remoteName,
+		ReferenceName: mergeRef,
+		Auth:          s.auth,
+	}); {
+	case errors.Is(err, git.NoErrAlreadyUpToDate):
+		return false, nil
+	case !configured && errors.Is(err, plumbing.ErrReferenceNotFound):
+		// The local branch has never been pushed
+		return false, coregit.ErrNoUpstream
+	case errors.Is(err, git.ErrNonFastForwardUpdate):
+		// go-git regards the local branch ahead of the remote one as non-fast-forward
+		ahead, inErr := isAhead(repo, head, plumbing.NewRemoteReferenceName(remoteName, mergeRef.Short()))
+		if inErr != nil {
+			return false, inErr
+		}
+		if ahead {
+			return false, nil
+		}
+		return false, coregit.ErrDiverged
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// upstreamOf returns the remote and the remote branch which the branch is configured to track
+// ("branch.<name>.remote" and "branch.<name>.merge").
+// If the branch does not track one on the remote, it falls back to the same-named branch on the remote,
+// and the configured is false.
+func upstreamOf(repo *git.Repository, branch plumbing.ReferenceName, remote string) (string, plumbing.ReferenceName, bool, error) {
+	cfg, err := repo.Config()
+	if err != nil {
+		return "", "", false, fmt.Errorf("getting config: %w", err)
+	}
+	if b, ok := cfg.Branches[branch.Short()]; ok && b.Remote != "" && b.Merge != "" {
+		if remote == "" || remote == b.Remote {
+			return b.Remote, b.Merge, true, nil
+		}
+	}
+	return remoteNameOf(remote), branch, false, nil
+}
+
+// isAhead checks whether the remote reference is an ancestor of the HEAD.
+func isAhead(repo *git.Repository, head *plumbing.Reference, remoteRefName plumbing.ReferenceName) (bool, error) {
+	remoteRef, err := repo.Reference(remoteRefName, true)
+	if err != nil {
+		return false, fmt.Errorf("getting remote reference: %w", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return false, fmt.Errorf("getting HEAD commit: %w", err)
+	}
+	remoteCommit, err := repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return false, fmt.Errorf("getting remote commit: %w", err)
+	}
+	return remoteCommit.IsAncestor(headCommit)
+}
+
 // Init initializes a new git repository at the specified local path.
 func (s *GitService) Init(_ context.Context, remoteURL, localPath string, isBare bool, _ coregit.InitOptions) error {
 	repo, err := git.PlainInit(localPath, isBare)
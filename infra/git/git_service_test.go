@@ -2,7 +2,11 @@ package git_test
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -10,11 +14,15 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	git "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	coregit "github.com/kyoh86/gogh/v4/core/git"
 	testtarget "github.com/kyoh86/gogh/v4/infra/git"
+	"golang.org/x/crypto/ssh"
 )
 
 func pathToFileURL(path string) string {
@@ -59,6 +67,85 @@ func TestNewService(t *testing.T) {
 	}
 }
 
+// writeSSHFiles writes a private key file (protected with the passphrase if it is not empty)
+// and an empty known_hosts file into the directory.
+func writeSSHFiles(t *testing.T, dir, passphrase string) (string, string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	var block *pem.Block
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(priv, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(passphrase))
+	}
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	knownHosts := filepath.Join(dir, "known_hosts")
+	if err := os.WriteFile(knownHosts, nil, 0o600); err != nil {
+		t.Fatalf("Failed to write known_hosts: %v", err)
+	}
+	return keyFile, knownHosts
+}
+
+func TestAuthenticateWithSSH(t *testing.T) {
+	ctx := context.Background()
+	service := testtarget.NewService()
+
+	t.Run("key file", func(t *testing.T) {
+		keyFile, knownHosts := writeSSHFiles(t, t.TempDir(), "")
+		authenticatedService, err := service.AuthenticateWithSSH(ctx, coregit.SSHAuthOptions{
+			KeyFiles:        []string{keyFile},
+			KnownHostsFiles: []string{knownHosts},
+		})
+		if err != nil {
+			t.Fatalf("Failed to authenticate: %v", err)
+		}
+		if authenticatedService == nil {
+			t.Fatal("Expected non-nil authenticated service")
+		}
+	})
+
+	t.Run("key file protected with a passphrase", func(t *testing.T) {
+		keyFile, knownHosts := writeSSHFiles(t, t.TempDir(), "secret")
+		if _, err := service.AuthenticateWithSSH(ctx, coregit.SSHAuthOptions{
+			KeyFiles:        []string{keyFile},
+			KnownHostsFiles: []string{knownHosts},
+		}); err == nil || !strings.Contains(err.Error(), "ssh-agent") {
+			t.Errorf("Expected an error suggesting the ssh-agent, got %v", err)
+		}
+	})
+
+	t.Run("missing key file", func(t *testing.T) {
+		dir := t.TempDir()
+		_, knownHosts := writeSSHFiles(t, dir, "")
+		if _, err := service.AuthenticateWithSSH(ctx, coregit.SSHAuthOptions{
+			KeyFiles:        []string{filepath.Join(dir, "missing")},
+			KnownHostsFiles: []string{knownHosts},
+		}); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+
+	t.Run("missing known_hosts", func(t *testing.T) {
+		dir := t.TempDir()
+		keyFile, _ := writeSSHFiles(t, dir, "")
+		if _, err := service.AuthenticateWithSSH(ctx, coregit.SSHAuthOptions{
+			KeyFiles:        []string{keyFile},
+			KnownHostsFiles: []string{filepath.Join(dir, "missing")},
+		}); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
 func TestAuthenticateWithUsernamePassword(t *testing.T) {
 	ctx := context.Background()
 	service := testtarget.NewService()
@@ -116,6 +203,247 @@ func TestClone(t *testing.T) {
 	}
 }
 
+func TestCloneWithOptions(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+
+	// Prepare a source repository with some commits on "main" and a "feature" branch
+	source, err := git.PlainInit(sourceDir, false)
+	if err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	wt, err := source.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	for i, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(sourceDir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatalf("Failed to add file: %v", err)
+		}
+		if _, err := wt.Commit(fmt.Sprintf("commit %d", i), &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		}); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+	head, err := source.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	if err := source.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), head.Hash())); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+
+	destDir := filepath.Join(tempDir, "dest")
+	service := testtarget.NewService()
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{
+		Depth:        1,
+		Branch:       "feature",
+		SingleBranch: true,
+		Filter:       "blob:none",
+	}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+
+	dest, err := git.PlainOpen(destDir)
+	if err != nil {
+		t.Fatalf("Failed to open cloned repository: %v", err)
+	}
+	destHead, err := dest.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	if destHead.Name().Short() != "feature" {
+		t.Errorf("Expected to check out feature, got %s", destHead.Name())
+	}
+	shallow, err := dest.Storer.Shallow()
+	if err != nil {
+		t.Fatalf("Failed to get shallow commits: %v", err)
+	}
+	if len(shallow) != 1 || shallow[0] != head.Hash() {
+		t.Errorf("Expected a shallow clone at %s, got %v", head.Hash(), shallow)
+	}
+	refs, err := dest.References()
+	if err != nil {
+		t.Fatalf("Failed to get references: %v", err)
+	}
+	if err := refs.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Name().IsRemote() && ref.Name().Short() != "origin/feature" {
+			t.Errorf("Expected only the single branch to be fetched, got %s", ref.Name())
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to iterate references: %v", err)
+	}
+}
+
+// commitFile writes a file named the name and commits it to the repository in the dir.
+func commitFile(t *testing.T, dir, name string) {
+	t.Helper()
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if _, err := wt.Add(name); err != nil {
+		t.Fatalf("Failed to add file: %v", err)
+	}
+	if _, err := wt.Commit("add "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+}
+
+func TestFetchAndPull(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+
+	if _, err := git.PlainInit(sourceDir, false); err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	commitFile(t, sourceDir, "a.txt")
+
+	service := testtarget.NewService()
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+
+	t.Run("UpToDate", func(t *testing.T) {
+		if updated, err := service.Fetch(ctx, destDir, coregit.FetchOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+		if updated, err := service.Pull(ctx, destDir, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		commitFile(t, sourceDir, "b.txt")
+		if updated, err := service.Fetch(ctx, destDir, coregit.FetchOptions{}); err != nil || !updated {
+			t.Errorf("Expected the fetch to update, got %v, %v", updated, err)
+		}
+		if updated, err := service.Pull(ctx, destDir, coregit.PullOptions{}); err != nil || !updated {
+			t.Errorf("Expected the pull to update, got %v, %v", updated, err)
+		}
+		if _, err := os.Stat(filepath.Join(destDir, "b.txt")); err != nil {
+			t.Errorf("Expected b.txt to be pulled: %v", err)
+		}
+	})
+
+	t.Run("Ahead", func(t *testing.T) {
+		commitFile(t, destDir, "c.txt")
+		if updated, err := service.Pull(ctx, destDir, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("Diverged", func(t *testing.T) {
+		commitFile(t, sourceDir, "d.txt")
+		if _, err := service.Pull(ctx, destDir, coregit.PullOptions{}); !errors.Is(err, coregit.ErrDiverged) {
+			t.Errorf("Expected ErrDiverged, got %v", err)
+		}
+	})
+
+	t.Run("Dirty", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(destDir, "a.txt"), []byte("modified"), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := service.Pull(ctx, destDir, coregit.PullOptions{}); !errors.Is(err, coregit.ErrWorktreeDirty) {
+			t.Errorf("Expected ErrWorktreeDirty, got %v", err)
+		}
+	})
+}
+
+func TestPull_Upstream(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+
+	source, err := git.PlainInit(sourceDir, false)
+	if err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	commitFile(t, sourceDir, "a.txt")
+	head, err := source.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	if err := source.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), head.Hash())); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+
+	service := testtarget.NewService()
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	dest, err := git.PlainOpen(destDir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	checkout := func(t *testing.T, repo *git.Repository, branch string) {
+		t.Helper()
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatalf("Failed to get worktree: %v", err)
+		}
+		if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch)}); err != nil {
+			t.Fatalf("Failed to checkout %s: %v", branch, err)
+		}
+	}
+	branch := func(t *testing.T, repo *git.Repository, name string, hash plumbing.Hash) {
+		t.Helper()
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(name), hash)); err != nil {
+			t.Fatalf("Failed to create branch: %v", err)
+		}
+	}
+
+	t.Run("LocalOnlyBranch", func(t *testing.T) {
+		branch(t, dest, "local-only", head.Hash())
+		checkout(t, dest, "local-only")
+		if _, err := service.Pull(ctx, destDir, coregit.PullOptions{}); !errors.Is(err, coregit.ErrNoUpstream) {
+			t.Errorf("Expected ErrNoUpstream, got %v", err)
+		}
+	})
+
+	t.Run("DifferentlyNamedUpstream", func(t *testing.T) {
+		branch(t, dest, "topic", head.Hash())
+		if err := dest.CreateBranch(&config.Branch{
+			Name:   "topic",
+			Remote: "origin",
+			Merge:  plumbing.NewBranchReferenceName("feature"),
+		}); err != nil {
+			t.Fatalf("Failed to configure branch: %v", err)
+		}
+		checkout(t, dest, "topic")
+		checkout(t, source, "feature")
+		commitFile(t, sourceDir, "b.txt")
+
+		if updated, err := service.Pull(ctx, destDir, coregit.PullOptions{}); err != nil || !updated {
+			t.Fatalf("Expected the pull to update, got %v, %v", updated, err)
+		}
+		if _, err := os.Stat(filepath.Join(destDir, "b.txt")); err != nil {
+			t.Errorf("Expected b.txt to be pulled from the feature: %v", err)
+		}
+		if updated, err := service.Pull(ctx, destDir, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+}
+
 func TestInit(t *testing.T) {
 	ctx := context.Background()
 	tempDir := setupTempDir(t)
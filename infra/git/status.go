@@ -0,0 +1,247 @@
+package git
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+)
+
+// GetStatus retrieves the status of the local repository.
+func (s *GitService) GetStatus(_ context.Context, localPath string) (*coregit.Status, error) {
+	repo, err := git.PlainOpen(localPath)
+	if err != nil {
+		return nil, err
+	}
+	var status coregit.Status
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	files, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("getting worktree status: %w", err)
+	}
+	for _, st := range files {
+		switch {
+		case st.Worktree == git.Untracked:
+			status.Untracked = true
+		case st.Worktree != git.Unmodified || st.Staging != git.Unmodified:
+			status.Dirty = true
+		}
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, err
+	}
+	branches, err := repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	if err := branches.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		b, ok := cfg.Branches[name]
+		if !ok || b.Remote == "" || b.Merge == "" {
+			status.NoUpstreamBranches = append(status.NoUpstreamBranches, name)
+			return nil
+		}
+		upstream, err := repo.Reference(plumbing.NewRemoteReferenceName(b.Remote, b.Merge.Short()), true)
+		if err != nil {
+			// The upstream is not fetched yet or is gone
+			return nil
+		}
+		ahead, _, err := aheadBehind(repo, ref.Hash(), upstream.Hash())
+		if err != nil {
+			return fmt.Errorf("counting commits ahead of %s: %w", name, err)
+		}
+		if ahead > 0 {
+			if status.AheadBranches == nil {
+				status.AheadBranches = map[string]int{}
+			}
+			status.AheadBranches[name] = ahead
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("listing branches: %w", err)
+	}
+	slices.Sort(status.NoUpstreamBranches)
+
+	if status.Stashes, err = countStashes(repo); err != nil {
+		return nil, err
+	}
+
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return nil, fmt.Errorf("getting HEAD: %w", err)
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		status.Branch = head.Target().Short()
+	}
+	resolved, err := repo.Head()
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound):
+		// No commit yet
+		return &status, nil
+	case err != nil:
+		return nil, fmt.Errorf("getting HEAD: %w", err)
+	}
+	status.Head = resolved.Hash().String()
+	commit, err := repo.CommitObject(resolved.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting HEAD commit: %w", err)
+	}
+	status.HeadTime = commit.Committer.When
+
+	b, ok := cfg.Branches[status.Branch]
+	if status.Branch == "" || !ok || b.Remote == "" || b.Merge == "" {
+		return &status, nil
+	}
+	upstreamName := plumbing.NewRemoteReferenceName(b.Remote, b.Merge.Short())
+	status.Upstream = upstreamName.Short()
+	upstream, err := repo.Reference(upstreamName, true)
+	if err != nil {
+		// The upstream is not fetched yet or is gone
+		return &status, nil
+	}
+	if status.Ahead, status.Behind, err = aheadBehind(repo, resolved.Hash(), upstream.Hash()); err != nil {
+		return nil, fmt.Errorf("counting commits ahead and behind: %w", err)
+	}
+	return &status, nil
+}
+
+// countStashes counts the entries of the stash (the reflog of refs/stash).
+func countStashes(repo *git.Repository) (int, error) {
+	storage, ok := repo.Storer.(*filesystem.Storage)
+	if !ok {
+		return 0, nil
+	}
+	file, err := storage.Filesystem().Open("logs/refs/stash")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("reading stash: %w", err)
+	}
+	defer file.Close()
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			count++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("reading stash: %w", err)
+	}
+	return count, nil
+}
+
+const (
+	fromLocal = 1 << iota
+	fromUpstream
+
+	fromBoth = fromLocal | fromUpstream
+)
+
+// commitQueue is a priority queue of commits ordered by the committer time (newest first).
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int { return len(q) }
+func (q commitQueue) Less(i, j int) bool {
+	return q[i].Committer.When.After(q[j].Committer.When)
+}
+func (q commitQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any)   { *q = append(*q, x.(*object.Commit)) }
+func (q *commitQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// aheadBehind counts the commits reachable from only one of the local and the upstream.
+// Like git, it walks the commits from the newest ones and stops when all of the remaining ones
+// are reachable from both of them.
+func aheadBehind(repo *git.Repository, local, upstream plumbing.Hash) (int, int, error) {
+	if local == upstream {
+		return 0, 0, nil
+	}
+	// flags holds the sides each seen commit is reachable from
+	flags := map[plumbing.Hash]int{}
+	// queued holds the commits in the queue, and whether each of them is visited again
+	queued := map[plumbing.Hash]bool{}
+	queue := &commitQueue{}
+	// pending is the number of the queued commits which must be walked:
+	// the ones not reachable from both yet, and the visited ones which got a new flag
+	// (e.g. by a clock skew) to propagate it to their ancestors.
+	var pending int
+	push := func(hash plumbing.Hash, flag int) error {
+		prev, seen := flags[hash]
+		if prev&flag == flag {
+			return nil
+		}
+		flags[hash] = prev | flag
+		if revisit, ok := queued[hash]; ok {
+			// The queued commit propagates the new flag to its parents when it is popped
+			if !revisit && prev|flag == fromBoth {
+				pending--
+			}
+			return nil
+		}
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		heap.Push(queue, commit)
+		queued[hash] = seen
+		if seen || prev|flag != fromBoth {
+			pending++
+		}
+		return nil
+	}
+	if err := push(local, fromLocal); err != nil {
+		return 0, 0, err
+	}
+	if err := push(upstream, fromUpstream); err != nil {
+		return 0, 0, err
+	}
+	for pending > 0 {
+		current := heap.Pop(queue).(*object.Commit)
+		revisit := queued[current.Hash]
+		delete(queued, current.Hash)
+		flag := flags[current.Hash]
+		if revisit || flag != fromBoth {
+			pending--
+		}
+		for _, parent := range current.ParentHashes {
+			if err := push(parent, flag); err != nil {
+				if errors.Is(err, plumbing.ErrObjectNotFound) {
+					// Beyond the shallow boundary
+					continue
+				}
+				return 0, 0, err
+			}
+		}
+	}
+	var ahead, behind int
+	for _, flag := range flags {
+		switch flag {
+		case fromLocal:
+			ahead++
+		case fromUpstream:
+			behind++
+		}
+	}
+	return ahead, behind, nil
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+func TestAheadBehind(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		t.Fatalf("Failed to initialize repository: %v", err)
+	}
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(message string, minutes int, parents ...plumbing.Hash) plumbing.Hash {
+		t.Helper()
+		sig := object.Signature{Name: "test", Email: "test@example.com", When: base.Add(time.Duration(minutes) * time.Minute)}
+		c := &object.Commit{Author: sig, Committer: sig, Message: message, ParentHashes: parents}
+		obj := repo.Storer.NewEncodedObject()
+		if err := c.Encode(obj); err != nil {
+			t.Fatalf("Failed to encode commit: %v", err)
+		}
+		hash, err := repo.Storer.SetEncodedObject(obj)
+		if err != nil {
+			t.Fatalf("Failed to store commit: %v", err)
+		}
+		return hash
+	}
+
+	root := commit("root", 0)
+	fork := commit("fork", 10, root)
+	local1 := commit("local1", 20, fork)
+	local2 := commit("local2", 30, local1)
+	upstream1 := commit("upstream1", 25, fork)
+	merge := commit("merge", 40, local2, upstream1)
+	// The committer time of the upstream is older than all of the commits it is based on
+	skewed := commit("skewed", -5, local1)
+
+	for _, testcase := range []struct {
+		title           string
+		local, upstream plumbing.Hash
+		ahead, behind   int
+	}{
+		{title: "same", local: local2, upstream: local2},
+		{title: "diverged", local: local2, upstream: upstream1, ahead: 2, behind: 1},
+		{title: "ahead with a merge", local: merge, upstream: upstream1, ahead: 3},
+		{title: "behind with a merge", local: upstream1, upstream: merge, behind: 3},
+		{title: "clock skew", local: local2, upstream: skewed, ahead: 1, behind: 1},
+	} {
+		t.Run(testcase.title, func(t *testing.T) {
+			ahead, behind, err := aheadBehind(repo, testcase.local, testcase.upstream)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if ahead != testcase.ahead || behind != testcase.behind {
+				t.Errorf("Expected ahead %d and behind %d, got %d and %d", testcase.ahead, testcase.behind, ahead, behind)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package git_test
+
+import (
+	"context"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+	testtarget "github.com/kyoh86/gogh/v4/infra/git"
+)
+
+func TestGetStatus(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+	service := testtarget.NewService()
+
+	t.Run("NoCommit", func(t *testing.T) {
+		emptyDir := filepath.Join(tempDir, "empty")
+		if _, err := git.PlainInit(emptyDir, false); err != nil {
+			t.Fatalf("Failed to initialize repository: %v", err)
+		}
+		status, err := service.GetStatus(ctx, emptyDir)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Branch != "master" || status.Head != "" || !status.HeadTime.IsZero() || !status.Clean() {
+			t.Errorf("Unexpected status: %+v", status)
+		}
+	})
+
+	if _, err := git.PlainInit(sourceDir, false); err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	commitFile(t, sourceDir, "a.txt")
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+
+	t.Run("Clean", func(t *testing.T) {
+		status, err := service.GetStatus(ctx, destDir)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Branch != "master" || status.Upstream != "origin/master" || status.Head == "" || !status.Clean() {
+			t.Errorf("Unexpected status: %+v", status)
+		}
+		if time.Since(status.HeadTime) > time.Hour {
+			t.Errorf("Expected the time of the commit just made, got %v", status.HeadTime)
+		}
+	})
+
+	t.Run("AheadAndBehind", func(t *testing.T) {
+		commitFile(t, sourceDir, "b.txt")
+		commitFile(t, sourceDir, "c.txt")
+		commitFile(t, destDir, "d.txt")
+		if _, err := service.Fetch(ctx, destDir, coregit.FetchOptions{}); err != nil {
+			t.Fatalf("Failed to fetch: %v", err)
+		}
+		status, err := service.GetStatus(ctx, destDir)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Ahead != 1 || status.Behind != 2 {
+			t.Errorf("Expected ahead 1 and behind 2, got %+v", status)
+		}
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(destDir, "a.txt"), []byte("modified"), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(destDir, "new.txt"), []byte("new"), 0o644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Join(destDir, ".git", "logs", "refs"), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		stash := "0000000000000000000000000000000000000000 1111111111111111111111111111111111111111 test <test@example.com> 1700000000 +0000\tWIP on master\n"
+		if err := os.WriteFile(filepath.Join(destDir, ".git", "logs", "refs", "stash"), []byte(stash+stash), 0o644); err != nil {
+			t.Fatalf("Failed to write stash: %v", err)
+		}
+		repo, err := git.PlainOpen(destDir)
+		if err != nil {
+			t.Fatalf("Failed to open repository: %v", err)
+		}
+		head, err := repo.Head()
+		if err != nil {
+			t.Fatalf("Failed to get HEAD: %v", err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), head.Hash())); err != nil {
+			t.Fatalf("Failed to create branch: %v", err)
+		}
+
+		status, err := service.GetStatus(ctx, destDir)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if !status.Dirty || !status.Untracked || status.Stashes != 2 {
+			t.Errorf("Expected dirty, untracked and 2 stashes, got %+v", status)
+		}
+		if !slices.Equal(status.NoUpstreamBranches, []string{"feature"}) {
+			t.Errorf("Expected feature to have no upstream, got %v", status.NoUpstreamBranches)
+		}
+	})
+}
+
+func TestGetStatus_UnpushedBranch(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	sourceDir := filepath.Join(tempDir, "source")
+	destDir := filepath.Join(tempDir, "dest")
+	service := testtarget.NewService()
+
+	source, err := git.PlainInit(sourceDir, false)
+	if err != nil {
+		t.Fatalf("Failed to initialize source repository: %v", err)
+	}
+	commitFile(t, sourceDir, "a.txt")
+	sourceHead, err := source.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	if err := source.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), sourceHead.Hash())); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	if err := service.Clone(ctx, pathToFileURL(sourceDir), destDir, coregit.CloneOptions{}); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+
+	// Commit to "feature" tracking "origin/feature" and go back to "master" which is in sync
+	repo, err := git.PlainOpen(destDir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	if err := repo.CreateBranch(&config.Branch{Name: "feature", Remote: "origin", Merge: plumbing.NewBranchReferenceName("feature")}); err != nil {
+		t.Fatalf("Failed to configure branch: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Hash: sourceHead.Hash(), Create: true}); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+	commitFile(t, destDir, "b.txt")
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")}); err != nil {
+		t.Fatalf("Failed to checkout master: %v", err)
+	}
+
+	status, err := service.GetStatus(ctx, destDir)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if status.Branch != "master" || status.Ahead != 0 || len(status.NoUpstreamBranches) != 0 {
+		t.Errorf("Expected master to be in sync, got %+v", status)
+	}
+	if !maps.Equal(status.AheadBranches, map[string]int{"feature": 1}) {
+		t.Errorf("Expected feature to be ahead by 1, got %v", status.AheadBranches)
+	}
+	if status.Clean() {
+		t.Error("Expected the repository with an unpushed branch not to be clean")
+	}
+}
@@ -0,0 +1,58 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"golang.org/x/oauth2"
+)
+
+type Token = oauth2.Token
+
+// ErrDeviceFlowUnsupported is returned when the device authorization flow is requested for Gitea
+var ErrDeviceFlowUnsupported = errors.New("gitea does not support the device authorization flow; login with an access token instead")
+
+// AuthenticateService authenticates users on Gitea with access tokens
+type AuthenticateService struct {
+	baseURL func(host string) string
+}
+
+// AuthenticateOption configures the AuthenticateService
+type AuthenticateOption func(*AuthenticateService)
+
+// AuthenticateBaseURL sets a function to resolve the REST API base URL for a host
+var AuthenticateBaseURL = func(f func(host string) string) AuthenticateOption {
+	return func(s *AuthenticateService) {
+		s.baseURL = f
+	}
+}
+
+// NewAuthenticateService creates a new AuthenticateService instance
+func NewAuthenticateService(options ...AuthenticateOption) *AuthenticateService {
+	s := &AuthenticateService{baseURL: DefaultBaseURL}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// Authenticate always fails: Gitea and Forgejo do not provide the device authorization flow.
+func (s *AuthenticateService) Authenticate(context.Context, string, auth.Verify) (string, *Token, error) {
+	return "", nil, ErrDeviceFlowUnsupported
+}
+
+// AuthenticateWithToken verifies an access token and returns the login name of its owner.
+func (s *AuthenticateService) AuthenticateWithToken(ctx context.Context, host string, accessToken string) (string, *Token, error) {
+	token := &Token{AccessToken: accessToken, TokenType: "Bearer"}
+	c := getClient(ctx, s.baseURL(host), token)
+	var u user
+	if _, err := c.Do(ctx, http.MethodGet, "/user", nil, nil, &u); err != nil {
+		return "", nil, fmt.Errorf("getting authenticated user: %w", err)
+	}
+	return u.Login, token, nil
+}
+
+var _ auth.AuthenticateService = (*AuthenticateService)(nil)
@@ -0,0 +1,69 @@
+package gitea
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/infra/internal/restapi"
+)
+
+// ErrNotFound is returned when the Gitea API responds with 404 Not Found
+var ErrNotFound = restapi.ErrNotFound
+
+// DefaultBaseURL returns the REST API base URL for the Gitea host
+func DefaultBaseURL(host string) string {
+	return (&url.URL{Scheme: "https", Host: host, Path: "/api/v1"}).String()
+}
+
+// newClient creates a client for the Gitea (and Forgejo) REST API (v1)
+func newClient(baseURL string, httpClient *http.Client) *restapi.Client {
+	return restapi.NewClient("gitea", baseURL, httpClient)
+}
+
+// repoPath returns the API path for the repository
+func repoPath(owner, name string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+type user struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type organization struct {
+	ID int64 `json:"id"`
+	// Name is the login name of the organization (Gitea 1.20 or later)
+	Name string `json:"name"`
+	// UserName is the login name of the organization (deprecated in Gitea 1.20)
+	UserName string `json:"username"`
+}
+
+func (o *organization) login() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	return o.UserName
+}
+
+type repo struct {
+	ID            int64     `json:"id"`
+	Owner         user      `json:"owner"`
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"`
+	Description   string    `json:"description"`
+	Website       string    `json:"website"`
+	Language      string    `json:"language"`
+	HTMLURL       string    `json:"html_url"`
+	CloneURL      string    `json:"clone_url"`
+	SSHURL        string    `json:"ssh_url"`
+	DefaultBranch string    `json:"default_branch"`
+	Private       bool      `json:"private"`
+	Fork          bool      `json:"fork"`
+	Template      bool      `json:"template"`
+	Archived      bool      `json:"archived"`
+	Parent        *repo     `json:"parent"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	StarsCount    int       `json:"stars_count"`
+}
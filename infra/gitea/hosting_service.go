@@ -0,0 +1,549 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/infra/internal/restapi"
+	"github.com/kyoh86/gogh/v4/typ"
+	"golang.org/x/oauth2"
+)
+
+// HostingService is a hosting.HostingService for Gitea and Forgejo instances
+type HostingService struct {
+	tokenService       auth.TokenService
+	defaultNameService repository.DefaultNameService
+	knownOwners        map[string]string
+	knownOwnersMu      sync.Mutex
+	baseURL            func(host string) string
+}
+
+// RepoListMaxLimitPerPage is the default MAX_RESPONSE_ITEMS of Gitea
+const RepoListMaxLimitPerPage = 50
+
+// Option configures the HostingService
+type Option func(*HostingService)
+
+// BaseURL sets a function to resolve the REST API base URL (e.g.: "https://codeberg.org/api/v1") for a host
+var BaseURL = func(f func(host string) string) Option {
+	return func(s *HostingService) {
+		s.baseURL = f
+	}
+}
+
+// NewHostingService creates a new HostingService instance
+func NewHostingService(
+	tokenService auth.TokenService,
+	defaultNameService repository.DefaultNameService,
+	options ...Option,
+) *HostingService {
+	s := &HostingService{
+		tokenService:       tokenService,
+		defaultNameService: defaultNameService,
+		knownOwners:        map[string]string{},
+		baseURL:            DefaultBaseURL,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// getClient builds a client for the host.
+// Gitea issues access tokens which never expire, so the token is used as is.
+func getClient(ctx context.Context, baseURL string, token *auth.Token) *restapi.Client {
+	return newClient(baseURL, oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)))
+}
+
+func (s *HostingService) getClient(ctx context.Context, host string, token *auth.Token) *restapi.Client {
+	return getClient(ctx, s.baseURL(host), token)
+}
+
+// GetURLOf implements hosting.HostingService.
+func (s *HostingService) GetURLOf(ref repository.Reference) (*url.URL, error) {
+	return &url.URL{
+		Scheme: "https",
+		Host:   ref.Host(),
+		Path:   strings.Join([]string{ref.Owner(), ref.Name()}, "/"),
+	}, nil
+}
+
+// ParseURL implements hosting.HostingService.
+func (s *HostingService) ParseURL(u *url.URL) (*repository.Reference, error) {
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	words := strings.Split(path, "/")
+	if len(words) != 2 || words[0] == "" || words[1] == "" {
+		return nil, fmt.Errorf("invalid path: %q", u.Path)
+	}
+	return typ.Ptr(repository.NewReference(u.Host, words[0], words[1])), nil
+}
+
+var ErrTokenNotFound = errors.New("no token found")
+
+// knownOwner returns the owner of the token which is cached for the key ("<host>/<owner>").
+// The cache is guarded because GetTokenFor may be called concurrently (e.g. by pull and clone).
+func (s *HostingService) knownOwner(key string) (string, bool) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	tokenOwner, ok := s.knownOwners[key]
+	return tokenOwner, ok
+}
+
+// setKnownOwner caches the owner of the token for the key ("<host>/<owner>")
+func (s *HostingService) setKnownOwner(key, tokenOwner string) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	s.knownOwners[key] = tokenOwner
+}
+
+// GetTokenFor cache requested token for the host and owner
+func (s *HostingService) GetTokenFor(ctx context.Context, host, owner string) (string, auth.Token, error) {
+	key := strings.Join([]string{host, owner}, "/")
+	if tokenOwner, ok := s.knownOwner(key); ok {
+		_, token, err := s.getTokenForCore(ctx, host, tokenOwner)
+		return tokenOwner, token, err
+	}
+	tokenOwner, token, err := s.getTokenForCore(ctx, host, owner)
+	if err == nil {
+		s.setKnownOwner(key, tokenOwner)
+		return tokenOwner, token, nil
+	}
+	if !errors.Is(err, ErrTokenNotFound) {
+		return "", token, fmt.Errorf("getting token for %s/%s: %w", host, owner, err)
+	}
+	// If no token is found, use the default owner as the username
+	defaultOwner, err := s.defaultNameService.GetDefaultOwnerFor(host)
+	if err != nil {
+		return "", token, fmt.Errorf("getting default owner: %w", err)
+	}
+	tokenOwner, token, err = s.getTokenForCore(ctx, host, defaultOwner)
+	if err != nil {
+		return "", token, fmt.Errorf("getting default token: %w", err)
+	}
+	s.setKnownOwner(key, tokenOwner)
+	return tokenOwner, token, nil
+}
+
+func (s *HostingService) memberOf(ctx context.Context, owner string, entry auth.TokenEntry) bool {
+	c := s.getClient(ctx, entry.Host, &entry.Token)
+	var orgs []organization
+	if _, err := c.Do(ctx, http.MethodGet, "/user/orgs", url.Values{
+		"limit": {strconv.Itoa(RepoListMaxLimitPerPage)},
+	}, nil, &orgs); err != nil {
+		return false
+	}
+	for _, o := range orgs {
+		if strings.EqualFold(o.login(), owner) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *HostingService) getTokenForCore(ctx context.Context, host, owner string) (string, auth.Token, error) {
+	if s.tokenService.Has(host, owner) {
+		token, err := s.tokenService.Get(host, owner)
+		return owner, token, err
+	}
+
+	for _, entry := range s.tokenService.Entries() {
+		if entry.Host != host {
+			continue
+		}
+		if entry.Owner == owner {
+			return entry.Owner, entry.Token, nil
+		}
+
+		// Check if this user is a member of the target organization
+		if s.memberOf(ctx, owner, entry) {
+			return entry.Owner, entry.Token, nil
+		}
+	}
+
+	return "", auth.Token{}, ErrTokenNotFound
+}
+
+// GetRepository retrieves repository information from a remote source
+func (s *HostingService) GetRepository(ctx context.Context, reference repository.Reference) (*hosting.Repository, error) {
+	_, token, err := s.GetTokenFor(ctx, reference.Host(), reference.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
+	}
+	c := s.getClient(ctx, reference.Host(), &token)
+	var r repo
+	if _, err := c.Do(ctx, http.MethodGet, repoPath(reference.Owner(), reference.Name()), nil, nil, &r); err != nil {
+		return nil, fmt.Errorf("requesting repository: %w", err)
+	}
+	return typ.Ptr(convertRepo(reference.Host(), reference, &r)), nil
+}
+
+// buildSearchQuery converts hosting.ListRepositoryOptions to query parameters of "GET /repos/search"
+func buildSearchQuery(opts hosting.ListRepositoryOptions, uid int64, perPage int) (url.Values, error) {
+	query := url.Values{
+		"uid":     {strconv.FormatInt(uid, 10)},
+		"private": {"true"},
+		"limit":   {strconv.Itoa(perPage)},
+	}
+
+	var isPrivate string
+	if err := typ.Remap(&isPrivate, map[hosting.RepositoryPrivacy]string{
+		hosting.RepositoryPrivacyPublic:  "false",
+		hosting.RepositoryPrivacyPrivate: "true",
+	}, opts.Privacy); err != nil {
+		return nil, fmt.Errorf("invalid privacy option %q", opts.Privacy)
+	}
+	if isPrivate != "" {
+		query.Set("is_private", isPrivate)
+	}
+
+	sort := "updated"
+	if err := typ.Remap(&sort, map[hosting.RepositoryOrderField]string{
+		hosting.RepositoryOrderFieldCreatedAt:  "created",
+		hosting.RepositoryOrderFieldUpdatedAt:  "updated",
+		hosting.RepositoryOrderFieldPushedAt:   "updated",
+		hosting.RepositoryOrderFieldName:       "alpha",
+		hosting.RepositoryOrderFieldStargazers: "stars",
+	}, opts.OrderBy.Field); err != nil {
+		return nil, fmt.Errorf("invalid order field %q", opts.OrderBy.Field)
+	}
+	query.Set("sort", sort)
+
+	order := "desc"
+	if err := typ.Remap(&order, map[hosting.OrderDirection]string{
+		hosting.OrderDirectionAsc:  "asc",
+		hosting.OrderDirectionDesc: "desc",
+	}, opts.OrderBy.Direction); err != nil {
+		return nil, fmt.Errorf("invalid order direction %q", opts.OrderBy.Direction)
+	}
+	query.Set("order", order)
+
+	// Gitea cannot distinguish "collaborator" from "organization-member":
+	// both of them are covered by the non-exclusive search.
+	exclusive := len(opts.OwnerAffiliations) > 0
+	for _, aff := range opts.OwnerAffiliations {
+		switch aff {
+		case hosting.RepositoryAffiliationOwner:
+		case hosting.RepositoryAffiliationCollaborator, hosting.RepositoryAffiliationOrganizationMember:
+			exclusive = false
+		default:
+			return nil, fmt.Errorf("invalid owner affiliations %q", opts.OwnerAffiliations)
+		}
+	}
+	// The uid is the owner's one if it is set: it lists only the repositories owned by the owner
+	if exclusive || opts.Owner != "" {
+		query.Set("exclusive", "true")
+	}
+
+	isFork, err := opts.IsFork.AsBoolPtr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid isFork option %q: %w", opts.IsFork, err)
+	}
+	if isFork != nil {
+		if *isFork {
+			query.Set("mode", "fork")
+		} else {
+			query.Set("mode", "source")
+		}
+	}
+
+	isArchived, err := opts.IsArchived.AsBoolPtr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid isArchived option %q: %w", opts.IsArchived, err)
+	}
+	if isArchived != nil {
+		query.Set("archived", strconv.FormatBool(*isArchived))
+	}
+	return query, nil
+}
+
+// searchResult is a response body of "GET /repos/search"
+type searchResult struct {
+	Data []repo `json:"data"`
+}
+
+// ListRepository retrieves a list of repositories from a remote source
+func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+	return func(yield func(*hosting.Repository, error) bool) {
+		var perPage int
+		switch {
+		case opts.Limit == 0:
+			perPage = RepoListMaxLimitPerPage
+		case opts.Limit > RepoListMaxLimitPerPage:
+			perPage = RepoListMaxLimitPerPage
+		default:
+			perPage = opts.Limit
+		}
+
+		var count int
+		for _, entry := range s.tokenService.Entries() {
+			if opts.Host != "" && entry.Host != opts.Host {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if opts.Owner != "" {
+				// List the repositories of the owner only with the token which is used for the owner
+				tokenOwner, _, err := s.GetTokenFor(ctx, entry.Host, opts.Owner)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if tokenOwner != entry.Owner {
+					continue
+				}
+			}
+
+			c := s.getClient(ctx, entry.Host, &entry.Token)
+			var u user
+			if opts.Owner == "" {
+				if _, err := c.Do(ctx, http.MethodGet, "/user", nil, nil, &u); err != nil {
+					yield(nil, fmt.Errorf("requesting authenticated user: %w", err))
+					return
+				}
+			} else {
+				// Organizations are also found as users
+				_, err := c.Do(ctx, http.MethodGet, "/users/"+url.PathEscape(opts.Owner), nil, nil, &u)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					yield(nil, fmt.Errorf("requesting owner %q: %w", opts.Owner, err))
+					return
+				}
+			}
+			query, err := buildSearchQuery(opts, u.ID, perPage)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			// Gitea does not tell the next page in the "/repos/search": a page shorter than the limit is the last one
+			nextPage := func(_ *http.Response, current int, result searchResult) int {
+				if len(result.Data) < perPage {
+					return 0
+				}
+				return current + 1
+			}
+			for result, err := range restapi.Pages(ctx, c, "/repos/search", query, nextPage) {
+				if err != nil {
+					yield(nil, fmt.Errorf("requesting repositories: %w", err))
+					return
+				}
+				for _, r := range result.Data {
+					ref := repository.NewReference(entry.Host, r.Owner.Login, r.Name)
+					if !yield(typ.Ptr(convertRepo(entry.Host, ref, &r)), nil) {
+						return
+					}
+
+					count++
+					if opts.Limit > 0 && count >= opts.Limit {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+type createRepoRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	AutoInit    bool   `json:"auto_init,omitempty"`
+	Readme      string `json:"readme,omitempty"`
+	Gitignores  string `json:"gitignores,omitempty"`
+	License     string `json:"license,omitempty"`
+	Template    bool   `json:"template,omitempty"`
+}
+
+type editRepoRequest struct {
+	Website                       *string `json:"website,omitempty"`
+	HasIssues                     *bool   `json:"has_issues,omitempty"`
+	HasWiki                       *bool   `json:"has_wiki,omitempty"`
+	HasProjects                   *bool   `json:"has_projects,omitempty"`
+	HasReleases                   *bool   `json:"has_releases,omitempty"`
+	AllowMergeCommits             *bool   `json:"allow_merge_commits,omitempty"`
+	AllowRebase                   *bool   `json:"allow_rebase,omitempty"`
+	AllowSquashMerge              *bool   `json:"allow_squash_merge,omitempty"`
+	DefaultDeleteBranchAfterMerge *bool   `json:"default_delete_branch_after_merge,omitempty"`
+}
+
+// falseIf returns a pointer to false if b is true, or nil (means "default") if b is false.
+func falseIf(b bool) *bool {
+	if b {
+		return typ.Ptr(false)
+	}
+	return nil
+}
+
+// CreateRepository creates a new repository on Gitea.
+// Gitea cannot set the repository settings on creation, so they are set by an additional request.
+// Options that Gitea does not have (e.g. TeamID) are ignored.
+func (s *HostingService) CreateRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	opts hosting.CreateRepositoryOptions,
+) (*hosting.Repository, error) {
+	user, token, err := s.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	req := createRepoRequest{
+		Name:        ref.Name(),
+		Description: opts.Description,
+		Private:     opts.Private,
+		AutoInit:    opts.AutoInit,
+		Gitignores:  opts.GitignoreTemplate,
+		License:     opts.LicenseTemplate,
+		Template:    opts.IsTemplate,
+	}
+	if opts.AutoInit {
+		req.Readme = "Default"
+	}
+	path := "/user/repos"
+	if user != ref.Owner() {
+		path = "/orgs/" + url.PathEscape(ref.Owner()) + "/repos"
+	}
+	var r repo
+	if _, err := c.Do(ctx, http.MethodPost, path, nil, req, &r); err != nil {
+		return nil, fmt.Errorf("requesting new repository: %w", err)
+	}
+
+	edit := editRepoRequest{
+		Website:           typ.NilablePtr(opts.Homepage),
+		HasIssues:         falseIf(opts.DisableIssues),
+		HasWiki:           falseIf(opts.DisableWiki),
+		HasProjects:       falseIf(opts.DisableProjects),
+		HasReleases:       falseIf(opts.DisableDownloads),
+		AllowMergeCommits: falseIf(opts.PreventMergeCommit),
+		AllowRebase:       falseIf(opts.PreventRebaseMerge),
+		AllowSquashMerge:  falseIf(opts.PreventSquashMerge),
+	}
+	if opts.DeleteBranchOnMerge {
+		edit.DefaultDeleteBranchAfterMerge = typ.Ptr(true)
+	}
+	if edit != (editRepoRequest{}) {
+		if _, err := c.Do(ctx, http.MethodPatch, repoPath(r.Owner.Login, r.Name), nil, edit, &r); err != nil {
+			return nil, fmt.Errorf("requesting to edit new repository: %w", err)
+		}
+	}
+	return typ.Ptr(convertRepo(ref.Host(), ref, &r)), nil
+}
+
+type generateRepoRequest struct {
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	GitContent  bool   `json:"git_content"`
+	Topics      bool   `json:"topics"`
+	Labels      bool   `json:"labels"`
+}
+
+// CreateRepositoryFromTemplate generates a new repository from a template repository.
+// Gitea copies only the default branch, so IncludeAllBranches is ignored.
+func (s *HostingService) CreateRepositoryFromTemplate(
+	ctx context.Context,
+	ref repository.Reference,
+	tmp repository.Reference,
+	opts hosting.CreateRepositoryFromTemplateOptions,
+) (*hosting.Repository, error) {
+	_, token, err := s.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	req := generateRepoRequest{
+		Owner:       ref.Owner(),
+		Name:        ref.Name(),
+		Description: opts.Description,
+		Private:     opts.Private,
+		GitContent:  true,
+		Topics:      true,
+		Labels:      true,
+	}
+	var r repo
+	if _, err := c.Do(ctx, http.MethodPost, repoPath(tmp.Owner(), tmp.Name())+"/generate", nil, req, &r); err != nil {
+		return nil, fmt.Errorf("requesting new repository from template: %w", err)
+	}
+	return typ.Ptr(convertRepo(ref.Host(), ref, &r)), nil
+}
+
+// DeleteRepository deletes a repository from a remote source
+func (s *HostingService) DeleteRepository(ctx context.Context, reference repository.Reference) error {
+	_, token, err := s.GetTokenFor(ctx, reference.Host(), reference.Owner())
+	if err != nil {
+		return fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
+	}
+	c := s.getClient(ctx, reference.Host(), &token)
+	if _, err := c.Do(ctx, http.MethodDelete, repoPath(reference.Owner(), reference.Name()), nil, nil, nil); err != nil {
+		return fmt.Errorf("requesting to delete repository: %w", err)
+	}
+	return nil
+}
+
+type forkRepoRequest struct {
+	Name         string `json:"name,omitempty"`
+	Organization string `json:"organization,omitempty"`
+}
+
+// ForkRepository implements hosting.HostingService.
+// Gitea forks all branches, so DefaultBranchOnly is ignored.
+func (s *HostingService) ForkRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	target repository.Reference,
+	_ hosting.ForkRepositoryOptions,
+) (*hosting.Repository, error) {
+	user, token, err := s.GetTokenFor(ctx, target.Host(), target.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", target.Host(), target.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	req := forkRepoRequest{Name: target.Name()}
+	if user != target.Owner() {
+		req.Organization = target.Owner()
+	}
+	var fork repo
+	if _, err := c.Do(ctx, http.MethodPost, repoPath(ref.Owner(), ref.Name())+"/forks", nil, req, &fork); err != nil {
+		return nil, fmt.Errorf("requesting fork: %w", err)
+	}
+	return typ.Ptr(convertRepo(target.Host(), target, &fork)), nil
+}
+
+func convertRepo(host string, ref repository.Reference, r *repo) hosting.Repository {
+	result := hosting.Repository{
+		Ref:         ref,
+		URL:         r.HTMLURL,
+		CloneURL:    r.CloneURL,
+		UpdatedAt:   r.UpdatedAt,
+		Description: r.Description,
+		Homepage:    r.Website,
+		Language:    r.Language,
+		Archived:    r.Archived,
+		Private:     r.Private,
+		IsTemplate:  r.Template,
+		Fork:        r.Fork,
+	}
+	if parent := r.Parent; parent != nil {
+		result.Parent = &hosting.ParentRepository{
+			Ref:      repository.NewReference(host, parent.Owner.Login, parent.Name),
+			CloneURL: parent.CloneURL,
+		}
+	}
+	return result
+}
+
+var _ hosting.HostingService = (*HostingService)(nil)
@@ -0,0 +1,469 @@
+package gitea_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/auth_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/repository_mock"
+	testtarget "github.com/kyoh86/gogh/v4/infra/gitea"
+	"github.com/kyoh86/gogh/v4/typ"
+	"go.uber.org/mock/gomock"
+)
+
+const testHost = "gitea.example.com"
+
+// setupHostingServiceTest starts a fake Gitea API server with the handler
+// and creates a HostingService which has a token for "kyoh86" on the testHost.
+func setupHostingServiceTest(t *testing.T, handler http.Handler) *testtarget.HostingService {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Has(testHost, "kyoh86").Return(true).AnyTimes()
+	tokenService.EXPECT().Has(testHost, gomock.Any()).Return(false).AnyTimes()
+	tokenService.EXPECT().Get(testHost, "kyoh86").Return(auth.Token{AccessToken: "dummy-token"}, nil).AnyTimes()
+	tokenService.EXPECT().Entries().Return([]auth.TokenEntry{
+		{Host: testHost, Owner: "kyoh86", Token: auth.Token{AccessToken: "dummy-token"}},
+	}).AnyTimes()
+	defaultNameService := repository_mock.NewMockDefaultNameService(ctrl)
+	defaultNameService.EXPECT().GetDefaultOwnerFor(testHost).Return("kyoh86", nil).AnyTimes()
+
+	return testtarget.NewHostingService(
+		tokenService,
+		defaultNameService,
+		testtarget.BaseURL(func(string) string { return server.URL + "/api/v1" }),
+	)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func repoJSON(owner, name string, fork bool) map[string]any {
+	r := map[string]any{
+		"id":             1,
+		"owner":          map[string]any{"login": owner},
+		"name":           name,
+		"full_name":      owner + "/" + name,
+		"description":    "description of " + name,
+		"html_url":       "https://" + testHost + "/" + owner + "/" + name,
+		"clone_url":      "https://" + testHost + "/" + owner + "/" + name + ".git",
+		"default_branch": "main",
+		"private":        true,
+		"fork":           fork,
+		"updated_at":     "2025-01-02T03:04:05Z",
+	}
+	if fork {
+		r["parent"] = repoJSON("upstream", name, false)
+	}
+	return r
+}
+
+func decodeBody(t *testing.T, r *http.Request) map[string]any {
+	t.Helper()
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		t.Errorf("decoding request: %v", err)
+	}
+	return body
+}
+
+func TestParseURL(t *testing.T) {
+	service := testtarget.NewHostingService(nil, nil)
+	testCases := []struct {
+		name    string
+		url     string
+		want    repository.Reference
+		wantErr bool
+	}{
+		{
+			name: "web URL",
+			url:  "https://codeberg.org/kyoh86/gogh",
+			want: repository.NewReference("codeberg.org", "kyoh86", "gogh"),
+		},
+		{
+			name: "clone URL",
+			url:  "https://codeberg.org/kyoh86/gogh.git",
+			want: repository.NewReference("codeberg.org", "kyoh86", "gogh"),
+		},
+		{
+			name:    "too deep",
+			url:     "https://codeberg.org/kyoh86/gogh/src/branch/main",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatalf("parsing URL: %v", err)
+			}
+			ref, err := service.ParseURL(u)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *ref != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, *ref)
+			}
+		})
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/repos/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer dummy-token" {
+			t.Errorf("unexpected Authorization header: %q", got)
+		}
+		if r.PathValue("name") != "gogh" {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(t, w, map[string]any{"message": "The target couldn't be found."})
+			return
+		}
+		writeJSON(t, w, repoJSON(r.PathValue("owner"), r.PathValue("name"), true))
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		repo, err := service.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "gogh"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.CloneURL != "https://"+testHost+"/kyoh86/gogh.git" {
+			t.Errorf("unexpected clone URL: %q", repo.CloneURL)
+		}
+		if !repo.Fork || repo.Parent == nil {
+			t.Fatal("expected fork repository with parent")
+		}
+		if want := repository.NewReference(testHost, "upstream", "gogh"); repo.Parent.Ref != want {
+			t.Errorf("expected parent %v, got %v", want, repo.Parent.Ref)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := service.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "missing"))
+		if !errors.Is(err, testtarget.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestListRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"id": 7, "login": "kyoh86"})
+	})
+	var queries []url.Values
+	mux.HandleFunc("GET /api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query)
+		if got := query.Get("uid"); got != "7" {
+			t.Errorf("expected uid=7, got %q", got)
+		}
+		switch query.Get("page") {
+		case "1":
+			data := make([]any, 0, testtarget.RepoListMaxLimitPerPage)
+			for range testtarget.RepoListMaxLimitPerPage {
+				data = append(data, repoJSON("kyoh86", "repo", false))
+			}
+			writeJSON(t, w, map[string]any{"ok": true, "data": data})
+		case "2":
+			writeJSON(t, w, map[string]any{"ok": true, "data": []any{repoJSON("org", "last", false)}})
+		default:
+			t.Errorf("unexpected page %q", query.Get("page"))
+		}
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	t.Run("all pages", func(t *testing.T) {
+		queries = nil
+		var count int
+		var last *hosting.Repository
+		for repo, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+			last = repo
+		}
+		if count != testtarget.RepoListMaxLimitPerPage+1 {
+			t.Errorf("expected %d repositories, got %d", testtarget.RepoListMaxLimitPerPage+1, count)
+		}
+		if want := repository.NewReference(testHost, "org", "last"); last == nil || last.Ref != want {
+			t.Errorf("expected last repository %v, got %v", want, last)
+		}
+	})
+
+	t.Run("filtered and limited", func(t *testing.T) {
+		queries = nil
+		var count int
+		for _, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{
+			Limit:             3,
+			Privacy:           hosting.RepositoryPrivacyPublic,
+			OwnerAffiliations: []hosting.RepositoryAffiliation{hosting.RepositoryAffiliationOwner},
+			IsFork:            typ.TristateFalse,
+			IsArchived:        typ.TristateTrue,
+			OrderBy: hosting.RepositoryOrder{
+				Field:     hosting.RepositoryOrderFieldName,
+				Direction: hosting.OrderDirectionAsc,
+			},
+		}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+		}
+		if count != 3 {
+			t.Errorf("expected 3 repositories, got %d", count)
+		}
+		if len(queries) != 1 {
+			t.Fatalf("expected 1 request, got %d", len(queries))
+		}
+		for key, want := range map[string]string{
+			"limit":      "3",
+			"is_private": "false",
+			"exclusive":  "true",
+			"mode":       "source",
+			"archived":   "true",
+			"sort":       "alpha",
+			"order":      "asc",
+		} {
+			if got := queries[0].Get(key); got != want {
+				t.Errorf("expected %s=%s, got %q", key, want, got)
+			}
+		}
+	})
+}
+
+func TestListRepositoryOfOwner(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/users/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") != "our-org" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(t, w, map[string]any{"id": 9, "login": "our-org"})
+	})
+	mux.HandleFunc("GET /api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("uid"); got != "9" {
+			t.Errorf("expected uid=9, got %q", got)
+		}
+		if got := query.Get("exclusive"); got != "true" {
+			t.Errorf("expected exclusive=true, got %q", got)
+		}
+		writeJSON(t, w, map[string]any{"ok": true, "data": []any{repoJSON("our-org", "svc", false)}})
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		owner string
+		want  []repository.Reference
+	}{
+		{owner: "our-org", want: []repository.Reference{repository.NewReference(testHost, "our-org", "svc")}},
+		{owner: "missing", want: nil},
+	} {
+		t.Run(tc.owner, func(t *testing.T) {
+			var got []repository.Reference
+			for repo, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{Owner: tc.owner}) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, repo.Ref)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateRepository(t *testing.T) {
+	var created, edited map[string]any
+	var createdPath string
+	mux := http.NewServeMux()
+	createHandler := func(w http.ResponseWriter, r *http.Request) {
+		createdPath = r.URL.Path
+		created = decodeBody(t, r)
+		owner := r.PathValue("org")
+		if owner == "" {
+			owner = "kyoh86"
+		}
+		writeJSON(t, w, repoJSON(owner, created["name"].(string), false))
+	}
+	mux.HandleFunc("POST /api/v1/user/repos", createHandler)
+	mux.HandleFunc("POST /api/v1/orgs/{org}/repos", createHandler)
+	mux.HandleFunc("PATCH /api/v1/repos/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		edited = decodeBody(t, r)
+		writeJSON(t, w, repoJSON(r.PathValue("owner"), r.PathValue("name"), false))
+	})
+	mux.HandleFunc("GET /api/v1/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []any{map[string]any{"id": 3, "username": "my-org"}})
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	t.Run("for user with settings", func(t *testing.T) {
+		created, edited = nil, nil
+		if _, err := service.CreateRepository(ctx, repository.NewReference(testHost, "kyoh86", "new"), hosting.CreateRepositoryOptions{
+			Private:       true,
+			AutoInit:      true,
+			DisableIssues: true,
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if createdPath != "/api/v1/user/repos" {
+			t.Errorf("unexpected path %q", createdPath)
+		}
+		if created["private"] != true || created["readme"] != "Default" {
+			t.Errorf("unexpected create request: %v", created)
+		}
+		if edited == nil {
+			t.Fatal("expected edit request")
+		}
+		if edited["has_issues"] != false {
+			t.Errorf("expected has_issues false, got %v", edited["has_issues"])
+		}
+		if _, ok := edited["has_wiki"]; ok {
+			t.Errorf("expected no has_wiki, got %v", edited["has_wiki"])
+		}
+	})
+
+	t.Run("for organization without settings", func(t *testing.T) {
+		created, edited = nil, nil
+		if _, err := service.CreateRepository(ctx, repository.NewReference(testHost, "my-org", "new"), hosting.CreateRepositoryOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if createdPath != "/api/v1/orgs/my-org/repos" {
+			t.Errorf("unexpected path %q", createdPath)
+		}
+		if edited != nil {
+			t.Errorf("expected no edit request, got %v", edited)
+		}
+	})
+}
+
+func TestCreateRepositoryFromTemplate(t *testing.T) {
+	var got map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/repos/{owner}/{name}/generate", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("owner") != "tmpl-owner" || r.PathValue("name") != "tmpl" {
+			t.Errorf("unexpected template %s/%s", r.PathValue("owner"), r.PathValue("name"))
+		}
+		got = decodeBody(t, r)
+		writeJSON(t, w, repoJSON(got["owner"].(string), got["name"].(string), false))
+	})
+	service := setupHostingServiceTest(t, mux)
+
+	repo, err := service.CreateRepositoryFromTemplate(
+		context.Background(),
+		repository.NewReference(testHost, "kyoh86", "new"),
+		repository.NewReference(testHost, "tmpl-owner", "tmpl"),
+		hosting.CreateRepositoryFromTemplateOptions{Private: true},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["owner"] != "kyoh86" || got["git_content"] != true || got["private"] != true {
+		t.Errorf("unexpected generate request: %v", got)
+	}
+	if want := repository.NewReference(testHost, "kyoh86", "new"); repo.Ref != want {
+		t.Errorf("expected %v, got %v", want, repo.Ref)
+	}
+}
+
+func TestForkRepository(t *testing.T) {
+	var got map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/repos/{owner}/{name}/forks", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("owner") != "upstream" || r.PathValue("name") != "gogh" {
+			t.Errorf("unexpected source %s/%s", r.PathValue("owner"), r.PathValue("name"))
+		}
+		got = decodeBody(t, r)
+		w.WriteHeader(http.StatusAccepted)
+		writeJSON(t, w, repoJSON("kyoh86", got["name"].(string), true))
+	})
+	service := setupHostingServiceTest(t, mux)
+
+	repo, err := service.ForkRepository(
+		context.Background(),
+		repository.NewReference(testHost, "upstream", "gogh"),
+		repository.NewReference(testHost, "kyoh86", "gogh-fork"),
+		hosting.ForkRepositoryOptions{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["name"] != "gogh-fork" {
+		t.Errorf("expected name gogh-fork, got %v", got["name"])
+	}
+	if _, ok := got["organization"]; ok {
+		t.Errorf("expected no organization, got %v", got["organization"])
+	}
+	if !repo.Fork {
+		t.Error("expected fork repository")
+	}
+}
+
+func TestDeleteRepository(t *testing.T) {
+	var deleted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/v1/repos/{owner}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.PathValue("owner") + "/" + r.PathValue("name")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	service := setupHostingServiceTest(t, mux)
+
+	if err := service.DeleteRepository(context.Background(), repository.NewReference(testHost, "kyoh86", "gogh")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != "kyoh86/gogh" {
+		t.Errorf("expected to delete kyoh86/gogh, got %q", deleted)
+	}
+}
+
+func TestGetTokenForConcurrently(t *testing.T) {
+	service := setupHostingServiceTest(t, http.NewServeMux())
+	ctx := context.Background()
+
+	// Concurrent calls (e.g. from the workers of pull) share the cache safely
+	var wg sync.WaitGroup
+	for range 32 {
+		wg.Go(func() {
+			tokenOwner, _, err := service.GetTokenFor(ctx, testHost, "kyoh86")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tokenOwner != "kyoh86" {
+				t.Errorf("expected the token of kyoh86, got %q", tokenOwner)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,644 @@
+package gitexec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/safeexec"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/kballard/go-shellquote"
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+)
+
+// DefaultRemoteName is the name of the default remote
+const DefaultRemoteName = "origin"
+
+// credentialHelper passes the username and the password from the environment variables to git.
+// They are never put in the command line to keep them out of the process list.
+const credentialHelper = `!f() { test "$1" = get || exit 0; echo "username=${GOGH_GIT_USERNAME}"; echo "password=${GOGH_GIT_PASSWORD}"; }; f`
+
+// GitService is a git.GitService which runs the git command installed in the system.
+// It supports the features which go-git lacks: the partial clone, LFS, credential helpers
+// and the SSH configurations (~/.ssh/config).
+type GitService struct {
+	exe                 string
+	args                []string
+	env                 []string
+	cloneProgressWriter io.Writer
+}
+
+// Option configures the GitService
+type Option func(*GitService)
+
+// CloneProgressWriter sets a writer to show the progress of the clone
+var CloneProgressWriter = func(w io.Writer) Option {
+	return func(s *GitService) {
+		s.cloneProgressWriter = w
+	}
+}
+
+// Executable sets the path of the git command instead of the one found in the PATH
+var Executable = func(exe string) Option {
+	return func(s *GitService) {
+		s.exe = exe
+	}
+}
+
+// NewService creates a new GitService instance without authentication.
+// It searches the git command in the PATH.
+func NewService(options ...Option) (*GitService, error) {
+	s := &GitService{}
+	for _, opt := range options {
+		opt(s)
+	}
+	if s.exe == "" {
+		exe, err := safeexec.LookPath("git")
+		if err != nil {
+			return nil, fmt.Errorf("search git command: %w", err)
+		}
+		s.exe = exe
+	}
+	return s, nil
+}
+
+func (s *GitService) with(args []string, env ...string) *GitService {
+	return &GitService{
+		exe:                 s.exe,
+		args:                append(append([]string{}, s.args...), args...),
+		env:                 append(append([]string{}, s.env...), env...),
+		cloneProgressWriter: s.cloneProgressWriter,
+	}
+}
+
+// AuthenticateWithUsernamePassword implements git.GitService.
+// The credential helpers configured by the user take priority over the username and the password.
+// If both of them are empty, only the credential helpers configured by the user will be used.
+func (s *GitService) AuthenticateWithUsernamePassword(_ context.Context, username string, password string) (coregit.GitService, error) {
+	if username == "" && password == "" {
+		return s.with(nil), nil
+	}
+	return s.with(
+		[]string{"-c", "credential.helper=" + credentialHelper},
+		"GOGH_GIT_USERNAME="+username,
+		"GOGH_GIT_PASSWORD="+password,
+	), nil
+}
+
+// AuthenticateWithSSH implements git.GitService.
+// The ssh command reads ~/.ssh/config and uses the ssh-agent as usual,
+// and verifies the host keys with the known_hosts files.
+func (s *GitService) AuthenticateWithSSH(_ context.Context, opts coregit.SSHAuthOptions) (coregit.GitService, error) {
+	if opts.User == "" && len(opts.KeyFiles) == 0 && len(opts.KnownHostsFiles) == 0 {
+		return s.with(nil), nil
+	}
+	command := []string{"ssh"}
+	if opts.User != "" {
+		command = append(command, "-o", "User="+opts.User)
+	}
+	for _, keyFile := range opts.KeyFiles {
+		command = append(command, "-i", keyFile, "-o", "IdentitiesOnly=yes")
+	}
+	if len(opts.KnownHostsFiles) > 0 {
+		command = append(command, "-o", "UserKnownHostsFile="+strings.Join(opts.KnownHostsFiles, " "), "-o", "StrictHostKeyChecking=yes")
+	}
+	return s.with(nil, "GIT_SSH_COMMAND="+shellquote.Join(command...)), nil
+}
+
+// run runs the git command and returns its standard output.
+// The standard error is returned in the error if the command fails.
+func (s *GitService) run(ctx context.Context, stderr io.Writer, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, s.exe, append(append([]string{}, s.args...), args...)...)
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), s.env...)
+	var stdout, errBuf bytes.Buffer
+	cmd.Stdout = &stdout
+	if stderr == nil {
+		cmd.Stderr = &errBuf
+	} else {
+		cmd.Stderr = io.MultiWriter(&errBuf, stderr)
+	}
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &Error{Args: args, Stderr: strings.TrimSpace(errBuf.String()), Err: err}
+	}
+	return stdout.Bytes(), nil
+}
+
+// Error is an error of the git command
+type Error struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("git %s: %s", strings.Join(e.Args, " "), e.Err)
+	}
+	return fmt.Sprintf("git %s: %s: %s", strings.Join(e.Args, " "), e.Err, e.Stderr)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of the git command, or -1 if it is not exited
+func (e *Error) ExitCode() int {
+	var exitErr *exec.ExitError
+	if errors.As(e.Err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// notExistsPattern matches the messages of git or the hosting services when the remote repository does not exist
+// or cannot be accessed. Like go-git, authentication failures are treated as not existing,
+// because the hosting services hide private repositories from unauthorized users.
+// Other failures (e.g. "Remote branch foo not found in upstream origin") must not match,
+// because the caller retries to clone a repository which does not exist yet.
+var notExistsPattern = regexp.MustCompile(`(?i)repository not found` +
+	`|repository '[^']*' does not exist` +
+	`|does not appear to be a git repository` +
+	`|could not read from remote repository` +
+	`|authentication failed` +
+	`|could not read (?:username|password)`)
+
+// sshAuthFailedPattern matches the message of ssh when the key is rejected by the server.
+// It is not a sign of a repository which does not exist, even though git says "Could not read from remote repository".
+var sshAuthFailedPattern = regexp.MustCompile(`(?i)permission denied \(publickey`)
+
+// isNotExists returns whether the stderr of git says that the remote repository does not exist
+func isNotExists(stderr string) bool {
+	return notExistsPattern.MatchString(stderr) && !sshAuthFailedPattern.MatchString(stderr)
+}
+
+// emptyMessage is the warning of git when the remote repository is empty
+const emptyMessage = "You appear to have cloned an empty repository."
+
+// Clone implements git.GitService.
+func (s *GitService) Clone(ctx context.Context, remoteURL string, localPath string, opts coregit.CloneOptions) error {
+	args := []string{"clone"}
+	if s.cloneProgressWriter != nil {
+		args = append(args, "--progress")
+	} else {
+		args = append(args, "--quiet")
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.SingleBranch {
+		args = append(args, "--single-branch")
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter", opts.Filter)
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+		if opts.Depth > 0 {
+			args = append(args, "--shallow-submodules")
+		}
+	}
+	args = append(args, "--", remoteURL, localPath)
+
+	var stderr bytes.Buffer
+	progress := io.Writer(&stderr)
+	if s.cloneProgressWriter != nil {
+		progress = io.MultiWriter(&stderr, s.cloneProgressWriter)
+	}
+	if _, err := s.run(ctx, progress, args...); err != nil {
+		var gitErr *Error
+		if errors.As(err, &gitErr) && isNotExists(gitErr.Stderr) {
+			return coregit.ErrRepositoryNotExists
+		}
+		return err
+	}
+	if strings.Contains(stderr.String(), emptyMessage) {
+		// Remove the clone to behave like go-git: the caller initializes the empty repository by itself
+		if err := os.RemoveAll(localPath); err != nil {
+			return fmt.Errorf("removing the empty clone: %w", err)
+		}
+		return coregit.ErrRepositoryEmpty
+	}
+	return nil
+}
+
+func containsAny(s string, substrs []string) bool {
+	s = strings.ToLower(s)
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteNameOf(name string) string {
+	if name == "" {
+		return DefaultRemoteName
+	}
+	return name
+}
+
+// remoteRefs returns the remote-tracking references and their object names.
+func (s *GitService) remoteRefs(ctx context.Context, localPath, remote string) (string, error) {
+	out, err := s.run(ctx, nil, "-C", localPath, "for-each-ref", "--format=%(objectname) %(refname)", "refs/remotes/"+remote+"/")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Fetch implements git.GitService.
+func (s *GitService) Fetch(ctx context.Context, localPath string, opts coregit.FetchOptions) (bool, error) {
+	remote := remoteNameOf(opts.Remote)
+	before, err := s.remoteRefs(ctx, localPath, remote)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.run(ctx, nil, "-C", localPath, "fetch", "--quiet", "--", remote); err != nil {
+		return false, err
+	}
+	after, err := s.remoteRefs(ctx, localPath, remote)
+	if err != nil {
+		return false, err
+	}
+	return before != after, nil
+}
+
+// divergedMessage is the error message of git when the branch cannot be fast-forwarded
+const divergedMessage = "not possible to fast-forward"
+
+// noRemoteRefMessage is the error message of git when the remote does not have the branch to pull
+const noRemoteRefMessage = "couldn't find remote ref"
+
+// upstreamOf returns the remote and the remote branch which the branch is configured to track
+// ("branch.<name>.remote" and "branch.<name>.merge").
+// If the branch does not track one on the remote, it falls back to the same-named branch on the remote,
+// and the configured is false.
+func (s *GitService) upstreamOf(ctx context.Context, localPath, branch, remote string) (string, string, bool, error) {
+	out, err := s.run(ctx, nil, "-C", localPath, "for-each-ref", "--format=%(upstream:remotename)%00%(upstream:remoteref)", "refs/heads/"+branch)
+	if err != nil {
+		return "", "", false, err
+	}
+	upstreamRemote, mergeRef, _ := strings.Cut(strings.TrimSpace(string(out)), "\x00")
+	if upstreamRemote != "" && mergeRef != "" && (remote == "" || remote == upstreamRemote) {
+		return upstreamRemote, mergeRef, true, nil
+	}
+	return remoteNameOf(remote), branch, false, nil
+}
+
+// Pull implements git.GitService.
+// Untracked files are not regarded as uncommitted changes.
+func (s *GitService) Pull(ctx context.Context, localPath string, opts coregit.PullOptions) (bool, error) {
+	status, err := s.run(ctx, nil, "-C", localPath, "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(status)) > 0 {
+		return false, coregit.ErrWorktreeDirty
+	}
+	branch, err := s.run(ctx, nil, "-C", localPath, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		if exitCodeOf(err) == 1 {
+			return false, errors.New("HEAD is detached")
+		}
+		return false, err
+	}
+	remote, mergeRef, configured, err := s.upstreamOf(ctx, localPath, strings.TrimSpace(string(branch)), opts.Remote)
+	if err != nil {
+		return false, err
+	}
+	before, err := s.run(ctx, nil, "-C", localPath, "rev-parse", "HEAD")
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.run(ctx, nil, "-C", localPath, "pull", "--quiet", "--ff-only", "--no-rebase", "--", remote, mergeRef); err != nil {
+		var gitErr *Error
+		switch {
+		case !errors.As(err, &gitErr):
+		case containsAny(gitErr.Stderr, []string{divergedMessage}):
+			return false, coregit.ErrDiverged
+		case !configured && containsAny(gitErr.Stderr, []string{noRemoteRefMessage}):
+			// The local branch has never been pushed
+			return false, coregit.ErrNoUpstream
+		}
+		return false, err
+	}
+	after, err := s.run(ctx, nil, "-C", localPath, "rev-parse", "HEAD")
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(before, after), nil
+}
+
+// GetStatus implements git.GitService.
+func (s *GitService) GetStatus(ctx context.Context, localPath string) (*coregit.Status, error) {
+	var status coregit.Status
+	out, err := s.run(ctx, nil, "-C", localPath, "status", "--porcelain=v2", "--branch", "--untracked-files=normal")
+	if err != nil {
+		return nil, err
+	}
+	for line := range strings.SplitSeq(string(out), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.oid "):
+			if oid := strings.TrimPrefix(line, "# branch.oid "); oid != "(initial)" {
+				status.Head = oid
+			}
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				status.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			if _, err := fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &status.Ahead, &status.Behind); err != nil {
+				return nil, fmt.Errorf("parsing ahead and behind %q: %w", line, err)
+			}
+		case strings.HasPrefix(line, "? "):
+			status.Untracked = true
+		case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "), strings.HasPrefix(line, "u "):
+			status.Dirty = true
+		}
+	}
+
+	out, err = s.run(ctx, nil, "-C", localPath, "for-each-ref", "--format=%(refname:short)%00%(upstream)%00%(upstream:track)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+	for line := range strings.SplitSeq(string(out), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		name, upstream, track := fields[0], fields[1], fields[2]
+		if upstream == "" {
+			status.NoUpstreamBranches = append(status.NoUpstreamBranches, name)
+			continue
+		}
+		// The track is like "[ahead 1]", "[ahead 1, behind 2]", "[behind 2]", "[gone]" or empty
+		var ahead int
+		if _, err := fmt.Sscanf(track, "[ahead %d", &ahead); err == nil && ahead > 0 {
+			if status.AheadBranches == nil {
+				status.AheadBranches = map[string]int{}
+			}
+			status.AheadBranches[name] = ahead
+		}
+	}
+
+	out, err = s.run(ctx, nil, "-C", localPath, "stash", "list", "--format=%H")
+	if err != nil {
+		return nil, err
+	}
+	status.Stashes = len(strings.Fields(string(out)))
+
+	if status.Head == "" {
+		return &status, nil
+	}
+	out, err = s.run(ctx, nil, "-C", localPath, "log", "-1", "--format=%ct", status.Head)
+	if err != nil {
+		return nil, err
+	}
+	committed, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing commit time %q: %w", out, err)
+	}
+	status.HeadTime = time.Unix(committed, 0)
+	return &status, nil
+}
+
+// defaultBranchRef finds the remote-tracking reference of the default branch of the default remote.
+// It uses the HEAD of the remote set by the clone, and falls back to "main" or "master".
+// It returns an empty string if it is not found.
+func (s *GitService) defaultBranchRef(ctx context.Context, localPath string) (string, error) {
+	out, err := s.run(ctx, nil, "-C", localPath, "symbolic-ref", "--quiet", "refs/remotes/"+DefaultRemoteName+"/HEAD")
+	if err == nil {
+		return strings.TrimSpace(string(out)), nil
+	}
+	if exitCodeOf(err) != 1 {
+		return "", err
+	}
+	for _, name := range []string{"main", "master"} {
+		ref := "refs/remotes/" + DefaultRemoteName + "/" + name
+		if _, err := s.run(ctx, nil, "-C", localPath, "rev-parse", "--verify", "--quiet", ref); err == nil {
+			return ref, nil
+		}
+	}
+	return "", nil
+}
+
+// ListPrunableBranches implements git.GitService.
+func (s *GitService) ListPrunableBranches(ctx context.Context, localPath string) ([]coregit.PrunableBranch, error) {
+	var current string
+	if out, err := s.run(ctx, nil, "-C", localPath, "symbolic-ref", "--quiet", "--short", "HEAD"); err == nil {
+		current = strings.TrimSpace(string(out))
+	}
+	defaultRef, err := s.defaultBranchRef(ctx, localPath)
+	if err != nil {
+		return nil, err
+	}
+	defaultName := strings.TrimPrefix(defaultRef, "refs/remotes/"+DefaultRemoteName+"/")
+
+	merged := map[string]bool{}
+	if defaultRef != "" {
+		out, err := s.run(ctx, nil, "-C", localPath, "for-each-ref", "--merged", defaultRef, "--format=%(refname:short)", "refs/heads/")
+		if err != nil {
+			return nil, err
+		}
+		for name := range strings.FieldsSeq(string(out)) {
+			merged[name] = true
+		}
+	}
+
+	out, err := s.run(ctx, nil, "-C", localPath, "for-each-ref", "--format=%(refname:short)%00%(upstream:track)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+	var prunable []coregit.PrunableBranch
+	for line := range strings.SplitSeq(string(out), "\n") {
+		name, track, ok := strings.Cut(line, "\x00")
+		if !ok || name == current || name == defaultName {
+			continue
+		}
+		switch {
+		case merged[name]:
+			prunable = append(prunable, coregit.PrunableBranch{Name: name, Reason: coregit.PruneReasonMerged})
+		case track == "[gone]":
+			prunable = append(prunable, coregit.PrunableBranch{Name: name, Reason: coregit.PruneReasonUpstreamGone})
+		}
+	}
+	return prunable, nil
+}
+
+// DeleteBranch implements git.GitService.
+func (s *GitService) DeleteBranch(ctx context.Context, localPath string, branch string) error {
+	_, err := s.run(ctx, nil, "-C", localPath, "branch", "--quiet", "--delete", "--force", "--", branch)
+	return err
+}
+
+// Init implements git.GitService.
+func (s *GitService) Init(ctx context.Context, remoteURL, localPath string, isBare bool, _ coregit.InitOptions) error {
+	args := []string{"init", "--quiet"}
+	if isBare {
+		args = append(args, "--bare")
+	}
+	if _, err := s.run(ctx, nil, append(args, "--", localPath)...); err != nil {
+		return err
+	}
+	return s.SetDefaultRemotes(ctx, localPath, []string{remoteURL})
+}
+
+// SetRemotes implements git.GitService.
+// It replaces all of the URLs of the remote.
+func (s *GitService) SetRemotes(ctx context.Context, localPath string, name string, remotes []string) error {
+	urlKey := "remote." + name + ".url"
+	// Exit code 5 means that the key does not exist
+	if _, err := s.run(ctx, nil, "-C", localPath, "config", "--unset-all", urlKey); err != nil && exitCodeOf(err) != 5 {
+		return err
+	}
+	for _, remote := range remotes {
+		if _, err := s.run(ctx, nil, "-C", localPath, "config", "--add", urlKey, remote); err != nil {
+			return err
+		}
+	}
+	fetchKey := "remote." + name + ".fetch"
+	if _, err := s.run(ctx, nil, "-C", localPath, "config", "--get", fetchKey); err != nil {
+		if exitCodeOf(err) != 1 {
+			return err
+		}
+		if _, err := s.run(ctx, nil, "-C", localPath, "config", "--add", fetchKey, "+refs/heads/*:refs/remotes/"+name+"/*"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetDefaultRemotes implements git.GitService.
+func (s *GitService) SetDefaultRemotes(ctx context.Context, localPath string, remotes []string) error {
+	return s.SetRemotes(ctx, localPath, DefaultRemoteName, remotes)
+}
+
+// GetRemotes implements git.GitService.
+func (s *GitService) GetRemotes(ctx context.Context, localPath string, name string) ([]string, error) {
+	out, err := s.run(ctx, nil, "-C", localPath, "config", "--get-all", "remote."+name+".url")
+	if err != nil {
+		// Exit code 1 means that the key does not exist
+		if exitCodeOf(err) == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// Split by lines, not by spaces: a URL of a local path may contain spaces
+	var remotes []string
+	for remote := range strings.SplitSeq(string(out), "\n") {
+		if remote != "" {
+			remotes = append(remotes, remote)
+		}
+	}
+	return remotes, nil
+}
+
+// GetDefaultRemotes implements git.GitService.
+func (s *GitService) GetDefaultRemotes(ctx context.Context, localPath string) ([]string, error) {
+	return s.GetRemotes(ctx, localPath, DefaultRemoteName)
+}
+
+func exitCodeOf(err error) int {
+	var gitErr *Error
+	if errors.As(err, &gitErr) {
+		return gitErr.ExitCode()
+	}
+	return -1
+}
+
+// inclusionMatcher builds a matcher for the file patterns, or returns nil if there's no pattern.
+func inclusionMatcher(localPath string, filePatterns []string) gitignore.Matcher {
+	if len(filePatterns) == 0 {
+		return nil
+	}
+	var ps []gitignore.Pattern
+	domain := strings.Split(filepath.ToSlash(localPath), "/")
+	for _, p := range filePatterns {
+		ps = append(ps, gitignore.ParsePattern(p, domain))
+	}
+	return gitignore.NewMatcher(ps)
+}
+
+// ListExcludedFiles implements git.GitService.
+// It lists the files ignored by git (`git ls-files --others --ignored --exclude-standard`).
+func (s *GitService) ListExcludedFiles(ctx context.Context, localPath string, filePatterns []string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		localPath, err := filepath.Abs(localPath)
+		if err != nil {
+			yield("", fmt.Errorf("getting absolute path of repo: %w", err))
+			return
+		}
+		inclusion := inclusionMatcher(localPath, filePatterns)
+
+		out, err := s.run(ctx, nil, "-C", localPath, "ls-files", "-z", "--others", "--ignored", "--exclude-standard")
+		if err != nil {
+			yield("", fmt.Errorf("listing excluded files: %w", err))
+			return
+		}
+		for rel := range strings.SplitSeq(string(out), "\x00") {
+			if rel == "" {
+				continue
+			}
+			path := filepath.Join(localPath, filepath.FromSlash(rel))
+			if inclusion != nil && !inclusion.Match(strings.Split(filepath.ToSlash(path), "/"), false) {
+				continue
+			}
+			if !yield(path, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ListAllFiles implements git.GitService.
+// It includes `.git` directory.
+func (s *GitService) ListAllFiles(_ context.Context, localPath string, filePatterns []string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		localPath, err := filepath.Abs(localPath)
+		if err != nil {
+			yield("", fmt.Errorf("getting absolute path of repo: %w", err))
+			return
+		}
+		inclusion := inclusionMatcher(localPath, filePatterns)
+
+		if err := filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if inclusion != nil && !inclusion.Match(strings.Split(filepath.ToSlash(path), "/"), false) {
+				return nil
+			}
+			if !yield(path, nil) {
+				return filepath.SkipAll
+			}
+			return nil
+		}); err != nil {
+			yield("", fmt.Errorf("walking repository path %q: %w", localPath, err))
+			return
+		}
+	}
+}
+
+var _ coregit.GitService = (*GitService)(nil)
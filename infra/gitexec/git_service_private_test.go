@@ -0,0 +1,24 @@
+package gitexec
+
+import "testing"
+
+func TestIsNotExists(t *testing.T) {
+	for _, testcase := range []struct {
+		stderr string
+		want   bool
+	}{
+		{stderr: "remote: Repository not found.\nfatal: repository 'https://github.com/kyoh86/missing/' not found\n", want: true},
+		{stderr: "fatal: repository '/tmp/missing' does not exist\n", want: true},
+		{stderr: "fatal: '/tmp/missing.git' does not appear to be a git repository\nfatal: Could not read from remote repository.\n", want: true},
+		{stderr: "ERROR: Repository not found.\nfatal: Could not read from remote repository.\n", want: true},
+		{stderr: "fatal: Authentication failed for 'https://github.com/kyoh86/private/'\n", want: true},
+		{stderr: "fatal: could not read Username for 'https://github.com': terminal prompts disabled\n", want: true},
+		{stderr: "warning: Could not find remote branch typo to clone.\nfatal: Remote branch typo not found in upstream origin\n", want: false},
+		{stderr: "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.\n", want: false},
+		{stderr: "fatal: could not create work tree dir 'local': Permission denied\n", want: false},
+	} {
+		if got := isNotExists(testcase.stderr); got != testcase.want {
+			t.Errorf("isNotExists(%q) = %v, want %v", testcase.stderr, got, testcase.want)
+		}
+	}
+}
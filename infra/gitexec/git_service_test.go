@@ -0,0 +1,589 @@
+package gitexec_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	coregit "github.com/kyoh86/gogh/v4/core/git"
+	testtarget "github.com/kyoh86/gogh/v4/infra/gitexec"
+)
+
+func pathToFileURL(path string) string {
+	normalized := filepath.ToSlash(path)
+	u := &url.URL{Scheme: "file"}
+	if runtime.GOOS == "windows" {
+		u.Path = "/" + normalized
+	} else {
+		u.Path = normalized
+	}
+	return u.String()
+}
+
+func newService(t *testing.T) *testtarget.GitService {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command is not found")
+	}
+	service, err := testtarget.NewService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	return service
+}
+
+// runGit runs the git command in the directory for the test setup.
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test User",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to run git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupRemote creates a bare repository with a commit and returns its path.
+func setupRemote(t *testing.T) string {
+	t.Helper()
+	work := t.TempDir()
+	runGit(t, work, "init", "--quiet", "--initial-branch=main")
+	if err := os.WriteFile(filepath.Join(work, "README.md"), []byte("# test"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	runGit(t, work, "add", "README.md")
+	runGit(t, work, "commit", "--quiet", "-m", "initial")
+	runGit(t, work, "branch", "feature")
+
+	bare := filepath.Join(t.TempDir(), "remote.git")
+	runGit(t, work, "clone", "--quiet", "--bare", work, bare)
+	return bare
+}
+
+func TestNewService(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git command is not found")
+	}
+	service, err := testtarget.NewService(testtarget.CloneProgressWriter(&strings.Builder{}))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if service == nil {
+		t.Fatal("Expected non-nil service")
+	}
+
+	if _, err := testtarget.NewService(testtarget.Executable("")); err != nil {
+		t.Fatalf("Expected to search git in the PATH, got %v", err)
+	}
+}
+
+func TestClone(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+
+	t.Run("Clone", func(t *testing.T) {
+		local := filepath.Join(t.TempDir(), "local")
+		if err := service.Clone(ctx, pathToFileURL(remote), local, coregit.CloneOptions{}); err != nil {
+			t.Fatalf("Failed to clone: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(local, "README.md")); err != nil {
+			t.Errorf("Expected README.md to be cloned: %v", err)
+		}
+	})
+
+	t.Run("WithOptions", func(t *testing.T) {
+		local := filepath.Join(t.TempDir(), "local")
+		if err := service.Clone(ctx, pathToFileURL(remote), local, coregit.CloneOptions{
+			Depth:        1,
+			Branch:       "feature",
+			SingleBranch: true,
+		}); err != nil {
+			t.Fatalf("Failed to clone: %v", err)
+		}
+		if branch := runGit(t, local, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature" {
+			t.Errorf("Expected branch feature, got %q", branch)
+		}
+		if shallow := runGit(t, local, "rev-parse", "--is-shallow-repository"); shallow != "true" {
+			t.Errorf("Expected shallow repository, got %q", shallow)
+		}
+		if branches := runGit(t, local, "branch", "-r"); strings.Contains(branches, "origin/main") {
+			t.Errorf("Expected single branch, got %q", branches)
+		}
+	})
+
+	t.Run("NotExists", func(t *testing.T) {
+		local := filepath.Join(t.TempDir(), "local")
+		err := service.Clone(ctx, pathToFileURL(filepath.Join(t.TempDir(), "missing.git")), local, coregit.CloneOptions{})
+		if !errors.Is(err, coregit.ErrRepositoryNotExists) {
+			t.Errorf("Expected ErrRepositoryNotExists, got %v", err)
+		}
+	})
+
+	t.Run("MissingBranch", func(t *testing.T) {
+		local := filepath.Join(t.TempDir(), "local")
+		err := service.Clone(ctx, pathToFileURL(remote), local, coregit.CloneOptions{Branch: "typo"})
+		if err == nil {
+			t.Fatal("Expected an error for a missing branch")
+		}
+		if errors.Is(err, coregit.ErrRepositoryNotExists) {
+			t.Errorf("Expected an error other than ErrRepositoryNotExists, got %v", err)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		empty := filepath.Join(t.TempDir(), "empty.git")
+		runGit(t, t.TempDir(), "init", "--quiet", "--bare", empty)
+		local := filepath.Join(t.TempDir(), "local")
+		err := service.Clone(ctx, pathToFileURL(empty), local, coregit.CloneOptions{})
+		if !errors.Is(err, coregit.ErrRepositoryEmpty) {
+			t.Errorf("Expected ErrRepositoryEmpty, got %v", err)
+		}
+		if _, err := os.Stat(local); !os.IsNotExist(err) {
+			t.Errorf("Expected the empty clone to be removed, got %v", err)
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		local := filepath.Join(t.TempDir(), "local")
+		err := service.Clone(ctx, pathToFileURL(remote), local, coregit.CloneOptions{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestFetchAndPull(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+
+	// upstream is a working repository to push new commits to the remote
+	upstream := filepath.Join(t.TempDir(), "upstream")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, upstream)
+	local := filepath.Join(t.TempDir(), "local")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, local)
+
+	commit := func(t *testing.T, dir, name string, push bool) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, dir, "add", name)
+		runGit(t, dir, "commit", "--quiet", "-m", "add "+name)
+		if push {
+			runGit(t, dir, "push", "--quiet", "origin", "main")
+		}
+	}
+
+	t.Run("UpToDate", func(t *testing.T) {
+		if updated, err := service.Fetch(ctx, local, coregit.FetchOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+		if updated, err := service.Pull(ctx, local, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("Updated", func(t *testing.T) {
+		commit(t, upstream, "b.txt", true)
+		if updated, err := service.Fetch(ctx, local, coregit.FetchOptions{}); err != nil || !updated {
+			t.Errorf("Expected the fetch to update, got %v, %v", updated, err)
+		}
+		if updated, err := service.Pull(ctx, local, coregit.PullOptions{}); err != nil || !updated {
+			t.Errorf("Expected the pull to update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("Ahead", func(t *testing.T) {
+		commit(t, local, "c.txt", false)
+		if updated, err := service.Pull(ctx, local, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+
+	t.Run("Diverged", func(t *testing.T) {
+		commit(t, upstream, "d.txt", true)
+		if _, err := service.Pull(ctx, local, coregit.PullOptions{}); !errors.Is(err, coregit.ErrDiverged) {
+			t.Errorf("Expected ErrDiverged, got %v", err)
+		}
+	})
+
+	t.Run("Dirty", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(local, "README.md"), []byte("modified"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if _, err := service.Pull(ctx, local, coregit.PullOptions{}); !errors.Is(err, coregit.ErrWorktreeDirty) {
+			t.Errorf("Expected ErrWorktreeDirty, got %v", err)
+		}
+	})
+}
+
+func TestPull_Upstream(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+
+	// upstream is a working repository to push new commits to the remote
+	upstream := filepath.Join(t.TempDir(), "upstream")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, upstream)
+	local := filepath.Join(t.TempDir(), "local")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, local)
+
+	t.Run("LocalOnlyBranch", func(t *testing.T) {
+		runGit(t, local, "switch", "--quiet", "-c", "local-only")
+		if _, err := service.Pull(ctx, local, coregit.PullOptions{}); !errors.Is(err, coregit.ErrNoUpstream) {
+			t.Errorf("Expected ErrNoUpstream, got %v", err)
+		}
+	})
+
+	t.Run("DifferentlyNamedUpstream", func(t *testing.T) {
+		runGit(t, local, "switch", "--quiet", "-c", "topic", "--track", "origin/feature")
+		runGit(t, upstream, "switch", "--quiet", "feature")
+		if err := os.WriteFile(filepath.Join(upstream, "b.txt"), []byte("b"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, upstream, "add", "b.txt")
+		runGit(t, upstream, "commit", "--quiet", "-m", "add b.txt")
+		runGit(t, upstream, "push", "--quiet", "origin", "feature")
+
+		if updated, err := service.Pull(ctx, local, coregit.PullOptions{}); err != nil || !updated {
+			t.Fatalf("Expected the pull to update, got %v, %v", updated, err)
+		}
+		if got, want := runGit(t, local, "rev-parse", "HEAD"), runGit(t, upstream, "rev-parse", "HEAD"); got != want {
+			t.Errorf("Expected HEAD to be fast-forwarded to the feature %s, got %s", want, got)
+		}
+		if updated, err := service.Pull(ctx, local, coregit.PullOptions{}); err != nil || updated {
+			t.Errorf("Expected no update, got %v, %v", updated, err)
+		}
+	})
+}
+
+func TestGetStatus(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+
+	t.Run("NoCommit", func(t *testing.T) {
+		empty := t.TempDir()
+		runGit(t, empty, "init", "--quiet", "--initial-branch=main")
+		status, err := service.GetStatus(ctx, empty)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Branch != "main" || status.Head != "" || !status.HeadTime.IsZero() || !status.Clean() {
+			t.Errorf("Unexpected status: %+v", status)
+		}
+	})
+
+	upstream := filepath.Join(t.TempDir(), "upstream")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, upstream)
+	local := filepath.Join(t.TempDir(), "local")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, local)
+
+	t.Run("Clean", func(t *testing.T) {
+		status, err := service.GetStatus(ctx, local)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Branch != "main" || status.Upstream != "origin/main" || status.Head == "" || !status.Clean() {
+			t.Errorf("Unexpected status: %+v", status)
+		}
+		if time.Since(status.HeadTime) > time.Hour {
+			t.Errorf("Expected the time of the commit just made, got %v", status.HeadTime)
+		}
+	})
+
+	t.Run("AheadAndBehind", func(t *testing.T) {
+		for _, name := range []string{"b.txt", "c.txt"} {
+			if err := os.WriteFile(filepath.Join(upstream, name), []byte(name), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+			runGit(t, upstream, "add", name)
+			runGit(t, upstream, "commit", "--quiet", "-m", "add "+name)
+		}
+		runGit(t, upstream, "push", "--quiet", "origin", "main")
+		if err := os.WriteFile(filepath.Join(local, "d.txt"), []byte("d"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, local, "add", "d.txt")
+		runGit(t, local, "commit", "--quiet", "-m", "add d.txt")
+		runGit(t, local, "fetch", "--quiet")
+
+		status, err := service.GetStatus(ctx, local)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if status.Ahead != 1 || status.Behind != 2 {
+			t.Errorf("Expected ahead 1 and behind 2, got %+v", status)
+		}
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(local, "README.md"), []byte("stashed"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, local, "stash", "--quiet")
+		if err := os.WriteFile(filepath.Join(local, "README.md"), []byte("modified"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(local, "new.txt"), []byte("new"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, local, "branch", "topic")
+
+		status, err := service.GetStatus(ctx, local)
+		if err != nil {
+			t.Fatalf("Failed to get status: %v", err)
+		}
+		if !status.Dirty || !status.Untracked || status.Stashes != 1 {
+			t.Errorf("Expected dirty, untracked and a stash, got %+v", status)
+		}
+		if !slices.Equal(status.NoUpstreamBranches, []string{"topic"}) {
+			t.Errorf("Expected topic to have no upstream, got %v", status.NoUpstreamBranches)
+		}
+	})
+}
+
+func TestGetStatus_UnpushedBranch(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+	local := filepath.Join(t.TempDir(), "local")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, local)
+
+	// Commit to "feature" tracking "origin/feature" and go back to "main" which is in sync
+	runGit(t, local, "switch", "--quiet", "feature")
+	if err := os.WriteFile(filepath.Join(local, "b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	runGit(t, local, "add", "b.txt")
+	runGit(t, local, "commit", "--quiet", "-m", "add b.txt")
+	runGit(t, local, "switch", "--quiet", "main")
+
+	status, err := service.GetStatus(ctx, local)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if status.Branch != "main" || status.Ahead != 0 || len(status.NoUpstreamBranches) != 0 {
+		t.Errorf("Expected main to be in sync, got %+v", status)
+	}
+	if !maps.Equal(status.AheadBranches, map[string]int{"feature": 1}) {
+		t.Errorf("Expected feature to be ahead by 1, got %v", status.AheadBranches)
+	}
+	if status.Clean() {
+		t.Error("Expected the repository with an unpushed branch not to be clean")
+	}
+}
+
+func TestPruneBranches(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+	local := filepath.Join(t.TempDir(), "local")
+	runGit(t, t.TempDir(), "clone", "--quiet", remote, local)
+
+	commit := func(name string) {
+		if err := os.WriteFile(filepath.Join(local, name), []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		runGit(t, local, "add", name)
+		runGit(t, local, "commit", "--quiet", "-m", "add "+name)
+	}
+	// "merged" is merged into the default branch
+	runGit(t, local, "branch", "merged")
+	// "wip" has a commit which is not merged and has no upstream
+	runGit(t, local, "switch", "--quiet", "-c", "wip")
+	commit("wip.txt")
+	// "gone" has a commit which is not merged and its upstream is deleted
+	runGit(t, local, "switch", "--quiet", "-c", "gone")
+	commit("gone.txt")
+	runGit(t, local, "config", "branch.gone.remote", "origin")
+	runGit(t, local, "config", "branch.gone.merge", "refs/heads/gone")
+	runGit(t, local, "switch", "--quiet", "main")
+
+	branches, err := service.ListPrunableBranches(ctx, local)
+	if err != nil {
+		t.Fatalf("Failed to list prunable branches: %v", err)
+	}
+	want := []coregit.PrunableBranch{
+		{Name: "gone", Reason: coregit.PruneReasonUpstreamGone},
+		{Name: "merged", Reason: coregit.PruneReasonMerged},
+	}
+	if !slices.Equal(branches, want) {
+		t.Errorf("Expected %v, got %v", want, branches)
+	}
+
+	if err := service.DeleteBranch(ctx, local, "gone"); err != nil {
+		t.Fatalf("Failed to delete branch: %v", err)
+	}
+	if branches := runGit(t, local, "branch", "--format=%(refname:short)"); strings.Contains(branches, "gone") {
+		t.Errorf("Expected the branch to be deleted, got %q", branches)
+	}
+	if err := service.DeleteBranch(ctx, local, "missing"); err == nil {
+		t.Error("Expected an error for a missing branch")
+	}
+}
+
+func TestInitAndRemotes(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	local := filepath.Join(t.TempDir(), "local")
+
+	if err := service.Init(ctx, "https://github.com/kyoh86/gogh.git", local, false, coregit.InitOptions{}); err != nil {
+		t.Fatalf("Failed to init: %v", err)
+	}
+	remotes, err := service.GetDefaultRemotes(ctx, local)
+	if err != nil {
+		t.Fatalf("Failed to get remotes: %v", err)
+	}
+	if !slices.Equal(remotes, []string{"https://github.com/kyoh86/gogh.git"}) {
+		t.Errorf("Unexpected remotes: %v", remotes)
+	}
+
+	want := []string{"https://example.com/a.git", "https://example.com/b.git"}
+	if err := service.SetRemotes(ctx, local, "upstream", want); err != nil {
+		t.Fatalf("Failed to set remotes: %v", err)
+	}
+	if err := service.SetRemotes(ctx, local, "upstream", want); err != nil {
+		t.Fatalf("Failed to overwrite remotes: %v", err)
+	}
+	remotes, err = service.GetRemotes(ctx, local, "upstream")
+	if err != nil {
+		t.Fatalf("Failed to get remotes: %v", err)
+	}
+	if !slices.Equal(remotes, want) {
+		t.Errorf("Expected %v, got %v", want, remotes)
+	}
+	if fetch := runGit(t, local, "config", "--get-all", "remote.upstream.fetch"); fetch != "+refs/heads/*:refs/remotes/upstream/*" {
+		t.Errorf("Unexpected fetch refspec: %q", fetch)
+	}
+
+	// A local path of the remote may contain spaces
+	spaced := []string{"/path/to/my repos/a.git", "/path/to/my repos/b.git"}
+	if err := service.SetRemotes(ctx, local, "local", spaced); err != nil {
+		t.Fatalf("Failed to set remotes: %v", err)
+	}
+	remotes, err = service.GetRemotes(ctx, local, "local")
+	if err != nil {
+		t.Fatalf("Failed to get remotes: %v", err)
+	}
+	if !slices.Equal(remotes, spaced) {
+		t.Errorf("Expected %v, got %v", spaced, remotes)
+	}
+
+	remotes, err = service.GetRemotes(ctx, local, "missing")
+	if err != nil {
+		t.Fatalf("Expected no error for a missing remote, got %v", err)
+	}
+	if len(remotes) != 0 {
+		t.Errorf("Expected no remotes, got %v", remotes)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	remote := setupRemote(t)
+
+	authed, err := service.AuthenticateWithUsernamePassword(ctx, "user", "secret")
+	if err != nil {
+		t.Fatalf("Failed to authenticate: %v", err)
+	}
+	if err := authed.Clone(ctx, pathToFileURL(remote), filepath.Join(t.TempDir(), "local"), coregit.CloneOptions{}); err != nil {
+		t.Errorf("Failed to clone with the credentials: %v", err)
+	}
+
+	authed, err = service.AuthenticateWithSSH(ctx, coregit.SSHAuthOptions{
+		User:     "git",
+		KeyFiles: []string{filepath.Join(t.TempDir(), "id ed25519")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to authenticate: %v", err)
+	}
+	// The SSH options are not used for the local repository
+	if err := authed.Clone(ctx, pathToFileURL(remote), filepath.Join(t.TempDir(), "local"), coregit.CloneOptions{}); err != nil {
+		t.Errorf("Failed to clone with the SSH options: %v", err)
+	}
+}
+
+func TestListFiles(t *testing.T) {
+	ctx := context.Background()
+	service := newService(t)
+	local := t.TempDir()
+	runGit(t, local, "init", "--quiet")
+	for name, content := range map[string]string{
+		".gitignore":    "*.log\n.envrc\n",
+		"main.go":       "package main",
+		"debug.log":     "log",
+		".envrc":        "export FOO=bar",
+		"sub/trace.log": "log",
+	} {
+		path := filepath.Join(local, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	collect := func(seq func(func(string, error) bool)) []string {
+		var files []string
+		for file, err := range seq {
+			if err != nil {
+				t.Fatalf("Failed to list files: %v", err)
+			}
+			rel, err := filepath.Rel(local, file)
+			if err != nil {
+				t.Fatalf("Failed to get relative path: %v", err)
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		slices.Sort(files)
+		return files
+	}
+
+	t.Run("Excluded", func(t *testing.T) {
+		got := collect(service.ListExcludedFiles(ctx, local, nil))
+		want := []string{".envrc", "debug.log", "sub/trace.log"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("ExcludedWithPatterns", func(t *testing.T) {
+		got := collect(service.ListExcludedFiles(ctx, local, []string{".envrc"}))
+		want := []string{".envrc"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		got := collect(service.ListAllFiles(ctx, local, []string{"*.go"}))
+		want := []string{"main.go"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+}
@@ -12,10 +12,26 @@ import (
 
 type Token = oauth2.Token
 
-type AuthenticateService struct{}
+type AuthenticateService struct {
+	baseURL func(host string) string
+}
+
+// AuthenticateOption configures the AuthenticateService
+type AuthenticateOption func(*AuthenticateService)
 
-func NewAuthenticateService() *AuthenticateService {
-	return &AuthenticateService{}
+// AuthenticateBaseURL sets a function to resolve the REST API base URL for a host
+var AuthenticateBaseURL = func(f func(host string) string) AuthenticateOption {
+	return func(s *AuthenticateService) {
+		s.baseURL = f
+	}
+}
+
+func NewAuthenticateService(options ...AuthenticateOption) *AuthenticateService {
+	s := &AuthenticateService{baseURL: DefaultBaseURL}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
 }
 
 func (s *AuthenticateService) Authenticate(ctx context.Context, host string, verify auth.Verify) (string, *Token, error) {
@@ -67,7 +83,18 @@ func (s *AuthenticateService) Authenticate(ctx context.Context, host string, ver
 	}
 
 	// Get user info
-	conn := getConnection(ctx, host, token)
+	conn := getConnection(ctx, s.baseURL(host), host, token)
+	user, _, err := conn.rest.Users.Get(ctx, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("getting authenticated user: %w", err)
+	}
+	return user.GetLogin(), token, nil
+}
+
+// AuthenticateWithToken verifies a personal access token and returns the login name of its owner.
+func (s *AuthenticateService) AuthenticateWithToken(ctx context.Context, host string, accessToken string) (string, *Token, error) {
+	token := &Token{AccessToken: accessToken, TokenType: "Bearer"}
+	conn := getConnection(ctx, s.baseURL(host), host, token)
 	user, _, err := conn.rest.Users.Get(ctx, "")
 	if err != nil {
 		return "", nil, fmt.Errorf("getting authenticated user: %w", err)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/google/go-github/v80/github"
@@ -24,6 +25,8 @@ type HostingService struct {
 	tokenService       auth.TokenService
 	defaultNameService repository.DefaultNameService
 	knownOwners        map[string]string
+	knownOwnersMu      sync.Mutex
+	baseURL            func(host string) string
 }
 
 const (
@@ -85,50 +88,69 @@ type connection struct {
 	gql  graphql.Client
 }
 
-func getEnterpriseConnection(host string, httpClient *http.Client) *connection {
-	baseRESTURL := &url.URL{
-		Scheme: "https",
-		Host:   host,
-		Path:   "/api/v3",
-	}
-	uploadRESTURL := &url.URL{
-		Scheme: "https",
-		Host:   host,
-		Path:   "/api/uploads",
+// DefaultBaseURL returns the REST API base URL for the GitHub host
+func DefaultBaseURL(host string) string {
+	if host == GlobalHost || host == GlobalAPIHost {
+		return "https://" + GlobalAPIHost + "/"
 	}
-	baseGQLURL := &url.URL{
-		Scheme: "https",
-		Host:   host,
-		Path:   "/api/graphql",
+	return (&url.URL{Scheme: "https", Host: host, Path: "/api/v3/"}).String()
+}
+
+// getEnterpriseConnection builds a connection for the REST API base URL.
+// The upload and GraphQL endpoints are derived from it: "<prefix>/v3" => "<prefix>/uploads" and "<prefix>/graphql".
+func getEnterpriseConnection(baseURL string, httpClient *http.Client) *connection {
+	base := strings.TrimSuffix(baseURL, "/")
+	uploadURL, gqlURL := base, base+"/graphql"
+	if prefix, ok := strings.CutSuffix(base, "/v3"); ok {
+		uploadURL, gqlURL = prefix+"/uploads", prefix+"/graphql"
 	}
-	restClient, err := github.NewClient(httpClient).WithEnterpriseURLs(baseRESTURL.String(), uploadRESTURL.String())
+	restClient, err := github.NewClient(httpClient).WithEnterpriseURLs(base+"/", uploadURL+"/")
 	if err != nil {
 		// NOTE: WithEnterpriseURLs returns error if the URLs are not valid.
 		// We assume that the URLs are valid.
 		panic(err)
 	}
-	return &connection{rest: restClient, gql: graphql.NewClient(baseGQLURL.String(), httpClient)}
+	return &connection{rest: restClient, gql: graphql.NewClient(gqlURL, httpClient)}
 }
 
-func getConnection(ctx context.Context, host string, token *auth.Token) *connection {
+func getConnection(ctx context.Context, baseURL string, host string, token *auth.Token) *connection {
 	source := getSource(ctx, host, token)
 	httpClient := oauth2.NewClient(ctx, source)
-	if host == GlobalHost || host == GlobalAPIHost {
+	if baseURL == DefaultBaseURL(GlobalHost) {
 		return &connection{
 			rest: github.NewClient(httpClient),
 			gql:  graphql.NewClient("https://"+GlobalAPIHost+"/graphql", httpClient),
 		}
 	}
-	return getEnterpriseConnection(host, httpClient)
+	return getEnterpriseConnection(baseURL, httpClient)
+}
+
+// Option configures the HostingService
+type Option func(*HostingService)
+
+// BaseURL sets a function to resolve the REST API base URL (e.g.: "https://github.example.com/api/v3") for a host
+var BaseURL = func(f func(host string) string) Option {
+	return func(s *HostingService) {
+		s.baseURL = f
+	}
 }
 
 // NewHostingService creates a new HostingService instance
-func NewHostingService(tokenService auth.TokenService, defaultNameService repository.DefaultNameService) *HostingService {
-	return &HostingService{
+func NewHostingService(
+	tokenService auth.TokenService,
+	defaultNameService repository.DefaultNameService,
+	options ...Option,
+) *HostingService {
+	s := &HostingService{
 		tokenService:       tokenService,
 		defaultNameService: defaultNameService,
 		knownOwners:        map[string]string{},
+		baseURL:            DefaultBaseURL,
+	}
+	for _, opt := range options {
+		opt(s)
 	}
+	return s
 }
 
 // GetURLOf implements hosting.HostingService.
@@ -151,10 +173,26 @@ func (s *HostingService) ParseURL(u *url.URL) (*repository.Reference, error) {
 
 var ErrTokenNotFound = errors.New("no token found")
 
+// knownOwner returns the owner of the token which is cached for the key ("<host>/<owner>").
+// The cache is guarded because GetTokenFor may be called concurrently (e.g. by pull and clone).
+func (s *HostingService) knownOwner(key string) (string, bool) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	tokenOwner, ok := s.knownOwners[key]
+	return tokenOwner, ok
+}
+
+// setKnownOwner caches the owner of the token for the key ("<host>/<owner>")
+func (s *HostingService) setKnownOwner(key, tokenOwner string) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	s.knownOwners[key] = tokenOwner
+}
+
 // GetTokenFor cache requested token for the host and owner
 func (s *HostingService) GetTokenFor(ctx context.Context, host, owner string) (string, auth.Token, error) {
 	key := strings.Join([]string{host, owner}, "/")
-	tokenOwner, ok := s.knownOwners[key]
+	tokenOwner, ok := s.knownOwner(key)
 	if ok {
 		_, token, err := s.getTokenForCore(ctx, host, tokenOwner)
 		return tokenOwner, token, err
@@ -171,19 +209,19 @@ func (s *HostingService) GetTokenFor(ctx context.Context, host, owner string) (s
 			if err != nil {
 				return "", token, fmt.Errorf("getting default token: %w", inErr)
 			} else {
-				s.knownOwners[key] = tokenOwner
+				s.setKnownOwner(key, tokenOwner)
 			}
 		} else {
 			return "", token, fmt.Errorf("getting token for %s/%s: %w", host, owner, err)
 		}
 	} else {
-		s.knownOwners[key] = tokenOwner
+		s.setKnownOwner(key, tokenOwner)
 	}
 	return tokenOwner, token, err
 }
 
-func memberOf(ctx context.Context, owner string, entry auth.TokenEntry) bool {
-	connection := getConnection(ctx, entry.Host, &entry.Token)
+func (s *HostingService) memberOf(ctx context.Context, owner string, entry auth.TokenEntry) bool {
+	connection := getConnection(ctx, s.baseURL(entry.Host), entry.Host, &entry.Token)
 	orgs, _, err := connection.rest.Organizations.List(ctx, "", &github.ListOptions{PerPage: 100})
 	if err != nil {
 		return false
@@ -211,7 +249,7 @@ func (s *HostingService) getTokenForCore(ctx context.Context, host, owner string
 		}
 
 		// Check if this user is a member of the target organization
-		if memberOf(ctx, owner, entry) {
+		if s.memberOf(ctx, owner, entry) {
 			return entry.Owner, entry.Token, nil
 		}
 	}
@@ -225,7 +263,7 @@ func (s *HostingService) GetRepository(ctx context.Context, reference repository
 	if err != nil {
 		return nil, fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
 	}
-	conn := getConnection(ctx, reference.Host(), &token)
+	conn := getConnection(ctx, s.baseURL(reference.Host()), reference.Host(), &token)
 	ghRepo, _, err := conn.rest.Repositories.Get(ctx, reference.Owner(), reference.Name())
 	if err != nil {
 		return nil, fmt.Errorf("requesting repository: %w", err)
@@ -277,12 +315,26 @@ func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRe
 		}
 		var count int
 		for _, entry := range s.tokenService.Entries() {
+			if opts.Host != "" && entry.Host != opts.Host {
+				continue
+			}
 			if err := ctx.Err(); err != nil {
 				yield(nil, err)
 				return
 			}
+			if opts.Owner != "" {
+				// List the repositories of the owner only with the token which is used for the owner
+				tokenOwner, _, err := s.GetTokenFor(ctx, entry.Host, opts.Owner)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if tokenOwner != entry.Owner {
+					continue
+				}
+			}
 
-			conn := getConnection(ctx, entry.Host, &entry.Token)
+			conn := getConnection(ctx, s.baseURL(entry.Host), entry.Host, &entry.Token)
 			var after string
 
 			for {
@@ -334,29 +386,19 @@ func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRe
 					yield(nil, fmt.Errorf("invalid isArchived option %q: %w", opts.IsArchived, err))
 					return
 				}
-				repos, err := githubv4.ListRepos(
-					ctx,
-					conn.gql,
-					limit,
-					after,
-					isFork,
-					privacy,
-					affs,
-					githubv4.RepositoryOrder{Field: orderField, Direction: orderDirection},
-					isArchived,
-				)
+				nodes, page, err := listRepos(ctx, conn.gql, opts.Owner, limit, after, isFork, privacy, affs,
+					githubv4.RepositoryOrder{Field: orderField, Direction: orderDirection}, isArchived)
 				if err != nil {
 					yield(nil, err)
 					return
 				}
 
-				repositories := repos.Viewer.Repositories
-				for _, edge := range repositories.Edges {
+				for _, node := range nodes {
 					if err := ctx.Err(); err != nil {
 						yield(nil, err)
 						return
 					}
-					if !yield(typ.Ptr(convertRepositoryFragment(entry.Host, edge.Node.RepositoryFragment)), nil) {
+					if !yield(typ.Ptr(convertRepositoryFragment(entry.Host, node)), nil) {
 						return
 					}
 
@@ -366,7 +408,6 @@ func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRe
 					}
 				}
 
-				page := repositories.PageInfo.PageInfoFragment
 				if !page.HasNextPage {
 					break
 				}
@@ -380,6 +421,46 @@ func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRe
 	}
 }
 
+// listRepos gets a page of the repositories which the viewer is affiliated with,
+// or which the owner owns if the owner is not empty.
+func listRepos(
+	ctx context.Context,
+	client graphql.Client,
+	owner string,
+	first int,
+	after string,
+	isFork *bool,
+	privacy githubv4.RepositoryPrivacy,
+	affs []githubv4.RepositoryAffiliation,
+	orderBy githubv4.RepositoryOrder,
+	isArchived *bool,
+) ([]githubv4.RepositoryFragment, githubv4.PageInfoFragment, error) {
+	var nodes []githubv4.RepositoryFragment
+	if owner == "" {
+		repos, err := githubv4.ListRepos(ctx, client, first, after, isFork, privacy, affs, orderBy, isArchived)
+		if err != nil {
+			return nil, githubv4.PageInfoFragment{}, err
+		}
+		for _, edge := range repos.Viewer.Repositories.Edges {
+			nodes = append(nodes, edge.Node.RepositoryFragment)
+		}
+		return nodes, repos.Viewer.Repositories.PageInfo.PageInfoFragment, nil
+	}
+	repos, err := githubv4.ListOwnerRepos(ctx, client, owner, first, after, isFork, privacy, orderBy, isArchived)
+	if err != nil {
+		return nil, githubv4.PageInfoFragment{}, err
+	}
+	if repos.RepositoryOwner == nil {
+		// The owner is not found
+		return nil, githubv4.PageInfoFragment{}, nil
+	}
+	repositories := repos.RepositoryOwner.GetRepositories()
+	for _, edge := range repositories.Edges {
+		nodes = append(nodes, edge.Node.RepositoryFragment)
+	}
+	return nodes, repositories.PageInfo.PageInfoFragment, nil
+}
+
 // invertPtr returns nil if b is true, or pointer to false if b is false.
 func invertPtr(b bool) *bool {
 	if b {
@@ -398,7 +479,7 @@ func (s *HostingService) CreateRepository(
 	if err != nil {
 		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
 	}
-	conn := getConnection(ctx, ref.Host(), &token)
+	conn := getConnection(ctx, s.baseURL(ref.Host()), ref.Host(), &token)
 	org := ""
 	if user != ref.Owner() {
 		org = ref.Owner()
@@ -438,7 +519,7 @@ func (s *HostingService) CreateRepositoryFromTemplate(
 	if err != nil {
 		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
 	}
-	conn := getConnection(ctx, ref.Host(), &token)
+	conn := getConnection(ctx, s.baseURL(ref.Host()), ref.Host(), &token)
 	req := github.TemplateRepoRequest{
 		Name:               typ.Ptr(ref.Name()),
 		Description:        &opts.Description,
@@ -466,7 +547,7 @@ func (s *HostingService) DeleteRepository(ctx context.Context, reference reposit
 	if err != nil {
 		return fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
 	}
-	conn := getConnection(ctx, reference.Host(), &token)
+	conn := getConnection(ctx, s.baseURL(reference.Host()), reference.Host(), &token)
 	if _, err = conn.rest.Repositories.Delete(ctx, reference.Owner(), reference.Name()); err != nil {
 		return fmt.Errorf("requesting to delete repository: %w", err)
 	}
@@ -484,7 +565,7 @@ func (s *HostingService) ForkRepository(
 	if err != nil {
 		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
 	}
-	conn := getConnection(ctx, ref.Host(), &token)
+	conn := getConnection(ctx, s.baseURL(ref.Host()), ref.Host(), &token)
 	ghOpts := &github.RepositoryCreateForkOptions{
 		Name:              target.Name(),
 		DefaultBranchOnly: opts.DefaultBranchOnly,
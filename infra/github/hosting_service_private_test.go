@@ -1,10 +1,12 @@
 package github
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/go-github/v80/github"
+	"github.com/kyoh86/gogh/v4/core/auth"
 	"github.com/kyoh86/gogh/v4/core/repository"
 )
 
@@ -170,3 +172,47 @@ func TestConvertSSHToHTTPS(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConnection(t *testing.T) {
+	ctx := context.Background()
+	testCases := []struct {
+		name       string
+		baseURL    string
+		host       string
+		wantREST   string
+		wantUpload string
+	}{
+		{
+			name:       "github.com",
+			baseURL:    DefaultBaseURL("github.com"),
+			host:       "github.com",
+			wantREST:   "https://api.github.com/",
+			wantUpload: "https://uploads.github.com/",
+		},
+		{
+			name:       "default enterprise",
+			baseURL:    DefaultBaseURL("github.example.com"),
+			host:       "github.example.com",
+			wantREST:   "https://github.example.com/api/v3/",
+			wantUpload: "https://github.example.com/api/uploads/",
+		},
+		{
+			name:       "custom API base",
+			baseURL:    "https://api.example.com/github/",
+			host:       "git.example.com",
+			wantREST:   "https://api.example.com/github/",
+			wantUpload: "https://api.example.com/github/",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := getConnection(ctx, tc.baseURL, tc.host, &auth.Token{AccessToken: "dummy"})
+			if got := conn.rest.BaseURL.String(); got != tc.wantREST {
+				t.Errorf("expected REST URL %q, got %q", tc.wantREST, got)
+			}
+			if got := conn.rest.UploadURL.String(); got != tc.wantUpload {
+				t.Errorf("expected upload URL %q, got %q", tc.wantUpload, got)
+			}
+		})
+	}
+}
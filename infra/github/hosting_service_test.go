@@ -2,7 +2,9 @@ package github_test
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"sync"
 	"testing"
 
 	"github.com/kyoh86/gogh/v4/core/auth"
@@ -189,6 +191,31 @@ func TestGetTokenFor(t *testing.T) {
 			t.Error("Expected error for missing token, got nil")
 		}
 	})
+
+	// Test case 3: Concurrent calls (e.g. from the workers of pull) share the cache safely
+	t.Run("concurrent calls", func(t *testing.T) {
+		ctrl, mockTokenService, _, service := setupHostingServiceTest(t)
+		defer ctrl.Finish()
+
+		token := auth.Token{AccessToken: "token1"}
+		mockTokenService.EXPECT().Has("github.com", gomock.Any()).Return(true).AnyTimes()
+		mockTokenService.EXPECT().Get("github.com", gomock.Any()).Return(token, nil).AnyTimes()
+
+		var wg sync.WaitGroup
+		for i := range 32 {
+			wg.Go(func() {
+				owner := fmt.Sprintf("user%d", i%4)
+				tokenOwner, _, err := service.GetTokenFor(ctx, "github.com", owner)
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				if tokenOwner != owner {
+					t.Errorf("Expected token owner %q, got %q", owner, tokenOwner)
+				}
+			})
+		}
+		wg.Wait()
+	})
 }
 
 func TestGetRepository(t *testing.T) {
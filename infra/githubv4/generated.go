@@ -23,6 +23,476 @@ type LanguageFragment struct {
 // GetName returns LanguageFragment.Name, and is useful for accessing the field via an interface.
 func (v *LanguageFragment) GetName() string { return v.Name }
 
+// ListOwnerReposRepositoryOwner includes the requested fields of the GraphQL interface RepositoryOwner.
+//
+// ListOwnerReposRepositoryOwner is implemented by the following types:
+// ListOwnerReposRepositoryOwnerOrganization
+// ListOwnerReposRepositoryOwnerUser
+// The GraphQL type's documentation follows.
+//
+// Represents an owner of a Repository.
+type ListOwnerReposRepositoryOwner interface {
+	implementsGraphQLInterfaceListOwnerReposRepositoryOwner()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
+	// GetRepositories returns the interface-field "repositories" from its implementation.
+	// The GraphQL interface field's documentation follows.
+	//
+	// A list of repositories that the user owns.
+	GetRepositories() ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection
+}
+
+func (v *ListOwnerReposRepositoryOwnerOrganization) implementsGraphQLInterfaceListOwnerReposRepositoryOwner() {
+}
+func (v *ListOwnerReposRepositoryOwnerUser) implementsGraphQLInterfaceListOwnerReposRepositoryOwner() {
+}
+
+func __unmarshalListOwnerReposRepositoryOwner(b []byte, v *ListOwnerReposRepositoryOwner) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
+	}
+	err := json.Unmarshal(b, &tn)
+	if err != nil {
+		return err
+	}
+
+	switch tn.TypeName {
+	case "Organization":
+		*v = new(ListOwnerReposRepositoryOwnerOrganization)
+		return json.Unmarshal(b, *v)
+	case "User":
+		*v = new(ListOwnerReposRepositoryOwnerUser)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing RepositoryOwner.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for ListOwnerReposRepositoryOwner: "%v"`, tn.TypeName)
+	}
+}
+
+func __marshalListOwnerReposRepositoryOwner(v *ListOwnerReposRepositoryOwner) ([]byte, error) {
+
+	var typename string
+	switch v := (*v).(type) {
+	case *ListOwnerReposRepositoryOwnerOrganization:
+		typename = "Organization"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*ListOwnerReposRepositoryOwnerOrganization
+		}{typename, v}
+		return json.Marshal(result)
+	case *ListOwnerReposRepositoryOwnerUser:
+		typename = "User"
+
+		result := struct {
+			TypeName string `json:"__typename"`
+			*ListOwnerReposRepositoryOwnerUser
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for ListOwnerReposRepositoryOwner: "%T"`, v)
+	}
+}
+
+// ListOwnerReposRepositoryOwnerOrganization includes the requested fields of the GraphQL type Organization.
+// The GraphQL type's documentation follows.
+//
+// An account on GitHub, with one or more owners, that has repositories, members and teams.
+type ListOwnerReposRepositoryOwnerOrganization struct {
+	Typename string `json:"__typename"`
+	// A list of repositories that the user owns.
+	Repositories ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection `json:"repositories"`
+}
+
+// GetTypename returns ListOwnerReposRepositoryOwnerOrganization.Typename, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerOrganization) GetTypename() string { return v.Typename }
+
+// GetRepositories returns ListOwnerReposRepositoryOwnerOrganization.Repositories, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerOrganization) GetRepositories() ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection {
+	return v.Repositories
+}
+
+// ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection includes the requested fields of the GraphQL type RepositoryConnection.
+// The GraphQL type's documentation follows.
+//
+// A list of repositories owned by the subject.
+type ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection struct {
+	// A list of edges.
+	Edges []ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge `json:"edges"`
+	// Identifies the total count of items in the connection.
+	TotalCount int `json:"totalCount"`
+	// Information to aid in pagination.
+	PageInfo ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo `json:"pageInfo"`
+}
+
+// GetEdges returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection.Edges, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection) GetEdges() []ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge {
+	return v.Edges
+}
+
+// GetTotalCount returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection.TotalCount, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection) GetTotalCount() int {
+	return v.TotalCount
+}
+
+// GetPageInfo returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection) GetPageInfo() ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo {
+	return v.PageInfo
+}
+
+// ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge includes the requested fields of the GraphQL type RepositoryEdge.
+// The GraphQL type's documentation follows.
+//
+// An edge in a connection.
+type ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge struct {
+	// The item at the end of the edge.
+	Node ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository `json:"node"`
+}
+
+// GetNode returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge.Node, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdge) GetNode() ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository {
+	return v.Node
+}
+
+// ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository includes the requested fields of the GraphQL type Repository.
+// The GraphQL type's documentation follows.
+//
+// A repository contains the content for a project.
+type ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository struct {
+	RepositoryFragment `json:"-"`
+}
+
+// GetUrl returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.Url, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetUrl() string {
+	return v.RepositoryFragment.Url
+}
+
+// GetHomepageUrl returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.HomepageUrl, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetHomepageUrl() string {
+	return v.RepositoryFragment.HomepageUrl
+}
+
+// GetSshUrl returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.SshUrl, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetSshUrl() string {
+	return v.RepositoryFragment.SshUrl
+}
+
+// GetPrimaryLanguage returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.PrimaryLanguage, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetPrimaryLanguage() RepositoryFragmentPrimaryLanguage {
+	return v.RepositoryFragment.PrimaryLanguage
+}
+
+// GetName returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.Name, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetName() string {
+	return v.RepositoryFragment.Name
+}
+
+// GetOwner returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.Owner, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetOwner() RepositoryFragmentOwnerRepositoryOwner {
+	return v.RepositoryFragment.Owner
+}
+
+// GetDescription returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.Description, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetDescription() string {
+	return v.RepositoryFragment.Description
+}
+
+// GetCreatedAt returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.CreatedAt, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetCreatedAt() time.Time {
+	return v.RepositoryFragment.CreatedAt
+}
+
+// GetIsArchived returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.IsArchived, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetIsArchived() bool {
+	return v.RepositoryFragment.IsArchived
+}
+
+// GetIsFork returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.IsFork, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetIsFork() bool {
+	return v.RepositoryFragment.IsFork
+}
+
+// GetIsPrivate returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.IsPrivate, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetIsPrivate() bool {
+	return v.RepositoryFragment.IsPrivate
+}
+
+// GetIsTemplate returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.IsTemplate, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetIsTemplate() bool {
+	return v.RepositoryFragment.IsTemplate
+}
+
+// GetUpdatedAt returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetUpdatedAt() time.Time {
+	return v.RepositoryFragment.UpdatedAt
+}
+
+// GetParent returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.Parent, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) GetParent() RepositoryFragmentParentRepository {
+	return v.RepositoryFragment.Parent
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.RepositoryFragment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository struct {
+	Url string `json:"url"`
+
+	HomepageUrl string `json:"homepageUrl"`
+
+	SshUrl string `json:"sshUrl"`
+
+	PrimaryLanguage RepositoryFragmentPrimaryLanguage `json:"primaryLanguage"`
+
+	Name string `json:"name"`
+
+	Owner json.RawMessage `json:"owner"`
+
+	Description string `json:"description"`
+
+	CreatedAt time.Time `json:"createdAt"`
+
+	IsArchived bool `json:"isArchived"`
+
+	IsFork bool `json:"isFork"`
+
+	IsPrivate bool `json:"isPrivate"`
+
+	IsTemplate bool `json:"isTemplate"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	Parent RepositoryFragmentParentRepository `json:"parent"`
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository) __premarshalJSON() (*__premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository, error) {
+	var retval __premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository
+
+	retval.Url = v.RepositoryFragment.Url
+	retval.HomepageUrl = v.RepositoryFragment.HomepageUrl
+	retval.SshUrl = v.RepositoryFragment.SshUrl
+	retval.PrimaryLanguage = v.RepositoryFragment.PrimaryLanguage
+	retval.Name = v.RepositoryFragment.Name
+	{
+
+		dst := &retval.Owner
+		src := v.RepositoryFragment.Owner
+		var err error
+		*dst, err = __marshalRepositoryFragmentOwnerRepositoryOwner(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionEdgesRepositoryEdgeNodeRepository.RepositoryFragment.Owner: %w", err)
+		}
+	}
+	retval.Description = v.RepositoryFragment.Description
+	retval.CreatedAt = v.RepositoryFragment.CreatedAt
+	retval.IsArchived = v.RepositoryFragment.IsArchived
+	retval.IsFork = v.RepositoryFragment.IsFork
+	retval.IsPrivate = v.RepositoryFragment.IsPrivate
+	retval.IsTemplate = v.RepositoryFragment.IsTemplate
+	retval.UpdatedAt = v.RepositoryFragment.UpdatedAt
+	retval.Parent = v.RepositoryFragment.Parent
+	return &retval, nil
+}
+
+// ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo struct {
+	PageInfoFragment `json:"-"`
+}
+
+// GetEndCursor returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo) GetEndCursor() string {
+	return v.PageInfoFragment.EndCursor
+}
+
+// GetHasNextPage returns ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo) GetHasNextPage() bool {
+	return v.PageInfoFragment.HasNextPage
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.PageInfoFragment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo struct {
+	EndCursor string `json:"endCursor"`
+
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo) __premarshalJSON() (*__premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo, error) {
+	var retval __premarshalListOwnerReposRepositoryOwnerRepositoriesRepositoryConnectionPageInfo
+
+	retval.EndCursor = v.PageInfoFragment.EndCursor
+	retval.HasNextPage = v.PageInfoFragment.HasNextPage
+	return &retval, nil
+}
+
+// ListOwnerReposRepositoryOwnerUser includes the requested fields of the GraphQL type User.
+// The GraphQL type's documentation follows.
+//
+// A user is an individual's account on GitHub that owns repositories and can make new content.
+type ListOwnerReposRepositoryOwnerUser struct {
+	Typename string `json:"__typename"`
+	// A list of repositories that the user owns.
+	Repositories ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection `json:"repositories"`
+}
+
+// GetTypename returns ListOwnerReposRepositoryOwnerUser.Typename, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerUser) GetTypename() string { return v.Typename }
+
+// GetRepositories returns ListOwnerReposRepositoryOwnerUser.Repositories, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposRepositoryOwnerUser) GetRepositories() ListOwnerReposRepositoryOwnerRepositoriesRepositoryConnection {
+	return v.Repositories
+}
+
+// ListOwnerReposResponse is returned by ListOwnerRepos on success.
+type ListOwnerReposResponse struct {
+	// Lookup a repository owner (ie. either a User or an Organization) by login.
+	RepositoryOwner ListOwnerReposRepositoryOwner `json:"-"`
+}
+
+// GetRepositoryOwner returns ListOwnerReposResponse.RepositoryOwner, and is useful for accessing the field via an interface.
+func (v *ListOwnerReposResponse) GetRepositoryOwner() ListOwnerReposRepositoryOwner {
+	return v.RepositoryOwner
+}
+
+func (v *ListOwnerReposResponse) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ListOwnerReposResponse
+		RepositoryOwner json.RawMessage `json:"repositoryOwner"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ListOwnerReposResponse = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.RepositoryOwner
+		src := firstPass.RepositoryOwner
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalListOwnerReposRepositoryOwner(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal ListOwnerReposResponse.RepositoryOwner: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalListOwnerReposResponse struct {
+	RepositoryOwner json.RawMessage `json:"repositoryOwner"`
+}
+
+func (v *ListOwnerReposResponse) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ListOwnerReposResponse) __premarshalJSON() (*__premarshalListOwnerReposResponse, error) {
+	var retval __premarshalListOwnerReposResponse
+
+	{
+
+		dst := &retval.RepositoryOwner
+		src := v.RepositoryOwner
+		var err error
+		*dst, err = __marshalListOwnerReposRepositoryOwner(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal ListOwnerReposResponse.RepositoryOwner: %w", err)
+		}
+	}
+	return &retval, nil
+}
+
 // ListReposResponse is returned by ListRepos on success.
 type ListReposResponse struct {
 	// The currently authenticated user.
@@ -1363,6 +1833,38 @@ var AllRepositoryPrivacy = []RepositoryPrivacy{
 	RepositoryPrivacyPublic,
 }
 
+// __ListOwnerReposInput is used internally by genqlient
+type __ListOwnerReposInput struct {
+	Owner      string            `json:"owner"`
+	First      int               `json:"first"`
+	After      string            `json:"after,omitempty"`
+	IsFork     *bool             `json:"isFork,omitempty"`
+	Privacy    RepositoryPrivacy `json:"privacy,omitempty"`
+	OrderBy    RepositoryOrder   `json:"orderBy,omitempty"`
+	IsArchived *bool             `json:"isArchived,omitempty"`
+}
+
+// GetOwner returns __ListOwnerReposInput.Owner, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetOwner() string { return v.Owner }
+
+// GetFirst returns __ListOwnerReposInput.First, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetFirst() int { return v.First }
+
+// GetAfter returns __ListOwnerReposInput.After, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetAfter() string { return v.After }
+
+// GetIsFork returns __ListOwnerReposInput.IsFork, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetIsFork() *bool { return v.IsFork }
+
+// GetPrivacy returns __ListOwnerReposInput.Privacy, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetPrivacy() RepositoryPrivacy { return v.Privacy }
+
+// GetOrderBy returns __ListOwnerReposInput.OrderBy, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetOrderBy() RepositoryOrder { return v.OrderBy }
+
+// GetIsArchived returns __ListOwnerReposInput.IsArchived, and is useful for accessing the field via an interface.
+func (v *__ListOwnerReposInput) GetIsArchived() *bool { return v.IsArchived }
+
 // __ListReposInput is used internally by genqlient
 type __ListReposInput struct {
 	First        int                     `json:"first"`
@@ -1395,6 +1897,104 @@ func (v *__ListReposInput) GetOrderBy() RepositoryOrder { return v.OrderBy }
 // GetIsArchived returns __ListReposInput.IsArchived, and is useful for accessing the field via an interface.
 func (v *__ListReposInput) GetIsArchived() *bool { return v.IsArchived }
 
+// The query executed by ListOwnerRepos.
+const ListOwnerRepos_Operation = `
+query ListOwnerRepos ($owner: String!, $first: Int = 30, $after: String, $isFork: Boolean, $privacy: RepositoryPrivacy, $orderBy: RepositoryOrder = {field:PUSHED_AT,direction:DESC}, $isArchived: Boolean) {
+	repositoryOwner(login: $owner) {
+		__typename
+		repositories(first: $first, after: $after, isArchived: $isArchived, isFork: $isFork, privacy: $privacy, ownerAffiliations: [OWNER], orderBy: $orderBy) {
+			edges {
+				node {
+					... RepositoryFragment
+				}
+			}
+			totalCount
+			pageInfo {
+				... PageInfoFragment
+			}
+		}
+	}
+}
+fragment RepositoryFragment on Repository {
+	url
+	homepageUrl
+	sshUrl
+	primaryLanguage {
+		... LanguageFragment
+	}
+	name
+	owner {
+		__typename
+		... OwnerFragment
+	}
+	description
+	createdAt
+	isArchived
+	isFork
+	isPrivate
+	isTemplate
+	updatedAt
+	parent {
+		... ParentRepositoryFragment
+	}
+}
+fragment PageInfoFragment on PageInfo {
+	endCursor
+	hasNextPage
+}
+fragment LanguageFragment on Language {
+	name
+}
+fragment OwnerFragment on RepositoryOwner {
+	login
+}
+fragment ParentRepositoryFragment on Repository {
+	name
+	owner {
+		__typename
+		... OwnerFragment
+	}
+	sshUrl
+}
+`
+
+func ListOwnerRepos(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	owner string,
+	first int,
+	after string,
+	isFork *bool,
+	privacy RepositoryPrivacy,
+	orderBy RepositoryOrder,
+	isArchived *bool,
+) (data_ *ListOwnerReposResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "ListOwnerRepos",
+		Query:  ListOwnerRepos_Operation,
+		Variables: &__ListOwnerReposInput{
+			Owner:      owner,
+			First:      first,
+			After:      after,
+			IsFork:     isFork,
+			Privacy:    privacy,
+			OrderBy:    orderBy,
+			IsArchived: isArchived,
+		},
+	}
+
+	data_ = &ListOwnerReposResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by ListRepos.
 const ListRepos_Operation = `
 query ListRepos ($first: Int = 30, $after: String, $isFork: Boolean, $privacy: RepositoryPrivacy, $affiliations: [RepositoryAffiliation], $orderBy: RepositoryOrder = {field:PUSHED_AT,direction:DESC}, $isArchived: Boolean) {
@@ -0,0 +1,150 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/errgroup"
+)
+
+type Token = oauth2.Token
+
+// ErrNoClientID is returned when the OAuth application for the device flow is not configured
+var ErrNoClientID = errors.New("no OAuth application ID is configured for GitLab; set GOGH_GITLAB_CLIENT_ID")
+
+func oAuth2Config(host, clientID string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID: clientID,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:       fmt.Sprintf("https://%s/oauth/authorize", host),
+			TokenURL:      fmt.Sprintf("https://%s/oauth/token", host),
+			DeviceAuthURL: fmt.Sprintf("https://%s/oauth/authorize_device", host),
+		},
+		Scopes: []string{"api"},
+	}
+}
+
+type tokenSource struct {
+	ctx    context.Context
+	config *oauth2.Config
+	token  *oauth2.Token
+}
+
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	if s.token.Valid() {
+		return s.token, nil
+	}
+	newToken, err := s.config.TokenSource(s.ctx, &oauth2.Token{RefreshToken: s.token.RefreshToken}).Token()
+	if err != nil {
+		return nil, err
+	}
+	s.token = newToken
+	return newToken, nil
+}
+
+func getSource(ctx context.Context, config *oauth2.Config, token *auth.Token) oauth2.TokenSource {
+	if token == nil {
+		return nil
+	}
+	return oauth2.ReuseTokenSource(token, &tokenSource{ctx: ctx, config: config, token: token})
+}
+
+// AuthenticateService authenticates users on GitLab with the OAuth 2.0 device authorization grant
+type AuthenticateService struct {
+	baseURL  func(host string) string
+	clientID string
+}
+
+// AuthenticateOption configures the AuthenticateService
+type AuthenticateOption func(*AuthenticateService)
+
+// AuthenticateBaseURL sets a function to resolve the REST API base URL for a host
+var AuthenticateBaseURL = func(f func(host string) string) AuthenticateOption {
+	return func(s *AuthenticateService) {
+		s.baseURL = f
+	}
+}
+
+// NewAuthenticateService creates a new AuthenticateService instance.
+// The clientID is an ID of the OAuth application registered in the GitLab instance.
+func NewAuthenticateService(clientID string, options ...AuthenticateOption) *AuthenticateService {
+	s := &AuthenticateService{
+		baseURL:  DefaultBaseURL,
+		clientID: clientID,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *AuthenticateService) Authenticate(ctx context.Context, host string, verify auth.Verify) (string, *Token, error) {
+	if s.clientID == "" {
+		return "", nil, ErrNoClientID
+	}
+	config := oAuth2Config(host, s.clientID)
+	// Request device code
+	deviceCodeResp, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("requesting device code: %w", err)
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		verificationURI := deviceCodeResp.VerificationURIComplete
+		if verificationURI == "" {
+			verificationURI = deviceCodeResp.VerificationURI
+		}
+		if err := verify(egCtx, auth.DeviceAuthResponse{
+			VerificationURI: verificationURI,
+			UserCode:        deviceCodeResp.UserCode,
+		}); err != nil {
+			return fmt.Errorf("verifying device code: %w", err)
+		}
+		return nil
+	})
+
+	// Poll for token
+	var token *Token
+	eg.Go(func() error {
+		// copy deviceCodeResp to avoid conflict with the other goroutine
+		codeResp := *deviceCodeResp
+		codeResp.Interval++ // Add a second for safety; the server may not be ready yet
+		resp, err := config.DeviceAccessToken(egCtx, &codeResp)
+		if err != nil {
+			return fmt.Errorf("polling for token: %w", err)
+		}
+		token = resp
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return "", nil, err
+	}
+
+	if token == nil {
+		return "", nil, fmt.Errorf("got nil token response")
+	}
+
+	return s.userOf(ctx, host, token)
+}
+
+// AuthenticateWithToken verifies a personal access token and returns the username of its owner.
+func (s *AuthenticateService) AuthenticateWithToken(ctx context.Context, host string, accessToken string) (string, *Token, error) {
+	return s.userOf(ctx, host, &Token{AccessToken: accessToken, TokenType: "Bearer"})
+}
+
+// userOf gets the username of the token owner
+func (s *AuthenticateService) userOf(ctx context.Context, host string, token *Token) (string, *Token, error) {
+	c := newClient(s.baseURL(host), oauth2.NewClient(ctx, oauth2.StaticTokenSource(token)))
+	var u user
+	if _, err := c.Do(ctx, http.MethodGet, "/user", nil, nil, &u); err != nil {
+		return "", nil, fmt.Errorf("getting authenticated user: %w", err)
+	}
+	return u.Username, token, nil
+}
+
+var _ auth.AuthenticateService = (*AuthenticateService)(nil)
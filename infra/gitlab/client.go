@@ -0,0 +1,80 @@
+package gitlab
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kyoh86/gogh/v4/infra/internal/restapi"
+)
+
+// ErrNotFound is returned when the GitLab API responds with 404 Not Found
+var ErrNotFound = restapi.ErrNotFound
+
+// DefaultBaseURL returns the REST API base URL for the GitLab host
+func DefaultBaseURL(host string) string {
+	return (&url.URL{Scheme: "https", Host: host, Path: "/api/v4"}).String()
+}
+
+// newClient creates a client for the GitLab REST API (v4)
+func newClient(baseURL string, httpClient *http.Client) *restapi.Client {
+	return restapi.NewClient("gitlab", baseURL, httpClient)
+}
+
+// projectPath returns the API path segment for the project identified by "namespace/path"
+func projectPath(namespace, name string) string {
+	return "/projects/" + url.PathEscape(namespace+"/"+name)
+}
+
+type user struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type namespace struct {
+	ID       int64  `json:"id"`
+	Path     string `json:"path"`
+	FullPath string `json:"full_path"`
+	Kind     string `json:"kind"`
+}
+
+type group struct {
+	ID       int64  `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+type project struct {
+	ID                int64      `json:"id"`
+	Name              string     `json:"name"`
+	Path              string     `json:"path"`
+	PathWithNamespace string     `json:"path_with_namespace"`
+	Namespace         namespace  `json:"namespace"`
+	Description       string     `json:"description"`
+	WebURL            string     `json:"web_url"`
+	HTTPURLToRepo     string     `json:"http_url_to_repo"`
+	SSHURLToRepo      string     `json:"ssh_url_to_repo"`
+	DefaultBranch     string     `json:"default_branch"`
+	Visibility        string     `json:"visibility"`
+	Archived          bool       `json:"archived"`
+	LastActivityAt    time.Time  `json:"last_activity_at"`
+	UpdatedAt         *time.Time `json:"updated_at"`
+	ForkedFromProject *project   `json:"forked_from_project"`
+}
+
+// updatedAt returns the most recent update time of the project.
+// Older GitLab versions do not return "updated_at" for projects.
+func (p *project) updatedAt() time.Time {
+	if p.UpdatedAt != nil && p.UpdatedAt.After(p.LastActivityAt) {
+		return *p.UpdatedAt
+	}
+	return p.LastActivityAt
+}
+
+// ownerAndName splits the path_with_namespace into the namespace and the project path
+func (p *project) ownerAndName() (string, string) {
+	if i := strings.LastIndex(p.PathWithNamespace, "/"); i >= 0 {
+		return p.PathWithNamespace[:i], p.PathWithNamespace[i+1:]
+	}
+	return p.Namespace.FullPath, p.Path
+}
@@ -0,0 +1,565 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/infra/internal/restapi"
+	"github.com/kyoh86/gogh/v4/typ"
+	"golang.org/x/oauth2"
+)
+
+// HostingService is a hosting.HostingService for GitLab (gitlab.com and self-managed instances)
+type HostingService struct {
+	tokenService       auth.TokenService
+	defaultNameService repository.DefaultNameService
+	knownOwners        map[string]string
+	knownOwnersMu      sync.Mutex
+	baseURL            func(host string) string
+	clientID           string
+}
+
+const (
+	GlobalHost = "gitlab.com"
+
+	RepoListMaxLimitPerPage = 100
+)
+
+// Option configures the HostingService
+type Option func(*HostingService)
+
+// BaseURL sets a function to resolve the REST API base URL (e.g.: "https://gitlab.com/api/v4") for a host
+var BaseURL = func(f func(host string) string) Option {
+	return func(s *HostingService) {
+		s.baseURL = f
+	}
+}
+
+// OAuthClientID sets the ID of the OAuth application which is used to refresh tokens
+var OAuthClientID = func(id string) Option {
+	return func(s *HostingService) {
+		s.clientID = id
+	}
+}
+
+// NewHostingService creates a new HostingService instance
+func NewHostingService(
+	tokenService auth.TokenService,
+	defaultNameService repository.DefaultNameService,
+	options ...Option,
+) *HostingService {
+	s := &HostingService{
+		tokenService:       tokenService,
+		defaultNameService: defaultNameService,
+		knownOwners:        map[string]string{},
+		baseURL:            DefaultBaseURL,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+func (s *HostingService) getClient(ctx context.Context, host string, token *auth.Token) *restapi.Client {
+	source := getSource(ctx, oAuth2Config(host, s.clientID), token)
+	return newClient(s.baseURL(host), oauth2.NewClient(ctx, source))
+}
+
+// GetURLOf implements hosting.HostingService.
+func (s *HostingService) GetURLOf(ref repository.Reference) (*url.URL, error) {
+	return &url.URL{
+		Scheme: "https",
+		Host:   ref.Host(),
+		Path:   strings.Join([]string{ref.Owner(), ref.Name()}, "/"),
+	}, nil
+}
+
+// ParseURL implements hosting.HostingService.
+func (s *HostingService) ParseURL(u *url.URL) (*repository.Reference, error) {
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	words := strings.Split(path, "/")
+	if len(words) < 2 || slices.Contains(words, "") {
+		return nil, fmt.Errorf("invalid path: %q", u.Path)
+	}
+	// The owner may be a nested namespace like "<group>/<subgroup>"
+	owner, name := strings.Join(words[:len(words)-1], "/"), words[len(words)-1]
+	return typ.Ptr(repository.NewReference(u.Host, owner, name)), nil
+}
+
+var ErrTokenNotFound = errors.New("no token found")
+
+// knownOwner returns the owner of the token which is cached for the key ("<host>/<owner>").
+// The cache is guarded because GetTokenFor may be called concurrently (e.g. by pull and clone).
+func (s *HostingService) knownOwner(key string) (string, bool) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	tokenOwner, ok := s.knownOwners[key]
+	return tokenOwner, ok
+}
+
+// setKnownOwner caches the owner of the token for the key ("<host>/<owner>")
+func (s *HostingService) setKnownOwner(key, tokenOwner string) {
+	s.knownOwnersMu.Lock()
+	defer s.knownOwnersMu.Unlock()
+	s.knownOwners[key] = tokenOwner
+}
+
+// GetTokenFor cache requested token for the host and owner
+func (s *HostingService) GetTokenFor(ctx context.Context, host, owner string) (string, auth.Token, error) {
+	key := strings.Join([]string{host, owner}, "/")
+	if tokenOwner, ok := s.knownOwner(key); ok {
+		_, token, err := s.getTokenForCore(ctx, host, tokenOwner)
+		return tokenOwner, token, err
+	}
+	tokenOwner, token, err := s.getTokenForCore(ctx, host, owner)
+	if err == nil {
+		s.setKnownOwner(key, tokenOwner)
+		return tokenOwner, token, nil
+	}
+	if !errors.Is(err, ErrTokenNotFound) {
+		return "", token, fmt.Errorf("getting token for %s/%s: %w", host, owner, err)
+	}
+	// If no token is found, use the default owner as the username
+	defaultOwner, err := s.defaultNameService.GetDefaultOwnerFor(host)
+	if err != nil {
+		return "", token, fmt.Errorf("getting default owner: %w", err)
+	}
+	tokenOwner, token, err = s.getTokenForCore(ctx, host, defaultOwner)
+	if err != nil {
+		return "", token, fmt.Errorf("getting default token: %w", err)
+	}
+	s.setKnownOwner(key, tokenOwner)
+	return tokenOwner, token, nil
+}
+
+// memberOf returns whether the user of the entry is a member of the group (or the subgroup).
+// It looks for the group in all pages of the groups which the user belongs to.
+func (s *HostingService) memberOf(ctx context.Context, owner string, entry auth.TokenEntry) bool {
+	c := s.getClient(ctx, entry.Host, &entry.Token)
+	for groups, err := range restapi.Pages(ctx, c, "/groups", url.Values{
+		"min_access_level": {"10"},
+		"per_page":         {strconv.Itoa(RepoListMaxLimitPerPage)},
+	}, restapi.NextPageHeader[[]group]) {
+		if err != nil {
+			return false
+		}
+		for _, g := range groups {
+			if strings.EqualFold(g.FullPath, owner) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *HostingService) getTokenForCore(ctx context.Context, host, owner string) (string, auth.Token, error) {
+	if s.tokenService.Has(host, owner) {
+		token, err := s.tokenService.Get(host, owner)
+		return owner, token, err
+	}
+
+	for _, entry := range s.tokenService.Entries() {
+		if entry.Host != host {
+			continue
+		}
+		if entry.Owner == owner {
+			return entry.Owner, entry.Token, nil
+		}
+
+		// Check if this user is a member of the target group
+		if s.memberOf(ctx, owner, entry) {
+			return entry.Owner, entry.Token, nil
+		}
+	}
+
+	return "", auth.Token{}, ErrTokenNotFound
+}
+
+// GetRepository retrieves repository information from a remote source
+func (s *HostingService) GetRepository(ctx context.Context, reference repository.Reference) (*hosting.Repository, error) {
+	_, token, err := s.GetTokenFor(ctx, reference.Host(), reference.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
+	}
+	c := s.getClient(ctx, reference.Host(), &token)
+	var p project
+	if _, err := c.Do(ctx, http.MethodGet, projectPath(reference.Owner(), reference.Name()), nil, nil, &p); err != nil {
+		return nil, fmt.Errorf("requesting repository: %w", err)
+	}
+	return typ.Ptr(convertProject(reference.Host(), reference, &p)), nil
+}
+
+// buildListQuery converts hosting.ListRepositoryOptions to query parameters of "GET /projects"
+func buildListQuery(opts hosting.ListRepositoryOptions, perPage int) (url.Values, error) {
+	query := url.Values{
+		"per_page": {strconv.Itoa(perPage)},
+	}
+
+	var visibility string
+	if err := typ.Remap(&visibility, map[hosting.RepositoryPrivacy]string{
+		hosting.RepositoryPrivacyPublic:  "public",
+		hosting.RepositoryPrivacyPrivate: "private",
+	}, opts.Privacy); err != nil {
+		return nil, fmt.Errorf("invalid privacy option %q", opts.Privacy)
+	}
+	if visibility != "" {
+		query.Set("visibility", visibility)
+	}
+
+	orderBy := "updated_at"
+	if err := typ.Remap(&orderBy, map[hosting.RepositoryOrderField]string{
+		hosting.RepositoryOrderFieldCreatedAt:  "created_at",
+		hosting.RepositoryOrderFieldUpdatedAt:  "updated_at",
+		hosting.RepositoryOrderFieldPushedAt:   "last_activity_at",
+		hosting.RepositoryOrderFieldName:       "name",
+		hosting.RepositoryOrderFieldStargazers: "star_count",
+	}, opts.OrderBy.Field); err != nil {
+		return nil, fmt.Errorf("invalid order field %q", opts.OrderBy.Field)
+	}
+	query.Set("order_by", orderBy)
+
+	sort := "desc"
+	if err := typ.Remap(&sort, map[hosting.OrderDirection]string{
+		hosting.OrderDirectionAsc:  "asc",
+		hosting.OrderDirectionDesc: "desc",
+	}, opts.OrderBy.Direction); err != nil {
+		return nil, fmt.Errorf("invalid order direction %q", opts.OrderBy.Direction)
+	}
+	query.Set("sort", sort)
+
+	// GitLab cannot distinguish "collaborator" from "organization-member":
+	// both of them are covered by the membership.
+	ownedOnly := len(opts.OwnerAffiliations) > 0
+	for _, aff := range opts.OwnerAffiliations {
+		switch aff {
+		case hosting.RepositoryAffiliationOwner:
+		case hosting.RepositoryAffiliationCollaborator, hosting.RepositoryAffiliationOrganizationMember:
+			ownedOnly = false
+		default:
+			return nil, fmt.Errorf("invalid owner affiliations %q", opts.OwnerAffiliations)
+		}
+	}
+	switch {
+	case opts.Owner != "":
+		// The projects of the owner are listed from its own path
+	case ownedOnly:
+		query.Set("owned", "true")
+	default:
+		query.Set("membership", "true")
+	}
+
+	isArchived, err := opts.IsArchived.AsBoolPtr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid isArchived option %q: %w", opts.IsArchived, err)
+	}
+	if isArchived != nil {
+		query.Set("archived", strconv.FormatBool(*isArchived))
+	}
+	return query, nil
+}
+
+// ownerProjectsPath returns the API path to list the projects of the owner (a user or a group)
+func ownerProjectsPath(ctx context.Context, c *restapi.Client, owner string) (string, error) {
+	var ns namespace
+	if _, err := c.Do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(owner), nil, nil, &ns); err != nil {
+		return "", fmt.Errorf("requesting namespace %q: %w", owner, err)
+	}
+	if ns.Kind == "user" {
+		return "/users/" + url.PathEscape(ns.FullPath) + "/projects", nil
+	}
+	return "/groups/" + strconv.FormatInt(ns.ID, 10) + "/projects", nil
+}
+
+// ListRepository retrieves a list of repositories from a remote source
+func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+	return func(yield func(*hosting.Repository, error) bool) {
+		var perPage int
+		switch {
+		case opts.Limit == 0:
+			perPage = RepoListMaxLimitPerPage
+		case opts.Limit > RepoListMaxLimitPerPage:
+			perPage = RepoListMaxLimitPerPage
+		default:
+			perPage = opts.Limit
+		}
+		query, err := buildListQuery(opts, perPage)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		// GitLab does not support to filter forks in the API
+		isFork, err := opts.IsFork.AsBoolPtr()
+		if err != nil {
+			yield(nil, fmt.Errorf("invalid isFork option %q: %w", opts.IsFork, err))
+			return
+		}
+
+		var count int
+		for _, entry := range s.tokenService.Entries() {
+			if opts.Host != "" && entry.Host != opts.Host {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if opts.Owner != "" {
+				// List the repositories of the owner only with the token which is used for the owner
+				tokenOwner, _, err := s.GetTokenFor(ctx, entry.Host, opts.Owner)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if tokenOwner != entry.Owner {
+					continue
+				}
+			}
+
+			c := s.getClient(ctx, entry.Host, &entry.Token)
+			path := "/projects"
+			if opts.Owner != "" {
+				var err error
+				path, err = ownerProjectsPath(ctx, c, opts.Owner)
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			for projects, err := range restapi.Pages(ctx, c, path, query, restapi.NextPageHeader[[]project]) {
+				if err != nil {
+					yield(nil, fmt.Errorf("requesting repositories: %w", err))
+					return
+				}
+				for _, p := range projects {
+					if isFork != nil && *isFork != (p.ForkedFromProject != nil) {
+						continue
+					}
+					owner, name := p.ownerAndName()
+					ref := repository.NewReference(entry.Host, owner, name)
+					if !yield(typ.Ptr(convertProject(entry.Host, ref, &p)), nil) {
+						return
+					}
+
+					count++
+					if opts.Limit > 0 && count >= opts.Limit {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+type createProjectRequest struct {
+	Name                         string `json:"name"`
+	Path                         string `json:"path"`
+	NamespaceID                  *int64 `json:"namespace_id,omitempty"`
+	Description                  string `json:"description,omitempty"`
+	Visibility                   string `json:"visibility,omitempty"`
+	InitializeWithReadme         bool   `json:"initialize_with_readme,omitempty"`
+	IssuesAccessLevel            string `json:"issues_access_level,omitempty"`
+	WikiAccessLevel              string `json:"wiki_access_level,omitempty"`
+	MergeMethod                  string `json:"merge_method,omitempty"`
+	SquashOption                 string `json:"squash_option,omitempty"`
+	RemoveSourceBranchAfterMerge bool   `json:"remove_source_branch_after_merge,omitempty"`
+	UseCustomTemplate            bool   `json:"use_custom_template,omitempty"`
+	TemplateProjectID            *int64 `json:"template_project_id,omitempty"`
+	GroupWithProjectTemplatesID  *int64 `json:"group_with_project_templates_id,omitempty"`
+}
+
+func visibilityOf(private bool) string {
+	if private {
+		return "private"
+	}
+	return "public"
+}
+
+// disabledIf returns "disabled" if b is true, or empty (means "default") if b is false.
+func disabledIf(b bool) string {
+	if b {
+		return "disabled"
+	}
+	return ""
+}
+
+// namespaceIDFor returns the ID of the namespace to create a project in.
+// It returns nil if the owner is the user; the project will be created in the user's namespace.
+func namespaceIDFor(ctx context.Context, c *restapi.Client, user, owner string) (*int64, error) {
+	if user == owner {
+		return nil, nil
+	}
+	var ns namespace
+	if _, err := c.Do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(owner), nil, nil, &ns); err != nil {
+		return nil, fmt.Errorf("requesting namespace %q: %w", owner, err)
+	}
+	return &ns.ID, nil
+}
+
+// CreateRepository creates a new project on GitLab.
+// Options that GitLab does not have (e.g. Homepage, DisableProjects, LicenseTemplate) are ignored.
+func (s *HostingService) CreateRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	opts hosting.CreateRepositoryOptions,
+) (*hosting.Repository, error) {
+	user, token, err := s.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	nsID, err := namespaceIDFor(ctx, c, user, ref.Owner())
+	if err != nil {
+		return nil, err
+	}
+	req := createProjectRequest{
+		Name:                         ref.Name(),
+		Path:                         ref.Name(),
+		NamespaceID:                  nsID,
+		Description:                  opts.Description,
+		Visibility:                   visibilityOf(opts.Private),
+		InitializeWithReadme:         opts.AutoInit,
+		IssuesAccessLevel:            disabledIf(opts.DisableIssues),
+		WikiAccessLevel:              disabledIf(opts.DisableWiki),
+		RemoveSourceBranchAfterMerge: opts.DeleteBranchOnMerge,
+	}
+	if opts.PreventMergeCommit {
+		req.MergeMethod = "ff"
+	}
+	if opts.PreventSquashMerge {
+		req.SquashOption = "never"
+	}
+	var p project
+	if _, err := c.Do(ctx, http.MethodPost, "/projects", nil, req, &p); err != nil {
+		return nil, fmt.Errorf("requesting new repository: %w", err)
+	}
+	return typ.Ptr(convertProject(ref.Host(), ref, &p)), nil
+}
+
+// CreateRepositoryFromTemplate creates a new project from a custom project template.
+func (s *HostingService) CreateRepositoryFromTemplate(
+	ctx context.Context,
+	ref repository.Reference,
+	tmp repository.Reference,
+	opts hosting.CreateRepositoryFromTemplateOptions,
+) (*hosting.Repository, error) {
+	user, token, err := s.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	var template project
+	if _, err := c.Do(ctx, http.MethodGet, projectPath(tmp.Owner(), tmp.Name()), nil, nil, &template); err != nil {
+		return nil, fmt.Errorf("requesting template repository: %w", err)
+	}
+	nsID, err := namespaceIDFor(ctx, c, user, ref.Owner())
+	if err != nil {
+		return nil, err
+	}
+	req := createProjectRequest{
+		Name:              ref.Name(),
+		Path:              ref.Name(),
+		NamespaceID:       nsID,
+		Description:       opts.Description,
+		Visibility:        visibilityOf(opts.Private),
+		UseCustomTemplate: true,
+		TemplateProjectID: &template.ID,
+	}
+	if template.Namespace.Kind == "group" {
+		req.GroupWithProjectTemplatesID = &template.Namespace.ID
+	}
+	var p project
+	if _, err := c.Do(ctx, http.MethodPost, "/projects", nil, req, &p); err != nil {
+		return nil, fmt.Errorf("requesting new repository from template: %w", err)
+	}
+	return typ.Ptr(convertProject(ref.Host(), ref, &p)), nil
+}
+
+// DeleteRepository deletes a repository from a remote source
+func (s *HostingService) DeleteRepository(ctx context.Context, reference repository.Reference) error {
+	_, token, err := s.GetTokenFor(ctx, reference.Host(), reference.Owner())
+	if err != nil {
+		return fmt.Errorf("getting token for %s/%s: %w", reference.Host(), reference.Owner(), err)
+	}
+	c := s.getClient(ctx, reference.Host(), &token)
+	if _, err := c.Do(ctx, http.MethodDelete, projectPath(reference.Owner(), reference.Name()), nil, nil, nil); err != nil {
+		return fmt.Errorf("requesting to delete repository: %w", err)
+	}
+	return nil
+}
+
+type forkProjectRequest struct {
+	Name          string `json:"name,omitempty"`
+	Path          string `json:"path,omitempty"`
+	NamespacePath string `json:"namespace_path,omitempty"`
+	Branches      string `json:"branches,omitempty"`
+}
+
+// ForkRepository implements hosting.HostingService.
+func (s *HostingService) ForkRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	target repository.Reference,
+	opts hosting.ForkRepositoryOptions,
+) (*hosting.Repository, error) {
+	user, token, err := s.GetTokenFor(ctx, target.Host(), target.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", target.Host(), target.Owner(), err)
+	}
+	c := s.getClient(ctx, ref.Host(), &token)
+	req := forkProjectRequest{
+		Name: target.Name(),
+		Path: target.Name(),
+	}
+	if user != target.Owner() {
+		req.NamespacePath = target.Owner()
+	}
+	if opts.DefaultBranchOnly {
+		var source project
+		if _, err := c.Do(ctx, http.MethodGet, projectPath(ref.Owner(), ref.Name()), nil, nil, &source); err != nil {
+			return nil, fmt.Errorf("requesting repository: %w", err)
+		}
+		req.Branches = source.DefaultBranch
+	}
+	var fork project
+	if _, err := c.Do(ctx, http.MethodPost, projectPath(ref.Owner(), ref.Name())+"/fork", nil, req, &fork); err != nil {
+		return nil, fmt.Errorf("requesting fork: %w", err)
+	}
+	return typ.Ptr(convertProject(target.Host(), target, &fork)), nil
+}
+
+func convertProject(host string, ref repository.Reference, p *project) hosting.Repository {
+	repo := hosting.Repository{
+		Ref:         ref,
+		URL:         p.WebURL,
+		CloneURL:    p.HTTPURLToRepo,
+		UpdatedAt:   p.updatedAt(),
+		Description: p.Description,
+		Archived:    p.Archived,
+		Private:     p.Visibility != "public",
+		Fork:        p.ForkedFromProject != nil,
+	}
+	if parent := p.ForkedFromProject; parent != nil {
+		owner, name := parent.ownerAndName()
+		repo.Parent = &hosting.ParentRepository{
+			Ref:      repository.NewReference(host, owner, name),
+			CloneURL: parent.HTTPURLToRepo,
+		}
+	}
+	return repo
+}
+
+var _ hosting.HostingService = (*HostingService)(nil)
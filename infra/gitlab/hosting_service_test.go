@@ -0,0 +1,515 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/auth_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/core/repository_mock"
+	testtarget "github.com/kyoh86/gogh/v4/infra/gitlab"
+	"github.com/kyoh86/gogh/v4/typ"
+	"go.uber.org/mock/gomock"
+)
+
+const testHost = "gitlab.example.com"
+
+// setupHostingServiceTest starts a fake GitLab API server with the handler
+// and creates a HostingService which has a token for "kyoh86" on the testHost.
+func setupHostingServiceTest(t *testing.T, handler http.Handler) *testtarget.HostingService {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Has(testHost, "kyoh86").Return(true).AnyTimes()
+	tokenService.EXPECT().Has(testHost, gomock.Any()).Return(false).AnyTimes()
+	tokenService.EXPECT().Get(testHost, "kyoh86").Return(auth.Token{AccessToken: "dummy-token"}, nil).AnyTimes()
+	tokenService.EXPECT().Entries().Return([]auth.TokenEntry{
+		{Host: testHost, Owner: "kyoh86", Token: auth.Token{AccessToken: "dummy-token"}},
+	}).AnyTimes()
+	defaultNameService := repository_mock.NewMockDefaultNameService(ctrl)
+	defaultNameService.EXPECT().GetDefaultOwnerFor(testHost).Return("kyoh86", nil).AnyTimes()
+
+	return testtarget.NewHostingService(
+		tokenService,
+		defaultNameService,
+		testtarget.BaseURL(func(string) string { return server.URL + "/api/v4" }),
+	)
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func projectJSON(pathWithNamespace string, fork bool) map[string]any {
+	p := map[string]any{
+		"id":                  1,
+		"path_with_namespace": pathWithNamespace,
+		"description":         "description of " + pathWithNamespace,
+		"web_url":             "https://" + testHost + "/" + pathWithNamespace,
+		"http_url_to_repo":    "https://" + testHost + "/" + pathWithNamespace + ".git",
+		"default_branch":      "main",
+		"visibility":          "private",
+		"last_activity_at":    "2025-01-02T03:04:05Z",
+	}
+	if fork {
+		p["forked_from_project"] = map[string]any{
+			"id":                  2,
+			"path_with_namespace": "upstream/" + pathWithNamespace,
+			"http_url_to_repo":    "https://" + testHost + "/upstream/" + pathWithNamespace + ".git",
+		}
+	}
+	return p
+}
+
+func TestGetURLOf(t *testing.T) {
+	service := testtarget.NewHostingService(nil, nil)
+	u, err := service.GetURLOf(repository.NewReference("gitlab.com", "kyoh86", "gogh"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.String() != "https://gitlab.com/kyoh86/gogh" {
+		t.Errorf("expected URL %q, got %q", "https://gitlab.com/kyoh86/gogh", u.String())
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	service := testtarget.NewHostingService(nil, nil)
+	testCases := []struct {
+		name    string
+		url     string
+		want    repository.Reference
+		wantErr bool
+	}{
+		{
+			name: "web URL",
+			url:  "https://gitlab.com/kyoh86/gogh",
+			want: repository.NewReference("gitlab.com", "kyoh86", "gogh"),
+		},
+		{
+			name: "clone URL",
+			url:  "https://gitlab.com/kyoh86/gogh.git",
+			want: repository.NewReference("gitlab.com", "kyoh86", "gogh"),
+		},
+		{
+			name: "subgroup URL",
+			url:  "https://gitlab.com/group/subgroup/project.git",
+			want: repository.NewReference("gitlab.com", "group/subgroup", "project"),
+		},
+		{
+			name:    "no name",
+			url:     "https://gitlab.com/kyoh86",
+			wantErr: true,
+		},
+		{
+			name:    "empty segment",
+			url:     "https://gitlab.com/group//project",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			if err != nil {
+				t.Fatalf("parsing URL: %v", err)
+			}
+			ref, err := service.ParseURL(u)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *ref != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, *ref)
+			}
+		})
+	}
+}
+
+func TestGetRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer dummy-token" {
+			t.Errorf("unexpected Authorization header: %q", got)
+		}
+		switch r.PathValue("id") {
+		case "kyoh86/gogh":
+			writeJSON(t, w, projectJSON("kyoh86/gogh", true))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(t, w, map[string]any{"message": "404 Project Not Found"})
+		}
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		repo, err := service.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "gogh"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.URL != "https://"+testHost+"/kyoh86/gogh" {
+			t.Errorf("unexpected URL: %q", repo.URL)
+		}
+		if repo.CloneURL != "https://"+testHost+"/kyoh86/gogh.git" {
+			t.Errorf("unexpected clone URL: %q", repo.CloneURL)
+		}
+		if !repo.Private {
+			t.Error("expected private repository")
+		}
+		if !repo.Fork || repo.Parent == nil {
+			t.Fatal("expected fork repository with parent")
+		}
+		if want := repository.NewReference(testHost, "upstream/kyoh86", "gogh"); repo.Parent.Ref != want {
+			t.Errorf("expected parent %v, got %v", want, repo.Parent.Ref)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := service.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "missing"))
+		if !errors.Is(err, testtarget.ErrNotFound) {
+			t.Errorf("expected ErrNotFound, got %v", err)
+		}
+	})
+}
+
+func TestListRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("membership"); got != "true" {
+			t.Errorf("expected membership=true, got %q", got)
+		}
+		switch query.Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(t, w, []any{
+				projectJSON("kyoh86/repo1", false),
+				projectJSON("kyoh86/fork1", true),
+			})
+		case "2":
+			writeJSON(t, w, []any{
+				projectJSON("group/sub/repo2", false),
+				projectJSON("kyoh86/repo3", false),
+			})
+		default:
+			t.Errorf("unexpected page %q", query.Get("page"))
+		}
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		opts hosting.ListRepositoryOptions
+		want []repository.Reference
+	}{
+		{
+			name: "all pages",
+			opts: hosting.ListRepositoryOptions{},
+			want: []repository.Reference{
+				repository.NewReference(testHost, "kyoh86", "repo1"),
+				repository.NewReference(testHost, "kyoh86", "fork1"),
+				repository.NewReference(testHost, "group/sub", "repo2"),
+				repository.NewReference(testHost, "kyoh86", "repo3"),
+			},
+		},
+		{
+			name: "exclude forks",
+			opts: hosting.ListRepositoryOptions{IsFork: typ.TristateFalse},
+			want: []repository.Reference{
+				repository.NewReference(testHost, "kyoh86", "repo1"),
+				repository.NewReference(testHost, "group/sub", "repo2"),
+				repository.NewReference(testHost, "kyoh86", "repo3"),
+			},
+		},
+		{
+			name: "limited",
+			opts: hosting.ListRepositoryOptions{Limit: 3},
+			want: []repository.Reference{
+				repository.NewReference(testHost, "kyoh86", "repo1"),
+				repository.NewReference(testHost, "kyoh86", "fork1"),
+				repository.NewReference(testHost, "group/sub", "repo2"),
+			},
+		},
+		{
+			name: "other host",
+			opts: hosting.ListRepositoryOptions{Host: "gitlab.com"},
+			want: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []repository.Reference
+			for repo, err := range service.ListRepository(ctx, tc.opts) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, repo.Ref)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d repositories, got %d: %v", len(tc.want), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("expected %v at %d, got %v", tc.want[i], i, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestListRepositoryOfOwner(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/namespaces/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "group/sub":
+			writeJSON(t, w, map[string]any{"id": 5, "full_path": "group/sub", "kind": "group"})
+		case "kyoh86":
+			writeJSON(t, w, map[string]any{"id": 3, "full_path": "kyoh86", "kind": "user"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	checkQuery := func(r *http.Request) {
+		query := r.URL.Query()
+		if query.Has("membership") || query.Has("owned") {
+			t.Errorf("expected no membership or owned filter, got %v", query)
+		}
+	}
+	mux.HandleFunc("GET /api/v4/groups/5/projects", func(w http.ResponseWriter, r *http.Request) {
+		checkQuery(r)
+		writeJSON(t, w, []any{projectJSON("group/sub/repo2", false)})
+	})
+	mux.HandleFunc("GET /api/v4/users/kyoh86/projects", func(w http.ResponseWriter, r *http.Request) {
+		checkQuery(r)
+		writeJSON(t, w, []any{projectJSON("kyoh86/repo1", false)})
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		owner string
+		want  []repository.Reference
+	}{
+		{owner: "group/sub", want: []repository.Reference{repository.NewReference(testHost, "group/sub", "repo2")}},
+		{owner: "kyoh86", want: []repository.Reference{repository.NewReference(testHost, "kyoh86", "repo1")}},
+		{owner: "missing", want: nil},
+	} {
+		t.Run(tc.owner, func(t *testing.T) {
+			var got []repository.Reference
+			for repo, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{Owner: tc.owner}) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, repo.Ref)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/namespaces/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "my-group" {
+			t.Errorf("unexpected namespace %q", r.PathValue("id"))
+		}
+		writeJSON(t, w, map[string]any{"id": 42, "full_path": "my-group", "kind": "group"})
+	})
+	var got map[string]any
+	mux.HandleFunc("POST /api/v4/projects", func(w http.ResponseWriter, r *http.Request) {
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		writeJSON(t, w, projectJSON(got["path"].(string), false))
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	t.Run("in user namespace", func(t *testing.T) {
+		if _, err := service.CreateRepository(ctx, repository.NewReference(testHost, "kyoh86", "new"), hosting.CreateRepositoryOptions{
+			Private:            true,
+			DisableWiki:        true,
+			PreventMergeCommit: true,
+		}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := got["namespace_id"]; ok {
+			t.Errorf("expected no namespace_id, got %v", got["namespace_id"])
+		}
+		if got["visibility"] != "private" {
+			t.Errorf("expected private visibility, got %v", got["visibility"])
+		}
+		if got["wiki_access_level"] != "disabled" {
+			t.Errorf("expected disabled wiki, got %v", got["wiki_access_level"])
+		}
+		if _, ok := got["issues_access_level"]; ok {
+			t.Errorf("expected default issues access level, got %v", got["issues_access_level"])
+		}
+		if got["merge_method"] != "ff" {
+			t.Errorf("expected ff merge method, got %v", got["merge_method"])
+		}
+	})
+
+	t.Run("in group namespace", func(t *testing.T) {
+		if _, err := service.CreateRepository(ctx, repository.NewReference(testHost, "my-group", "new"), hosting.CreateRepositoryOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got["namespace_id"] != float64(42) {
+			t.Errorf("expected namespace_id 42, got %v", got["namespace_id"])
+		}
+		if got["visibility"] != "public" {
+			t.Errorf("expected public visibility, got %v", got["visibility"])
+		}
+	})
+}
+
+func TestForkRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, projectJSON(r.PathValue("id"), false))
+	})
+	var got map[string]any
+	mux.HandleFunc("POST /api/v4/projects/{id}/fork", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "upstream/gogh" {
+			t.Errorf("unexpected source project %q", r.PathValue("id"))
+		}
+		got = nil
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		writeJSON(t, w, projectJSON("kyoh86/"+got["path"].(string), true))
+	})
+	service := setupHostingServiceTest(t, mux)
+
+	repo, err := service.ForkRepository(
+		context.Background(),
+		repository.NewReference(testHost, "upstream", "gogh"),
+		repository.NewReference(testHost, "kyoh86", "gogh-fork"),
+		hosting.ForkRepositoryOptions{DefaultBranchOnly: true},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["path"] != "gogh-fork" {
+		t.Errorf("expected path gogh-fork, got %v", got["path"])
+	}
+	if _, ok := got["namespace_path"]; ok {
+		t.Errorf("expected no namespace_path, got %v", got["namespace_path"])
+	}
+	if got["branches"] != "main" {
+		t.Errorf("expected branches main, got %v", got["branches"])
+	}
+	if want := repository.NewReference(testHost, "kyoh86", "gogh-fork"); repo.Ref != want {
+		t.Errorf("expected %v, got %v", want, repo.Ref)
+	}
+}
+
+func TestDeleteRepository(t *testing.T) {
+	var deleted string
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /api/v4/projects/{id}", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.PathValue("id")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	service := setupHostingServiceTest(t, mux)
+
+	if err := service.DeleteRepository(context.Background(), repository.NewReference(testHost, "kyoh86", "gogh")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleted != "kyoh86/gogh" {
+		t.Errorf("expected to delete kyoh86/gogh, got %q", deleted)
+	}
+}
+
+func TestGetTokenForGroup(t *testing.T) {
+	var pages []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("min_access_level"); got != "10" {
+			t.Errorf("expected min_access_level=10, got %q", got)
+		}
+		pages = append(pages, query.Get("page"))
+		switch query.Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(t, w, []any{
+				map[string]any{"id": 1, "full_path": "first"},
+			})
+		case "2":
+			writeJSON(t, w, []any{
+				map[string]any{"id": 2, "full_path": "group/sub"},
+			})
+		default:
+			t.Errorf("unexpected page %q", query.Get("page"))
+		}
+	})
+	service := setupHostingServiceTest(t, mux)
+	ctx := context.Background()
+
+	for _, testcase := range []struct {
+		owner string
+		pages []string
+	}{
+		{owner: "first", pages: []string{"1"}},
+		{owner: "group/sub", pages: []string{"1", "2"}},
+	} {
+		t.Run(testcase.owner, func(t *testing.T) {
+			pages = nil
+			tokenOwner, token, err := service.GetTokenFor(ctx, testHost, testcase.owner)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tokenOwner != "kyoh86" || token.AccessToken != "dummy-token" {
+				t.Errorf("expected the token of kyoh86, got %q %v", tokenOwner, token)
+			}
+			if !slices.Equal(pages, testcase.pages) {
+				t.Errorf("expected to request pages %v, got %v", testcase.pages, pages)
+			}
+		})
+	}
+}
+
+func TestGetTokenForConcurrently(t *testing.T) {
+	service := setupHostingServiceTest(t, http.NewServeMux())
+	ctx := context.Background()
+
+	// Concurrent calls (e.g. from the workers of pull) share the cache safely
+	var wg sync.WaitGroup
+	for range 32 {
+		wg.Go(func() {
+			tokenOwner, _, err := service.GetTokenFor(ctx, testHost, "kyoh86")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tokenOwner != "kyoh86" {
+				t.Errorf("expected the token of kyoh86, got %q", tokenOwner)
+			}
+		})
+	}
+	wg.Wait()
+}
@@ -0,0 +1,165 @@
+// Package restapi provides a minimal JSON REST client shared by the hosting services
+// which do not have an official SDK in use (e.g. GitLab and Gitea).
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when the API responds with 404 Not Found
+var ErrNotFound = errors.New("not found")
+
+// Client is a minimal client for a JSON REST API
+type Client struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new Client.
+// The name is the name of the API used in the error messages (e.g. "gitlab").
+func NewClient(name, baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		name:       name,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+	}
+}
+
+// Error represents an error response from the API
+type Error struct {
+	API        string
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s api responds %d %s", e.API, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s api responds %d %s: %s", e.API, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// parseError builds an Error from the response body.
+// The APIs return errors as {"message": ...} or {"error": ...}, and the message may be an object (GitLab).
+func (c *Client) parseError(resp *http.Response) error {
+	var body struct {
+		Message json.RawMessage `json:"message"`
+		Error   string          `json:"error"`
+	}
+	apiErr := &Error{API: c.name, StatusCode: resp.StatusCode}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil || len(raw) == 0 {
+		return apiErr
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return apiErr
+	}
+	var message string
+	if err := json.Unmarshal(body.Message, &message); err == nil {
+		apiErr.Message = message
+	} else if len(body.Message) > 0 {
+		apiErr.Message = string(body.Message)
+	} else {
+		apiErr.Message = body.Error
+	}
+	return apiErr
+}
+
+// Do sends a request to the API and decodes the JSON response into out (if not nil)
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, in, out any) (*http.Response, error) {
+	u := strings.TrimSuffix(c.baseURL, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+		body = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, c.parseError(resp)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// NextPage returns the number of the page following the current one from its response and body,
+// or 0 if the current one is the last page.
+type NextPage[T any] func(resp *http.Response, current int, body T) int
+
+// NextPageHeader reads the number of the next page from the "X-Next-Page" header (GitLab)
+func NextPageHeader[T any](resp *http.Response, _ int, _ T) int {
+	next, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	if err != nil {
+		return 0
+	}
+	return next
+}
+
+// Pages sends GET requests for the pages of the list from the first one,
+// and yields the decoded body of each page until next returns 0.
+// The "page" parameter is set to the query for each page.
+func Pages[T any](ctx context.Context, c *Client, path string, query url.Values, next NextPage[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		query := maps.Clone(query)
+		if query == nil {
+			query = url.Values{}
+		}
+		for page := 1; page > 0; {
+			var body T
+			if err := ctx.Err(); err != nil {
+				yield(body, err)
+				return
+			}
+			query.Set("page", strconv.Itoa(page))
+			resp, err := c.Do(ctx, http.MethodGet, path, query, nil, &body)
+			if err != nil {
+				yield(body, err)
+				return
+			}
+			if !yield(body, nil) {
+				return
+			}
+			page = next(resp, page, body)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	testtarget "github.com/kyoh86/gogh/v4/infra/internal/restapi"
+)
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func setupClient(t *testing.T, handler http.Handler) *testtarget.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return testtarget.NewClient("test", server.URL+"/api/", server.Client())
+}
+
+func TestClientDo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/items", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("expected Content-Type application/json, got %q", got)
+		}
+		if got := r.URL.Query().Get("q"); got != "a b" {
+			t.Errorf("expected query q=%q, got %q", "a b", got)
+		}
+		var in map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		writeJSON(t, w, http.StatusCreated, map[string]string{"name": in["name"]})
+	})
+	mux.HandleFunc("GET /api/missing", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "404 Project Not Found"})
+	})
+	mux.HandleFunc("GET /api/invalid", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusBadRequest, map[string]any{"message": map[string][]string{"name": {"is invalid"}}})
+	})
+	mux.HandleFunc("GET /api/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusForbidden, map[string]string{"error": "insufficient_scope"})
+	})
+	mux.HandleFunc("GET /api/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	c := setupClient(t, mux)
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		var out map[string]string
+		if _, err := c.Do(ctx, http.MethodPost, "/items", url.Values{"q": {"a b"}}, map[string]string{"name": "gogh"}, &out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out["name"] != "gogh" {
+			t.Errorf("expected name gogh, got %q", out["name"])
+		}
+	})
+
+	for _, testcase := range []struct {
+		path     string
+		wantMsg  string
+		notFound bool
+	}{
+		{path: "/missing", wantMsg: "test api responds 404 Not Found: 404 Project Not Found", notFound: true},
+		{path: "/invalid", wantMsg: `test api responds 400 Bad Request: {"name":["is invalid"]}`},
+		{path: "/forbidden", wantMsg: "test api responds 403 Forbidden: insufficient_scope"},
+		{path: "/empty", wantMsg: "test api responds 500 Internal Server Error"},
+	} {
+		t.Run(testcase.path, func(t *testing.T) {
+			_, err := c.Do(ctx, http.MethodGet, testcase.path, nil, nil, nil)
+			var apiErr *testtarget.Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an api error, got %v", err)
+			}
+			if err.Error() != testcase.wantMsg {
+				t.Errorf("expected %q, got %q", testcase.wantMsg, err.Error())
+			}
+			if got := errors.Is(err, testtarget.ErrNotFound); got != testcase.notFound {
+				t.Errorf("expected errors.Is(err, ErrNotFound) to be %v", testcase.notFound)
+			}
+		})
+	}
+}
+
+func TestPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/items", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("per_page"); got != "2" {
+			t.Errorf("expected per_page=2, got %q", got)
+		}
+		switch query.Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(t, w, http.StatusOK, []string{"a", "b"})
+		case "2":
+			writeJSON(t, w, http.StatusOK, []string{"c"})
+		default:
+			writeJSON(t, w, http.StatusBadRequest, map[string]string{"message": "unexpected page " + query.Get("page")})
+		}
+	})
+	c := setupClient(t, mux)
+	ctx := context.Background()
+	query := url.Values{"per_page": {"2"}}
+
+	collect := func(t *testing.T, next testtarget.NextPage[[]string]) []string {
+		t.Helper()
+		var got []string
+		for items, err := range testtarget.Pages(ctx, c, "/items", query, next) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, items...)
+		}
+		return got
+	}
+
+	t.Run("next page header", func(t *testing.T) {
+		if got, want := collect(t, testtarget.NextPageHeader[[]string]), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("next page number", func(t *testing.T) {
+		got := collect(t, func(_ *http.Response, current int, items []string) int {
+			if len(items) < 2 {
+				return 0
+			}
+			return current + 1
+		})
+		if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("query is not modified", func(t *testing.T) {
+		collect(t, testtarget.NextPageHeader[[]string])
+		if query.Has("page") {
+			t.Errorf("expected the query not to be modified, got %v", query)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var count int
+		for _, err := range testtarget.Pages(ctx, c, "/items", query, func(_ *http.Response, current int, _ []string) int {
+			return current + 2
+		}) {
+			count++
+			if count == 2 && err == nil {
+				t.Error("expected an error for page 3")
+			}
+		}
+		if count != 2 {
+			t.Errorf("expected to stop at the error, but got %d pages", count)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		for _, err := range testtarget.Pages(ctx, c, "/items", query, testtarget.NextPageHeader[[]string]) {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context.Canceled, got %v", err)
+			}
+		}
+	})
+}
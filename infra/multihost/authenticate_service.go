@@ -0,0 +1,30 @@
+package multihost
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+)
+
+// AuthenticateService is an auth.AuthenticateService which dispatches
+// the authentication to the service for the host.
+type AuthenticateService struct {
+	selector Selector[auth.AuthenticateService]
+}
+
+// NewAuthenticateService creates a new AuthenticateService instance
+func NewAuthenticateService(selector Selector[auth.AuthenticateService]) *AuthenticateService {
+	return &AuthenticateService{selector: selector}
+}
+
+// Authenticate implements auth.AuthenticateService.
+func (s *AuthenticateService) Authenticate(ctx context.Context, host string, verify auth.Verify) (string, *auth.Token, error) {
+	return s.selector(host).Authenticate(ctx, host, verify)
+}
+
+// AuthenticateWithToken implements auth.AuthenticateService.
+func (s *AuthenticateService) AuthenticateWithToken(ctx context.Context, host string, accessToken string) (string, *auth.Token, error) {
+	return s.selector(host).AuthenticateWithToken(ctx, host, accessToken)
+}
+
+var _ auth.AuthenticateService = (*AuthenticateService)(nil)
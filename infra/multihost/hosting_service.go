@@ -0,0 +1,129 @@
+package multihost
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"slices"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+)
+
+// Selector returns the service which handles the host
+type Selector[T any] func(host string) T
+
+// HostingService is a hosting.HostingService which dispatches each call
+// to the service for the host of the target repository.
+type HostingService struct {
+	tokenService auth.TokenService
+	selector     Selector[hosting.HostingService]
+}
+
+// NewHostingService creates a new HostingService instance
+func NewHostingService(tokenService auth.TokenService, selector Selector[hosting.HostingService]) *HostingService {
+	return &HostingService{
+		tokenService: tokenService,
+		selector:     selector,
+	}
+}
+
+// GetURLOf implements hosting.HostingService.
+func (s *HostingService) GetURLOf(ref repository.Reference) (*url.URL, error) {
+	return s.selector(ref.Host()).GetURLOf(ref)
+}
+
+// ParseURL implements hosting.HostingService.
+func (s *HostingService) ParseURL(u *url.URL) (*repository.Reference, error) {
+	return s.selector(u.Host).ParseURL(u)
+}
+
+// GetTokenFor implements hosting.HostingService.
+func (s *HostingService) GetTokenFor(ctx context.Context, host, owner string) (string, auth.Token, error) {
+	return s.selector(host).GetTokenFor(ctx, host, owner)
+}
+
+// GetRepository implements hosting.HostingService.
+func (s *HostingService) GetRepository(ctx context.Context, ref repository.Reference) (*hosting.Repository, error) {
+	return s.selector(ref.Host()).GetRepository(ctx, ref)
+}
+
+// hosts returns the hosts which have any token in the sorted order
+func (s *HostingService) hosts() []string {
+	var hosts []string
+	for _, entry := range s.tokenService.Entries() {
+		if !slices.Contains(hosts, entry.Host) {
+			hosts = append(hosts, entry.Host)
+		}
+	}
+	slices.Sort(hosts)
+	return hosts
+}
+
+// ListRepository implements hosting.HostingService.
+// If the host is not specified in the options, it lists up repositories on each host which has a token.
+func (s *HostingService) ListRepository(ctx context.Context, opts hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+	if opts.Host != "" {
+		return s.selector(opts.Host).ListRepository(ctx, opts)
+	}
+	return func(yield func(*hosting.Repository, error) bool) {
+		var count int
+		for _, host := range s.hosts() {
+			hostOpts := opts
+			hostOpts.Host = host
+			if opts.Limit > 0 {
+				hostOpts.Limit = opts.Limit - count
+			}
+			for repo, err := range s.selector(host).ListRepository(ctx, hostOpts) {
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if !yield(repo, nil) {
+					return
+				}
+				count++
+				if opts.Limit > 0 && count >= opts.Limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+// DeleteRepository implements hosting.HostingService.
+func (s *HostingService) DeleteRepository(ctx context.Context, ref repository.Reference) error {
+	return s.selector(ref.Host()).DeleteRepository(ctx, ref)
+}
+
+// CreateRepository implements hosting.HostingService.
+func (s *HostingService) CreateRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	opts hosting.CreateRepositoryOptions,
+) (*hosting.Repository, error) {
+	return s.selector(ref.Host()).CreateRepository(ctx, ref, opts)
+}
+
+// CreateRepositoryFromTemplate implements hosting.HostingService.
+func (s *HostingService) CreateRepositoryFromTemplate(
+	ctx context.Context,
+	ref repository.Reference,
+	tmp repository.Reference,
+	opts hosting.CreateRepositoryFromTemplateOptions,
+) (*hosting.Repository, error) {
+	return s.selector(ref.Host()).CreateRepositoryFromTemplate(ctx, ref, tmp, opts)
+}
+
+// ForkRepository implements hosting.HostingService.
+func (s *HostingService) ForkRepository(
+	ctx context.Context,
+	ref repository.Reference,
+	target repository.Reference,
+	opts hosting.ForkRepositoryOptions,
+) (*hosting.Repository, error) {
+	return s.selector(ref.Host()).ForkRepository(ctx, ref, target, opts)
+}
+
+var _ hosting.HostingService = (*HostingService)(nil)
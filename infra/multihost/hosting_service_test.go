@@ -0,0 +1,101 @@
+package multihost_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/auth_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/hosting_mock"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	testtarget "github.com/kyoh86/gogh/v4/infra/multihost"
+	"go.uber.org/mock/gomock"
+)
+
+func repositories(host string, names ...string) iter.Seq2[*hosting.Repository, error] {
+	return func(yield func(*hosting.Repository, error) bool) {
+		for _, name := range names {
+			if !yield(&hosting.Repository{Ref: repository.NewReference(host, "kyoh86", name)}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestHostingService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Entries().Return([]auth.TokenEntry{
+		{Host: "gitlab.com", Owner: "kyoh86"},
+		{Host: "github.com", Owner: "kyoh86"},
+		{Host: "github.com", Owner: "kyoh86-tryouts"},
+	}).AnyTimes()
+	github := hosting_mock.NewMockHostingService(ctrl)
+	gitlab := hosting_mock.NewMockHostingService(ctrl)
+	service := testtarget.NewHostingService(tokenService, func(host string) hosting.HostingService {
+		if host == "gitlab.com" {
+			return gitlab
+		}
+		return github
+	})
+	ctx := context.Background()
+
+	t.Run("dispatch by reference", func(t *testing.T) {
+		ref := repository.NewReference("gitlab.com", "kyoh86", "gogh")
+		gitlab.EXPECT().GetRepository(ctx, ref).Return(&hosting.Repository{Ref: ref}, nil)
+		repo, err := service.GetRepository(ctx, ref)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.Ref != ref {
+			t.Errorf("expected %v, got %v", ref, repo.Ref)
+		}
+	})
+
+	t.Run("list on the host", func(t *testing.T) {
+		gitlab.EXPECT().
+			ListRepository(ctx, hosting.ListRepositoryOptions{Host: "gitlab.com"}).
+			Return(repositories("gitlab.com", "gogh"))
+		var count int
+		for _, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{Host: "gitlab.com"}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+		}
+		if count != 1 {
+			t.Errorf("expected 1 repository, got %d", count)
+		}
+	})
+
+	t.Run("list on all hosts with limit", func(t *testing.T) {
+		github.EXPECT().
+			ListRepository(ctx, hosting.ListRepositoryOptions{Host: "github.com", Limit: 3}).
+			Return(repositories("github.com", "gogh", "vim-ripgrep"))
+		gitlab.EXPECT().
+			ListRepository(ctx, hosting.ListRepositoryOptions{Host: "gitlab.com", Limit: 1}).
+			Return(repositories("gitlab.com", "gogh", "dotfiles"))
+		var got []repository.Reference
+		for repo, err := range service.ListRepository(ctx, hosting.ListRepositoryOptions{Limit: 3}) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got = append(got, repo.Ref)
+		}
+		want := []repository.Reference{
+			repository.NewReference("github.com", "kyoh86", "gogh"),
+			repository.NewReference("github.com", "kyoh86", "vim-ripgrep"),
+			repository.NewReference("gitlab.com", "kyoh86", "gogh"),
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("expected %v at %d, got %v", want[i], i, got[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package plaingit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/core/auth"
+)
+
+// AuthenticateService is an auth.AuthenticateService for plain git servers.
+// They have no API to authenticate users, so all of the methods fail.
+type AuthenticateService struct{}
+
+// NewAuthenticateService creates a new AuthenticateService instance
+func NewAuthenticateService() *AuthenticateService {
+	return &AuthenticateService{}
+}
+
+// Authenticate implements auth.AuthenticateService.
+func (s *AuthenticateService) Authenticate(_ context.Context, host string, _ auth.Verify) (string, *auth.Token, error) {
+	return "", nil, fmt.Errorf("login to %s: %w", host, ErrUnsupported)
+}
+
+// AuthenticateWithToken implements auth.AuthenticateService.
+func (s *AuthenticateService) AuthenticateWithToken(_ context.Context, host string, _ string) (string, *auth.Token, error) {
+	return "", nil, fmt.Errorf("login to %s: %w", host, ErrUnsupported)
+}
+
+var _ auth.AuthenticateService = (*AuthenticateService)(nil)
@@ -0,0 +1,164 @@
+package plaingit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	"github.com/kyoh86/gogh/v4/typ"
+)
+
+// ErrUnsupported is returned when the operation requires the API of the hosting service
+var ErrUnsupported = errors.New("unsupported by a plain git server which has no API")
+
+// HostingService is a hosting.HostingService for plain git servers without any API
+// (e.g.: gitolite, or a host serving bare repositories over SSH).
+type HostingService struct {
+	tokenService auth.TokenService
+	cloneURL     func(host string) string
+}
+
+// Option configures the HostingService
+type Option func(*HostingService)
+
+// CloneURL sets a function to resolve the template of the clone URL for a host.
+// See hosting.Provider.CloneURL for the syntax of the template.
+var CloneURL = func(f func(host string) string) Option {
+	return func(s *HostingService) {
+		s.cloneURL = f
+	}
+}
+
+// NewHostingService creates a new HostingService instance
+func NewHostingService(tokenService auth.TokenService, options ...Option) *HostingService {
+	s := &HostingService{
+		tokenService: tokenService,
+		cloneURL:     func(string) string { return hosting.DefaultCloneURLTemplate },
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// GetURLOf implements hosting.HostingService.
+func (s *HostingService) GetURLOf(ref repository.Reference) (*url.URL, error) {
+	return &url.URL{
+		Scheme: "https",
+		Host:   ref.Host(),
+		Path:   strings.Join([]string{ref.Owner(), ref.Name()}, "/"),
+	}, nil
+}
+
+// ParseURL implements hosting.HostingService.
+func (s *HostingService) ParseURL(u *url.URL) (*repository.Reference, error) {
+	path := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	words := strings.Split(path, "/")
+	if len(words) < 2 || slices.Contains(words, "") {
+		return nil, fmt.Errorf("invalid path: %q", u.Path)
+	}
+	// The owner may be a nested namespace like "<group>/<subgroup>"
+	owner, name := strings.Join(words[:len(words)-1], "/"), words[len(words)-1]
+	return typ.Ptr(repository.NewReference(u.Host, owner, name)), nil
+}
+
+// GetTokenFor implements hosting.HostingService.
+// Plain git servers are usually accessed with SSH keys, so it returns an empty token
+// without any error if no token is stored for the host.
+func (s *HostingService) GetTokenFor(_ context.Context, host, owner string) (string, auth.Token, error) {
+	if s.tokenService.Has(host, owner) {
+		token, err := s.tokenService.Get(host, owner)
+		return owner, token, err
+	}
+	for _, entry := range s.tokenService.Entries() {
+		if entry.Host == host {
+			return entry.Owner, entry.Token, nil
+		}
+	}
+	return "", auth.Token{}, nil
+}
+
+// GetRepository implements hosting.HostingService.
+// It builds the repository from the template of the clone URL,
+// and checks that the remote exists by listing its references (like `git ls-remote`).
+func (s *HostingService) GetRepository(ctx context.Context, ref repository.Reference) (*hosting.Repository, error) {
+	provider := hosting.Provider{Host: ref.Host(), Kind: hosting.ProviderKindGit, CloneURL: s.cloneURL(ref.Host())}
+	cloneURL, err := provider.CloneURLFor(ref)
+	if err != nil {
+		return nil, err
+	}
+	user, token, err := s.GetTokenFor(ctx, ref.Host(), ref.Owner())
+	if err != nil {
+		return nil, fmt.Errorf("getting token for %s/%s: %w", ref.Host(), ref.Owner(), err)
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{cloneURL},
+	})
+	if _, err := remote.ListContext(ctx, &git.ListOptions{Auth: authMethod(cloneURL, user, token)}); err != nil && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil, fmt.Errorf("listing remote references of %s: %w", cloneURL, err)
+	}
+
+	u, err := s.GetURLOf(ref)
+	if err != nil {
+		return nil, err
+	}
+	return &hosting.Repository{
+		Ref:      ref,
+		URL:      u.String(),
+		CloneURL: cloneURL,
+	}, nil
+}
+
+// authMethod builds the authentication for the remote.
+// The token is used only for http(s) remotes; others use the default method of the transport (e.g. ssh-agent).
+func authMethod(cloneURL, user string, token auth.Token) transport.AuthMethod {
+	if token.AccessToken == "" {
+		return nil
+	}
+	if !strings.HasPrefix(cloneURL, "https://") && !strings.HasPrefix(cloneURL, "http://") {
+		return nil
+	}
+	return &http.BasicAuth{Username: user, Password: token.AccessToken}
+}
+
+// ListRepository implements hosting.HostingService.
+// Plain git servers cannot list repositories, so it yields nothing.
+func (s *HostingService) ListRepository(context.Context, hosting.ListRepositoryOptions) iter.Seq2[*hosting.Repository, error] {
+	return func(func(*hosting.Repository, error) bool) {}
+}
+
+// DeleteRepository implements hosting.HostingService.
+func (s *HostingService) DeleteRepository(_ context.Context, ref repository.Reference) error {
+	return fmt.Errorf("deleting repository %s: %w", ref, ErrUnsupported)
+}
+
+// CreateRepository implements hosting.HostingService.
+func (s *HostingService) CreateRepository(_ context.Context, ref repository.Reference, _ hosting.CreateRepositoryOptions) (*hosting.Repository, error) {
+	return nil, fmt.Errorf("creating repository %s: %w", ref, ErrUnsupported)
+}
+
+// CreateRepositoryFromTemplate implements hosting.HostingService.
+func (s *HostingService) CreateRepositoryFromTemplate(_ context.Context, ref repository.Reference, _ repository.Reference, _ hosting.CreateRepositoryFromTemplateOptions) (*hosting.Repository, error) {
+	return nil, fmt.Errorf("creating repository %s from template: %w", ref, ErrUnsupported)
+}
+
+// ForkRepository implements hosting.HostingService.
+func (s *HostingService) ForkRepository(_ context.Context, ref repository.Reference, _ repository.Reference, _ hosting.ForkRepositoryOptions) (*hosting.Repository, error) {
+	return nil, fmt.Errorf("forking repository %s: %w", ref, ErrUnsupported)
+}
+
+var _ hosting.HostingService = (*HostingService)(nil)
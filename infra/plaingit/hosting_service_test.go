@@ -0,0 +1,158 @@
+package plaingit_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/kyoh86/gogh/v4/core/auth"
+	"github.com/kyoh86/gogh/v4/core/auth_mock"
+	"github.com/kyoh86/gogh/v4/core/hosting"
+	"github.com/kyoh86/gogh/v4/core/repository"
+	testtarget "github.com/kyoh86/gogh/v4/infra/plaingit"
+	"go.uber.org/mock/gomock"
+)
+
+const testHost = "git.example.com"
+
+// setupHostingServiceTest creates a HostingService which clones repositories
+// from bare repositories under the temporary directory, and has no token.
+func setupHostingServiceTest(t *testing.T) (string, *testtarget.HostingService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	root := t.TempDir()
+
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Has(testHost, gomock.Any()).Return(false).AnyTimes()
+	tokenService.EXPECT().Entries().Return([]auth.TokenEntry{}).AnyTimes()
+
+	return root, testtarget.NewHostingService(
+		tokenService,
+		testtarget.CloneURL(func(string) string { return "file://" + filepath.ToSlash(root) + "/{{.Owner}}/{{.Name}}.git" }),
+	)
+}
+
+func TestGetRepository(t *testing.T) {
+	ctx := context.Background()
+	root, svc := setupHostingServiceTest(t)
+	if _, err := git.PlainInit(filepath.Join(root, "kyoh86", "gogh.git"), true); err != nil {
+		t.Fatalf("failed to init bare repository: %v", err)
+	}
+
+	t.Run("existing repository", func(t *testing.T) {
+		repo, err := svc.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "gogh"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := "file://" + filepath.ToSlash(root) + "/kyoh86/gogh.git"; repo.CloneURL != want {
+			t.Errorf("expected clone URL %q, got %q", want, repo.CloneURL)
+		}
+		if want := "https://git.example.com/kyoh86/gogh"; repo.URL != want {
+			t.Errorf("expected URL %q, got %q", want, repo.URL)
+		}
+		if repo.Ref.String() != "git.example.com/kyoh86/gogh" {
+			t.Errorf("unexpected ref: %s", repo.Ref)
+		}
+	})
+
+	t.Run("missing repository", func(t *testing.T) {
+		if _, err := svc.GetRepository(ctx, repository.NewReference(testHost, "kyoh86", "missing")); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestGetRepositoryInvalidTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Has(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	tokenService.EXPECT().Entries().Return(nil).AnyTimes()
+	svc := testtarget.NewHostingService(tokenService, testtarget.CloneURL(func(string) string { return "{{.Invalid" }))
+	if _, err := svc.GetRepository(context.Background(), repository.NewReference(testHost, "kyoh86", "gogh")); err == nil {
+		t.Error("expected error for the invalid template, got nil")
+	}
+}
+
+func TestGetTokenFor(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	tokenService := auth_mock.NewMockTokenService(ctrl)
+	tokenService.EXPECT().Has(testHost, "kyoh86").Return(true).AnyTimes()
+	tokenService.EXPECT().Has(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+	tokenService.EXPECT().Get(testHost, "kyoh86").Return(auth.Token{AccessToken: "dummy-token"}, nil).AnyTimes()
+	tokenService.EXPECT().Entries().Return([]auth.TokenEntry{
+		{Host: testHost, Owner: "kyoh86", Token: auth.Token{AccessToken: "dummy-token"}},
+	}).AnyTimes()
+	svc := testtarget.NewHostingService(tokenService)
+
+	for _, tc := range []struct {
+		host, owner string
+		wantUser    string
+		wantToken   string
+	}{
+		{host: testHost, owner: "kyoh86", wantUser: "kyoh86", wantToken: "dummy-token"},
+		{host: testHost, owner: "other", wantUser: "kyoh86", wantToken: "dummy-token"},
+		{host: "other.example.com", owner: "kyoh86"},
+	} {
+		user, token, err := svc.GetTokenFor(ctx, tc.host, tc.owner)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user != tc.wantUser || token.AccessToken != tc.wantToken {
+			t.Errorf("GetTokenFor(%q, %q) = %q, %q; want %q, %q", tc.host, tc.owner, user, token.AccessToken, tc.wantUser, tc.wantToken)
+		}
+	}
+}
+
+func TestListRepository(t *testing.T) {
+	_, svc := setupHostingServiceTest(t)
+	for repo, err := range svc.ListRepository(context.Background(), hosting.ListRepositoryOptions{}) {
+		t.Errorf("expected nothing, got %v, %v", repo, err)
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	_, svc := setupHostingServiceTest(t)
+	ref, err := svc.ParseURL(&url.URL{Scheme: "https", Host: testHost, Path: "/kyoh86/gogh.git"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref.String() != "git.example.com/kyoh86/gogh" {
+		t.Errorf("unexpected ref: %s", ref)
+	}
+	if _, err := svc.ParseURL(&url.URL{Scheme: "https", Host: testHost, Path: "/kyoh86"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+	nested, err := svc.ParseURL(&url.URL{Scheme: "https", Host: testHost, Path: "/group/subgroup/project.git"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nested.Owner() != "group/subgroup" || nested.Name() != "project" {
+		t.Errorf("unexpected ref: %s", nested)
+	}
+}
+
+func TestUnsupportedOperations(t *testing.T) {
+	ctx := context.Background()
+	_, svc := setupHostingServiceTest(t)
+	ref := repository.NewReference(testHost, "kyoh86", "gogh")
+
+	if err := svc.DeleteRepository(ctx, ref); !errors.Is(err, testtarget.ErrUnsupported) {
+		t.Errorf("DeleteRepository: expected ErrUnsupported, got %v", err)
+	}
+	if _, err := svc.CreateRepository(ctx, ref, hosting.CreateRepositoryOptions{}); !errors.Is(err, testtarget.ErrUnsupported) {
+		t.Errorf("CreateRepository: expected ErrUnsupported, got %v", err)
+	}
+	if _, err := svc.CreateRepositoryFromTemplate(ctx, ref, ref, hosting.CreateRepositoryFromTemplateOptions{}); !errors.Is(err, testtarget.ErrUnsupported) {
+		t.Errorf("CreateRepositoryFromTemplate: expected ErrUnsupported, got %v", err)
+	}
+	if _, err := svc.ForkRepository(ctx, ref, ref, hosting.ForkRepositoryOptions{}); !errors.Is(err, testtarget.ErrUnsupported) {
+		t.Errorf("ForkRepository: expected ErrUnsupported, got %v", err)
+	}
+	if _, _, err := testtarget.NewAuthenticateService().Authenticate(ctx, testHost, nil); !errors.Is(err, testtarget.ErrUnsupported) {
+		t.Errorf("Authenticate: expected ErrUnsupported, got %v", err)
+	}
+}
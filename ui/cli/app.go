@@ -53,6 +53,9 @@ func NewApp(
 			if err := svc.TokenStore.Save(ctx, svc.TokenService, false); err != nil {
 				return fmt.Errorf("saving tokens: %w", err)
 			}
+			if err := svc.ProviderStore.Save(ctx, svc.ProviderService, false); err != nil {
+				return fmt.Errorf("saving providers: %w", err)
+			}
 			if err := svc.WorkspaceStore.Save(ctx, svc.WorkspaceService, false); err != nil {
 				return fmt.Errorf("saving workspaces: %w", err)
 			}
@@ -71,6 +74,15 @@ func NewApp(
 			if err := svc.ExtraStore.Save(ctx, svc.ExtraService, false); err != nil {
 				return fmt.Errorf("saving extra: %w", err)
 			}
+			if err := svc.IndexStore.Save(ctx, svc.IndexService, false); err != nil {
+				return fmt.Errorf("saving index: %w", err)
+			}
+			if err := svc.TagStore.Save(ctx, svc.TagService, false); err != nil {
+				return fmt.Errorf("saving tags: %w", err)
+			}
+			if err := svc.UsageStore.Save(ctx, svc.UsageService, false); err != nil {
+				return fmt.Errorf("saving usage: %w", err)
+			}
 			return nil
 		},
 	}
@@ -124,10 +136,22 @@ func NewApp(
 	}
 	authCommand.GroupID = groupConfig
 
+	rootsRouteCommand, err := cmdWithSubs(
+		ctx, svc,
+		commands.NewRootsRouteCommand,
+		nil,
+		commands.NewRootsRouteAddCommand,
+		commands.NewRootsRouteRemoveCommand,
+		commands.NewRootsRouteListCommand,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	rootsCommand, err := cmdWithSubs(
 		ctx, svc,
 		commands.NewRootsCommand,
-		nil,
+		[]*cobra.Command{rootsRouteCommand},
 		commands.NewRootsSetPrimaryCommand,
 		commands.NewRootsRemoveCommand,
 		commands.NewRootsAddCommand,
@@ -207,6 +231,42 @@ func NewApp(
 	}
 	extraCommand.GroupID = groupAutomation
 
+	configHostCommand, err := cmdWithSubs(
+		ctx, svc,
+		commands.NewConfigHostCommand,
+		nil,
+		commands.NewConfigHostListCommand,
+		commands.NewConfigHostSetCommand,
+		commands.NewConfigHostRemoveCommand,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	doctorCommand, err := cmdWithSubs(
+		ctx, svc,
+		commands.NewDoctorCommand,
+		nil,
+		commands.NewDoctorWorkspaceCommand,
+	)
+	if err != nil {
+		return nil, err
+	}
+	doctorCommand.GroupID = groupConfig
+
+	tagCommand, err := cmdWithSubs(
+		ctx, svc,
+		commands.NewTagCommand,
+		nil,
+		commands.NewTagAddCommand,
+		commands.NewTagRemoveCommand,
+		commands.NewTagListCommand,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tagCommand.GroupID = groupManipulate
+
 	configAuthCommand := typ.Ptr(*authCommand)
 	configAuthCommand.GroupID = ""
 	configRootsCommand := typ.Ptr(*rootsCommand)
@@ -214,7 +274,7 @@ func NewApp(
 	configCommand, err := cmdWithSubs(
 		ctx, svc,
 		commands.NewConfigCommand,
-		[]*cobra.Command{configAuthCommand, configRootsCommand},
+		[]*cobra.Command{configAuthCommand, configRootsCommand, configHostCommand},
 		commands.NewConfigShowCommand,
 		commands.NewSetDefaultHostCommand,
 		commands.NewSetDefaultOwnerCommand,
@@ -234,19 +294,30 @@ func NewApp(
 		scriptCommand,
 		hookCommand,
 		extraCommand,
+		doctorCommand,
+		tagCommand,
 	}
 	for _, sub := range []struct {
 		fn    func(context.Context, *service.ServiceSet) (*cobra.Command, error)
 		group string
 	}{
 		{fn: commands.NewManCommand},
+		{fn: commands.NewShellInitCommand},
 		{fn: commands.NewCwdCommand, group: groupShow},
+		{fn: commands.NewJumpCommand, group: groupShow},
 		{fn: commands.NewListCommand, group: groupShow},
 		{fn: commands.NewCloneCommand, group: groupManipulate},
 		{fn: commands.NewCreateCommand, group: groupManipulate},
 		{fn: commands.NewReposCommand, group: groupShow},
+		{fn: commands.NewStatusCommand, group: groupShow},
 		{fn: commands.NewDeleteCommand, group: groupManipulate},
+		{fn: commands.NewMoveCommand, group: groupManipulate},
+		{fn: commands.NewAdoptCommand, group: groupManipulate},
 		{fn: commands.NewForkCommand, group: groupManipulate},
+		{fn: commands.NewPullCommand, group: groupManipulate},
+		{fn: commands.NewPruneBranchesCommand, group: groupManipulate},
+		{fn: commands.NewGCCommand, group: groupManipulate},
+		{fn: commands.NewReindexCommand, group: groupConfig},
 	} {
 		c, err := sub.fn(ctx, svc)
 		if err != nil {
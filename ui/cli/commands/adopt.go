@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/adopt"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewAdoptCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		adopt.Options
+		scan []string
+	}
+	cmd := &cobra.Command{
+		Use:   "adopt [flags] [<path>...]",
+		Short: "Adopt existing clones from outside the roots",
+		Long: `Adopt existing clones from outside the roots.
+
+It reads the default remote (usually "origin") of each repository,
+and moves it into the layout path of the remote in the root chosen by --root,
+or the root chosen by the routes (or the primary root) if it is omitted.
+With --symlink, it creates a symbolic link in the root instead of moving the repository.
+With --scan, it discovers the git repositories under the directory recursively.
+Repositories which conflict with existing clones are reported and left as they are.`,
+		Example: `  gogh adopt ~/src/gogh
+  gogh adopt --scan ~/src --dry-run
+  gogh adopt --scan ~/go/src --symlink`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			logger := log.FromContext(ctx)
+			uc := adopt.NewUsecase(
+				svc.WorkspaceService,
+				svc.IndexService,
+				svc.HostingService,
+				svc.GitService,
+			)
+			paths := args
+			for _, dir := range f.scan {
+				for path, err := range uc.Scan(ctx, dir) {
+					if err != nil {
+						return fmt.Errorf("scanning %s: %w", dir, err)
+					}
+					paths = append(paths, path)
+				}
+			}
+			if len(paths) == 0 {
+				return errors.New("no repository to adopt: specify paths or --scan")
+			}
+
+			var failed int
+			for _, path := range paths {
+				result, err := uc.Execute(ctx, path, f.Options)
+				switch {
+				case errors.Is(err, adopt.ErrAlreadyAdopted):
+					logger.Debugf("Skipped %s: already adopted", path)
+				case errors.Is(err, adopt.ErrConflict):
+					failed++
+					logger.Warnf("Conflict %s: %s already exists", path, result.Location.FullPath())
+				case err != nil:
+					failed++
+					logger.Errorf("Failed to adopt %s: %v", path, err)
+				case f.DryRun:
+					logger.Infof("Would adopt %s as %s", path, result.Location.FullPath())
+				default:
+					logger.Infof("Adopted %s as %s", path, result.Location.FullPath())
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("failed to adopt %d of %d repositories", failed, len(paths))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringArrayVarP(&f.scan, "scan", "", nil, "Discover git repositories under the directory recursively")
+	cmd.Flags().StringVarP(&f.Root, "root", "", "", "Root to adopt the repositories into")
+	if err := cmd.RegisterFlagCompletionFunc("root", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return svc.WorkspaceService.GetRoots(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		return nil, fmt.Errorf("registering completion for root flag: %w", err)
+	}
+	cmd.Flags().BoolVarP(&f.Symlink, "symlink", "", false, "Create a symbolic link in the root instead of moving the repository")
+	cmd.Flags().BoolVarP(&f.DryRun, "dry-run", "", false, "Displays the operations that would be performed using the specified command without actually running them")
+	return cmd, nil
+}
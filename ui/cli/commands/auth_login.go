@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/apex/log"
 	"github.com/charmbracelet/huh"
@@ -13,11 +15,13 @@ import (
 	"github.com/kyoh86/gogh/v4/app/auth/login"
 	"github.com/kyoh86/gogh/v4/app/service"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 func NewAuthLoginCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
 	var f struct {
-		Host string
+		Host      string
+		WithToken bool
 	}
 
 	cmd := &cobra.Command{
@@ -38,7 +42,20 @@ func NewAuthLoginCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Com
 				}
 			}
 
-			if err := login.NewUsecase(svc.TokenService, svc.AuthenticateService, svc.HostingService).Execute(cmd.Context(), f.Host, func(ctx context.Context, res login.DeviceAuthResponse) error {
+			uc := login.NewUsecase(svc.TokenService, svc.AuthenticateService, svc.HostingService)
+			if f.WithToken {
+				token, err := readAccessToken(cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				if err := uc.ExecuteWithToken(ctx, f.Host, token); err != nil {
+					return err
+				}
+				log.FromContext(ctx).Info("Login successful!")
+				return nil
+			}
+
+			if err := uc.Execute(ctx, f.Host, func(ctx context.Context, res login.DeviceAuthResponse) error {
 				if errors.Is(browser.OpenURL(res.VerificationURI), exec.ErrNotFound) {
 					fmt.Fprintf(
 						os.Stderr,
@@ -63,5 +80,28 @@ func NewAuthLoginCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Com
 		},
 	}
 	cmd.Flags().StringVarP(&f.Host, "host", "", "", "Host name to login")
+	cmd.Flags().BoolVarP(&f.WithToken, "with-token", "", false, "Login with an access token (e.g. a personal access token) read from the standard input")
 	return cmd, nil
 }
+
+// readAccessToken reads an access token from the input.
+// If the input is a terminal, it prompts the user to enter the token.
+func readAccessToken(in io.Reader) (string, error) {
+	if file, ok := in.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		var token string
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewInput().
+				Title("Access token").
+				EchoMode(huh.EchoModePassword).
+				Value(&token),
+		)).Run(); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(token), nil
+	}
+	buf, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("reading access token: %w", err)
+	}
+	return strings.TrimSpace(string(buf)), nil
+}
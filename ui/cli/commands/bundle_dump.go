@@ -8,6 +8,7 @@ import (
 	"github.com/kyoh86/gogh/v4/app/config"
 	"github.com/kyoh86/gogh/v4/app/dump"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/spf13/cobra"
 )
 
@@ -32,7 +33,7 @@ func NewBundleDumpCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Co
 				defer file.Close()
 				out = file
 			}
-			for entry, err := range dump.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.HostingService, svc.GitService).Execute(cmd.Context(), dump.Options{}) {
+			for entry, err := range dump.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.HostingService, svc.GitService).Execute(cmd.Context(), dump.Options{Tags: f.Tags}) {
 				if err != nil {
 					return err
 				}
@@ -47,5 +48,8 @@ func NewBundleDumpCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&f.File, "file", "f", svc.Flags.BundleDump.File, `A file to output; if it's empty("") or hyphen("-"), output to stdout`)
+	if err := flags.TagsFlag(cmd, &f.Tags, svc); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/kyoh86/gogh/v4/app/clone/try"
 	"github.com/kyoh86/gogh/v4/app/config"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/kyoh86/gogh/v4/ui/cli/view"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
@@ -19,8 +20,10 @@ func NewBundleRestoreCommand(_ context.Context, svc *service.ServiceSet) (*cobra
 	var f config.BundleRestoreFlags
 	cloneUsecase := clone.NewUsecase(
 		svc.HostingService,
+		svc.ProviderService,
 		svc.WorkspaceService,
 		svc.FinderService,
+		svc.IndexService,
 		svc.OverlayService,
 		svc.ScriptService,
 		svc.HookService,
@@ -49,7 +52,8 @@ func NewBundleRestoreCommand(_ context.Context, svc *service.ServiceSet) (*cobra
 				eg.Go(func() error {
 					if err := cloneUsecase.Execute(egCtx, ref, clone.Options{
 						TryCloneOptions: try.Options{
-							Notify: try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(egCtx, nil)),
+							Notify:       try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(egCtx, nil)),
+							CloneOptions: flags.CloneOptions(f.CloneOptionFlags),
 						},
 					}); err != nil {
 						return fmt.Errorf("cloning %s: %w", ref, err)
@@ -77,5 +81,8 @@ func NewBundleRestoreCommand(_ context.Context, svc *service.ServiceSet) (*cobra
 	cmd.Flags().StringVarP(&f.File, "file", "f", svc.Flags.BundleRestore.File, `Read the file as input; if it's empty("") or hyphen("-"), read from stdin`)
 	cmd.Flags().DurationVarP(&f.CloneRetryTimeout, "clone-retry-timeout", "", svc.Flags.BundleRestore.CloneRetryTimeout, "Timeout for each clone attempt")
 	cmd.Flags().IntVarP(&f.CloneRetryLimit, "clone-retry-limit", "", svc.Flags.Create.CloneRetryLimit, "The number of retries to clone a repository")
+	if err := flags.CloneOptionFlags(cmd, &f.CloneOptionFlags, svc.Flags.BundleRestore.CloneOptionFlags, false); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
@@ -8,10 +8,13 @@ import (
 	"github.com/apex/log"
 	"github.com/charmbracelet/huh"
 	"github.com/kyoh86/gogh/v4/app/clone"
+	"github.com/kyoh86/gogh/v4/app/clone/expand"
 	"github.com/kyoh86/gogh/v4/app/clone/try"
 	"github.com/kyoh86/gogh/v4/app/config"
 	"github.com/kyoh86/gogh/v4/app/repos"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 )
@@ -20,8 +23,10 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
 	var f config.CloneFlags
 	cloneUsecase := clone.NewUsecase(
 		svc.HostingService,
+		svc.ProviderService,
 		svc.WorkspaceService,
 		svc.FinderService,
+		svc.IndexService,
 		svc.OverlayService,
 		svc.ScriptService,
 		svc.HookService,
@@ -54,6 +59,34 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
 		return args, nil
 	}
 
+	// expandPatterns replaces the doublestar patterns in args with the matching remote repositories.
+	expandPatterns := func(ctx context.Context, args []string) ([]string, error) {
+		opts := expand.Options{
+			Fork:    f.Fork,
+			Archive: f.Archive,
+		}
+		expandUsecase := expand.NewUsecase(svc.HostingService, svc.DefaultNameService)
+		refs := make([]string, 0, len(args))
+		for _, arg := range args {
+			if !expand.IsPattern(arg) {
+				refs = append(refs, arg)
+				continue
+			}
+			var count int
+			for ref, err := range expandUsecase.Execute(ctx, arg, opts) {
+				if err != nil {
+					return nil, fmt.Errorf("expanding %q: %w", arg, err)
+				}
+				refs = append(refs, ref.String())
+				count++
+			}
+			if count == 0 {
+				log.FromContext(ctx).WithField("pattern", arg).Warn("No remote repository matches the pattern")
+			}
+		}
+		return refs, nil
+	}
+
 	runFunc := func(ctx context.Context, refs []string) error {
 		if f.DryRun {
 			for _, ref := range refs {
@@ -69,8 +102,9 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
 			eg.Go(func() error {
 				err := cloneUsecase.Execute(egCtx, ref, clone.Options{
 					TryCloneOptions: try.Options{
-						Notify:  try.RetryLimit(1, nil),
-						Timeout: f.CloneRetryTimeout,
+						Notify:       try.RetryLimit(1, nil),
+						Timeout:      f.CloneRetryTimeout,
+						CloneOptions: flags.CloneOptions(f.CloneOptionFlags),
 					},
 				})
 				return err
@@ -87,12 +121,19 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
 		Aliases: []string{"get"},
 		Args:    cobra.ArbitraryArgs,
 		Short:   "Clone remote repositories to local",
+		ValidArgsFunction: func(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return completion.HostAliases(cmd.Context(), svc, toComplete)
+		},
 		Example: `  It accepts a short notation for a repository
   (for example, "github.com/kyoh86/example") like below.
     - "<name>": e.g. "example"; 
     - "<owner>/<name>": e.g. "kyoh86/example"
   They'll be completed with the default host and owner set by "config set-default{-host|-owner}".
 
+  It also accepts a URL or an SCP-like address of a repository
+  (e.g. "https://github.com/kyoh86/example.git" or "git@github.com:kyoh86/example.git").
+  Host aliases configured in the default names are expanded (e.g. "gh:kyoh86/example").
+
   It also accepts an alias for each repository.
 	The alias is used for a local repository.
   For example:
@@ -100,13 +141,24 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
     - "kyoh86/example=kyoh86-tryouts/tryout"
   For each them will be cloned from "github.com/kyoh86/example" into the local as:
     - "$(gogh root)/github.com/kyoh86/sample"
-    - "$(gogh root)/github.com/kyoh86-tryouts/tryout"`,
+    - "$(gogh root)/github.com/kyoh86-tryouts/tryout"
+
+  It also accepts a doublestar pattern of "[[<host>/]<owner>/]<name>"
+  to clone every remote repository matching it (e.g. 'our-org/svc-*').
+  If the owner is also a pattern, only the repositories you are affiliated with are matched.
+  Forks and archived repositories are skipped by default: choose them with "--fork" and "--archive"
+  like "gogh repos" (e.g. '--fork forked' for only forks, or '--fork ""' for both).
+  Check the matching repositories with "--dry-run" first.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 			args, err := checkFlags(ctx, args)
 			if err != nil {
 				return err
 			}
+			args, err = expandPatterns(ctx, args)
+			if err != nil {
+				return err
+			}
 			if len(args) == 0 {
 				return errors.New("no repository specified")
 			}
@@ -114,11 +166,23 @@ func NewCloneCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command
 				return fmt.Errorf("cloning repositories: %v", err)
 			}
 			log.FromContext(ctx).Infof("Cloning %d repositories completed", len(args))
+			if !f.DryRun && len(args) == 1 {
+				writeChdirFile(ctx, svc, args[0])
+			}
 			return nil
 		},
 	}
 
+	if err := enumFlag(cmd, &f.Fork, "fork", "not-forked", `Expand patterns only to forked/not-forked repositories (set "" for both)`, "forked", "not-forked"); err != nil {
+		return nil, fmt.Errorf("registering fork flag: %w", err)
+	}
+	if err := enumFlag(cmd, &f.Archive, "archive", "not-archived", `Expand patterns only to archived/not-archived repositories (set "" for both)`, "archived", "not-archived"); err != nil {
+		return nil, fmt.Errorf("registering archive flag: %w", err)
+	}
 	cmd.Flags().BoolVarP(&f.DryRun, "dry-run", "", false, "Displays the operations that would be performed using the specified command without actually running them")
 	cmd.Flags().DurationVarP(&f.CloneRetryTimeout, "clone-retry-timeout", "t", svc.Flags.Clone.CloneRetryTimeout, "Timeout for each clone attempt")
+	if err := flags.CloneOptionFlags(cmd, &f.CloneOptionFlags, svc.Flags.Clone.CloneOptionFlags, true); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigHostCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:     "host",
+		Short:   "Manage the hosting service providers for each host",
+		Aliases: []string{"hosts", "provider", "providers"},
+		Args:    cobra.NoArgs,
+		RunE:    ConfigHostListRunE(svc),
+	}, nil
+}
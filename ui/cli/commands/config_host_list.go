@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyoh86/gogh/v4/app/provider/list"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigHostListCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the hosting service providers registered for hosts",
+		Args:  cobra.NoArgs,
+		RunE:  ConfigHostListRunE(svc),
+	}, nil
+}
+
+func ConfigHostListRunE(svc *service.ServiceSet) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		for _, p := range list.NewUsecase(svc.ProviderService).Execute(cmd.Context()) {
+			fields := []string{p.Host, string(p.Kind)}
+			for _, v := range []string{p.APIBase, p.CloneURL, string(p.Protocol), p.SSHKeyFile} {
+				if v != "" {
+					fields = append(fields, v)
+				}
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), strings.Join(fields, "\t"))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/provider/list"
+	"github.com/kyoh86/gogh/v4/app/provider/remove"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigHostRemoveCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:   "remove [flags] <host>",
+		Short: "Remove the hosting service provider for a host",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			var hosts []cobra.Completion
+			for _, p := range list.NewUsecase(svc.ProviderService).Execute(cmd.Context()) {
+				hosts = append(hosts, p.Host)
+			}
+			return hosts, cobra.ShellCompDirectiveNoFileComp
+		},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			host := args[0]
+			if err := remove.NewUsecase(svc.ProviderService).Execute(ctx, host); err != nil {
+				return err
+			}
+			log.FromContext(ctx).Infof("Removed the provider for %s", host)
+			return nil
+		},
+	}
+	return cmd, nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/provider/set"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigHostSetCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		kind string
+		opts set.Options
+	}
+	cmd := &cobra.Command{
+		Use:   "set [flags] <host>",
+		Short: "Set the hosting service provider for a host",
+		Example: `  gogh config host set github.example.com --kind github --api-base https://github.example.com/api/v3
+  gogh config host set git.example.com --kind gitlab
+  gogh config host set github.com --kind github --protocol ssh --ssh-key-file ~/.ssh/id_ed25519
+  gogh config host set gitolite.example.com --kind git --clone-url 'git@gitolite.example.com:{{.Owner}}/{{.Name}}'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			host := args[0]
+			if err := set.NewUsecase(svc.ProviderService).Execute(ctx, host, f.kind, f.opts); err != nil {
+				return err
+			}
+			log.FromContext(ctx).Infof("Set the provider for %s: %s", host, f.kind)
+			return nil
+		},
+	}
+	kinds := set.ProviderKinds()
+	cmd.Flags().StringVarP(&f.kind, "kind", "", "", fmt.Sprintf("Kind of the hosting service (%s)", strings.Join(kinds, ", ")))
+	cmd.Flags().StringVarP(&f.opts.APIBase, "api-base", "", "", "Base URL of the REST API (default: the one of the kind for the host)")
+	cmd.Flags().StringVarP(&f.opts.CloneURL, "clone-url", "", "", "Template of the clone URL for the plain git server (kind: git) with {{.Host}}, {{.Owner}} and {{.Name}} (default: \"https://{{.Host}}/{{.Owner}}/{{.Name}}.git\")")
+	protocols := set.Protocols()
+	cmd.Flags().StringVarP(&f.opts.Protocol, "protocol", "", "", fmt.Sprintf("Protocol preferred to clone repositories and to set remotes (%s) (default: https)", strings.Join(protocols, ", ")))
+	cmd.Flags().StringVarP(&f.opts.SSHKeyFile, "ssh-key-file", "", "", "Path of the private key file to access the host over SSH (default: the keys in the ssh-agent)")
+	if err := cmd.MarkFlagRequired("kind"); err != nil {
+		return nil, err
+	}
+	if err := cmd.RegisterFlagCompletionFunc("kind", cobra.FixedCompletions(kinds, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		return nil, err
+	}
+	if err := cmd.RegisterFlagCompletionFunc("protocol", cobra.FixedCompletions(protocols, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
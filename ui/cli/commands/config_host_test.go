@@ -0,0 +1,20 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/commands"
+)
+
+func TestNewConfigHostCommand(t *testing.T) {
+	ctx := context.Background()
+	serviceSet := &service.ServiceSet{Flags: &config.Flags{}}
+
+	_, err := commands.NewConfigHostCommand(ctx, serviceSet)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
@@ -40,6 +40,10 @@ func NewConfigShowCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Co
 			if err != nil {
 				return err
 			}
+			providerSource, err := svc.ProviderStore.Source()
+			if err != nil {
+				return err
+			}
 			flagsSource, err := svc.FlagsStore.Source()
 			if err != nil {
 				return err
@@ -51,12 +55,15 @@ func NewConfigShowCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Co
 			if err := t.Execute(&w, map[string]any{
 				"defaultNameSource": defaultNameSource,
 				"tokenSource":       tokenSource,
+				"providerSource":    providerSource,
 				"flagsSource":       flagsSource,
 				"workspaceSource":   workspaceSource,
 				"roots":             svc.WorkspaceService.GetRoots(),
 				"defaultHost":       svc.DefaultNameService.GetDefaultHost(),
 				"defaultNames":      svc.DefaultNameService.GetMap(),
+				"hostAliases":       svc.DefaultNameService.GetHostAliases(),
 				"tokens":            svc.TokenService.Entries(),
+				"providers":         svc.ProviderService.Entries(),
 				"flags":             flags,
 			}); err != nil {
 				return fmt.Errorf("[Bug] failed to execute template: %w", err)
@@ -12,6 +12,8 @@ import (
 	"github.com/kyoh86/gogh/v4/app/create"
 	"github.com/kyoh86/gogh/v4/app/create/template"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/kyoh86/gogh/v4/ui/cli/view"
 	"github.com/spf13/cobra"
 )
@@ -43,7 +45,8 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 		if f.Template == "" {
 			ropt := create.Options{
 				TryCloneOptions: try.Options{
-					Notify: try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					Notify:       try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					CloneOptions: flags.CloneOptions(f.CloneOptionFlags),
 				},
 				RepositoryOptions: create.RepositoryOptions{
 					Description:         f.Description,
@@ -65,8 +68,10 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 			}
 			if err := create.NewUsecase(
 				svc.HostingService,
+				svc.ProviderService,
 				svc.WorkspaceService,
 				svc.FinderService,
+				svc.IndexService,
 				svc.OverlayService,
 				svc.ScriptService,
 				svc.HookService,
@@ -82,8 +87,10 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 			}
 			if err := template.NewUsecase(
 				svc.HostingService,
+				svc.ProviderService,
 				svc.WorkspaceService,
 				svc.FinderService,
+				svc.IndexService,
 				svc.OverlayService,
 				svc.ScriptService,
 				svc.HookService,
@@ -91,8 +98,9 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 				svc.GitService,
 			).Execute(ctx, refWithAlias, *tmp, template.CreateFromTemplateOptions{
 				TryCloneOptions: try.Options{
-					Timeout: f.CloneRetryTimeout,
-					Notify:  try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					Timeout:      f.CloneRetryTimeout,
+					Notify:       try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					CloneOptions: flags.CloneOptions(f.CloneOptionFlags),
 				},
 				RepositoryOptions: template.RepositoryOptions{
 					Description:        f.Description,
@@ -107,7 +115,7 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 			fmt.Printf("Apply overlay for %q\n", refWithAlias)
 			return nil
 		}
-
+		writeChdirFile(ctx, svc, refWithAlias)
 		return nil
 	}
 
@@ -116,11 +124,18 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 		Aliases: []string{"new"},
 		Short:   "Create a new local and remote repository",
 		Args:    cobra.RangeArgs(0, 1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completion.HostAliases(cmd.Context(), svc, toComplete)
+		},
 		Example: `  It accepts a short notation for a repository
   (for example, "github.com/kyoh86/example") like below.
     - "<name>": e.g. "example"; 
     - "<owner>/<name>": e.g. "kyoh86/example"
   They'll be completed with the default host and owner set by "config set-default{-host|-owner}".
+  Host aliases configured in the default names are expanded (e.g. "gh:kyoh86/example").
 
   It also accepts an alias for each repository.
 	The alias is used for a local repository.
@@ -174,5 +189,8 @@ func NewCreateCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 	cmd.Flags().BoolVarP(&f.DeleteBranchOnMerge, "delete-branch-on-merge", "", svc.Flags.Create.DeleteBranchOnMerge, "Allow automatically deleting head branches when pull requests are merged")
 	cmd.Flags().DurationVarP(&f.CloneRetryTimeout, "clone-retry-timeout", "t", svc.Flags.Create.CloneRetryTimeout, "Timeout for each clone attempt")
 	cmd.Flags().IntVarP(&f.CloneRetryLimit, "clone-retry-limit", "", svc.Flags.Create.CloneRetryLimit, "The number of retries to clone a repository")
+	if err := flags.CloneOptionFlags(cmd, &f.CloneOptionFlags, svc.Flags.Create.CloneOptionFlags, false); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
@@ -2,24 +2,35 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/apex/log"
 	"github.com/kyoh86/gogh/v4/app/config"
 	"github.com/kyoh86/gogh/v4/app/cwd"
+	"github.com/kyoh86/gogh/v4/app/jump"
 	"github.com/kyoh86/gogh/v4/app/service"
 	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // NewCwdCommand creates a new command to print the local repository which the current working directory belongs to.
 func NewCwdCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
 	var format flags.LocationFormat
+	var prompt bool
 
 	cmd := &cobra.Command{
 		Use:   "cwd",
 		Short: "Print the local repository which the current working directory belongs to",
-		Args:  cobra.NoArgs,
+		Long: `Print the local repository which the current working directory belongs to.
+If the current working directory is not in any local repository and the input is a terminal,
+it shows the picker of "gogh jump" to choose one.
+
+With --prompt, it prints the reference of the repository for a shell prompt (see "gogh shell-init"):
+it prints nothing outside the repositories, and does not record the visit.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			formatter, err := config.LocationFormatter(format.String())
 			if err != nil {
@@ -28,7 +39,25 @@ func NewCwdCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Command
 
 			ctx := cmd.Context()
 			repo, err := cwd.NewUsecase(svc.WorkspaceService, svc.FinderService).Execute(ctx)
-			if err != nil {
+			if prompt {
+				if errors.Is(err, cwd.ErrNotInRepository) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("finding repository in current directory: %w", err)
+				}
+				fmt.Println(repo.Ref())
+				return nil
+			}
+			switch {
+			case err == nil:
+				jump.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.UsageService, svc.ReferenceParser).Visit(repo)
+			case errors.Is(err, cwd.ErrNotInRepository) && term.IsTerminal(int(os.Stdin.Fd())):
+				repo, err = pickRepository(ctx, svc, jump.Options{})
+				if err != nil {
+					return fmt.Errorf("picking a repository: %w", err)
+				}
+			default:
 				return fmt.Errorf("finding repository in current directory: %w", err)
 			}
 			str, err := formatter.Format(*repo)
@@ -45,6 +74,7 @@ func NewCwdCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Command
 		},
 	}
 
+	cmd.Flags().BoolVarP(&prompt, "prompt", "", false, "Print the reference of the repository for a shell prompt")
 	if err := flags.LocationFormatFlag(cmd, &format, svc.Flags.Cwd.Format); err != nil {
 		return nil, fmt.Errorf("adding location format flag: %w", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/kyoh86/gogh/v4/app/delete"
 	"github.com/kyoh86/gogh/v4/app/repos"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
 	"github.com/spf13/cobra"
 )
 
@@ -83,12 +84,22 @@ func NewDeleteCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 		Aliases: []string{"remove", "rm", "del"},
 		Short:   "Delete local and remote repository",
 		Args:    cobra.RangeArgs(0, 1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completion.HostAliases(cmd.Context(), svc, toComplete)
+		},
 		Example: `  It accepts a short notation for a repository
   (for example, "github.com/kyoh86/example") like below.
     - "<name>": e.g. "example"; 
     - "<owner>/<name>": e.g. "kyoh86/example"
   They'll be completed with the default host and owner set by "config set-default{-host|-owner}".
 
+  It also accepts a URL or an SCP-like address of a repository
+  (e.g. "https://github.com/kyoh86/example.git" or "git@github.com:kyoh86/example.git").
+  Host aliases configured in the default names are expanded (e.g. "gh:kyoh86/example").
+
   It also accepts an alias for each repository.
 	The alias is a local name for the remote repository.
   For example:
@@ -121,6 +132,8 @@ func NewDeleteCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Comman
 			if err := delete.NewUsecase(
 				svc.WorkspaceService,
 				svc.FinderService,
+				svc.IndexService,
+				svc.TagService,
 				svc.HostingService,
 				svc.ReferenceParser,
 			).Execute(ctx, selected, delete.Options{
@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewDoctorCommand(_ context.Context, _ *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems",
+	}, nil
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/doctor"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/view"
+	"github.com/spf13/cobra"
+)
+
+// NewDoctorWorkspaceCommand creates a new command to find and fix the problems in the roots.
+func NewDoctorWorkspaceCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		fix   bool
+		force bool
+	}
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Find orphans and broken clones in the roots",
+		Long: `Find orphans and broken clones in the roots, and list them.
+
+It finds:
+  - empty directory:  an empty host or owner directory (e.g. left after "gogh delete")
+  - incomplete clone: an empty directory or a ".git" without "HEAD" at the repository depth (e.g. left by a failed clone)
+  - not a repository: a directory at the repository depth which is not a git repository
+  - remote mismatch:  a clone whose default remote (usually "origin") is not on the host of its layout path
+    (they are skipped by "gogh bundle dump")
+
+With --fix, it confirms to fix each of them: the empty directories and the incomplete clones are deleted,
+and the mismatched clones are moved into the layout path of their remotes like "gogh move".
+The directories which are not a repository are only reported: remove them by yourself if they are not needed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := log.FromContext(ctx)
+			uc := doctor.NewUsecase(
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.IndexService,
+				svc.TagService,
+				svc.HostingService,
+				svc.GitService,
+				svc.ExtraService,
+				svc.ReferenceParser,
+			)
+
+			if !f.fix {
+				for problem, err := range uc.Execute(ctx) {
+					if err != nil {
+						return err
+					}
+					fmt.Printf("%s\t%s\t%s\n", problem.Path, problem.Kind, problem.Detail)
+				}
+				return nil
+			}
+
+			fix := func(problem *doctor.Problem) error {
+				if err := uc.Fix(ctx, problem); err != nil {
+					if errors.Is(err, doctor.ErrCannotFix) {
+						logger.Warnf("Skipped %s: %v", problem.Path, err)
+						return nil
+					}
+					return err
+				}
+				logger.Infof("Fixed %s (%s)", problem.Path, problem.Kind)
+				return nil
+			}
+			if f.force {
+				for problem, err := range uc.Execute(ctx) {
+					if err != nil {
+						return err
+					}
+					if err := fix(problem); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if err := view.ProcessWithConfirmation(ctx, uc.Execute(ctx), func(problem *doctor.Problem) string {
+				return fmt.Sprintf("Fix %s in %s (%s)?", problem.Kind, problem.Path, problem.Detail)
+			}, fix); err != nil && !errors.Is(err, view.ErrQuit) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&f.fix, "fix", "", false, "Fix the problems with confirmation")
+	cmd.Flags().BoolVarP(&f.force, "force", "", false, "Do NOT confirm to fix (with --fix)")
+	return cmd, nil
+}
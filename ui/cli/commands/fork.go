@@ -9,6 +9,8 @@ import (
 	"github.com/kyoh86/gogh/v4/app/config"
 	"github.com/kyoh86/gogh/v4/app/fork"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/kyoh86/gogh/v4/ui/cli/view"
 	"github.com/spf13/cobra"
 )
@@ -20,36 +22,51 @@ func NewForkCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command,
 		Use:   "fork [flags] [<host>/]<owner>/<name>",
 		Short: "Fork a repository",
 		Args:  cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completion.HostAliases(cmd.Context(), svc, toComplete)
+		},
 		Example: `  It accepts a short notation for a repository
   (for example, "github.com/kyoh86/example") like "<owner>/<name>": e.g. "kyoh86/example"
-  They'll be completed with the default host set by "config set-default-host"`,
+  They'll be completed with the default host set by "config set-default-host".
+
+  It also accepts a URL or an SCP-like address of a repository
+  (e.g. "https://github.com/kyoh86/example.git" or "git@github.com:kyoh86/example.git").
+  Host aliases configured in the default names are expanded (e.g. "gh:kyoh86/example").`,
 		RunE: func(cmd *cobra.Command, refs []string) error {
 			ctx := cmd.Context()
 			opts := fork.Options{
 				TryCloneOptions: try.Options{
-					Timeout: f.CloneRetryTimeout,
-					Notify:  try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					Timeout:      f.CloneRetryTimeout,
+					Notify:       try.RetryLimit(f.CloneRetryLimit, view.TryCloneNotify(ctx, nil)),
+					CloneOptions: flags.CloneOptions(f.CloneOptionFlags),
 				},
 				HostingOptions: fork.HostingOptions{
 					DefaultBranchOnly: f.DefaultBranchOnly,
 				},
 				Target: f.To,
 			}
-			if err := fork.
-				NewUsecase(
-					svc.HostingService,
-					svc.WorkspaceService,
-					svc.FinderService,
-					svc.OverlayService,
-					svc.ScriptService,
-					svc.HookService,
-					svc.DefaultNameService,
-					svc.ReferenceParser,
-					svc.GitService,
-				).
-				Execute(ctx, refs[0], opts); err != nil {
+			forkUsecase := fork.NewUsecase(
+				svc.HostingService,
+				svc.ProviderService,
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.IndexService,
+				svc.OverlayService,
+				svc.ScriptService,
+				svc.HookService,
+				svc.DefaultNameService,
+				svc.ReferenceParser,
+				svc.GitService,
+			)
+			if err := forkUsecase.Execute(ctx, refs[0], opts); err != nil {
 				return fmt.Errorf("forking the repository: %w", err)
 			}
+			if target, err := forkUsecase.Target(refs[0], f.To); err == nil {
+				writeChdirFile(ctx, svc, target.String())
+			}
 
 			return nil
 		},
@@ -69,5 +86,8 @@ func NewForkCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command,
 	cmd.Flags().IntVarP(&f.CloneRetryLimit, "clone-retry-limit", "", svc.Flags.Fork.CloneRetryLimit, "The number of retries to clone a repository")
 	cmd.Flags().BoolVarP(&f.DefaultBranchOnly, "default-branch-only", "", svc.Flags.Fork.DefaultBranchOnly, "Only fork the default branch")
 	cmd.Flags().DurationVarP(&f.CloneRetryTimeout, "clone-retry-timeout", "t", svc.Flags.Fork.CloneRetryTimeout, "Timeout for each clone attempt")
+	if err := flags.CloneOptionFlags(cmd, &f.CloneOptionFlags, svc.Flags.Fork.CloneOptionFlags, true); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
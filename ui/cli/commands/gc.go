@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/app/gc"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/view"
+	"github.com/spf13/cobra"
+)
+
+// NewGCCommand creates a new command to remove stale local repositories.
+func NewGCCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		config.GCFlags
+		force  bool
+		dryRun bool
+	}
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove stale local repositories to free disk space",
+		Long: `Rank local repositories from the least recently active one,
+by the time of the last commit, the last checkout or modification of the working tree, and the disk usage.
+
+Then it confirms to remove each repository which is clean (no uncommitted changes, untracked files,
+stashes or branches without upstream), fully pushed to the upstream in every branch, and untouched for the days.
+They can be cloned again from the remote.
+
+With --dry-run or --format json, it just prints the ranking.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := log.FromContext(ctx)
+			uc := gc.NewUsecase(
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.IndexService,
+				svc.TagService,
+				svc.HostingService,
+				svc.GitService,
+				svc.ReferenceParser,
+			)
+			candidates, err := uc.Execute(ctx, gc.Options{
+				Primary:     f.Primary,
+				ListOptions: gc.ListOptions{Patterns: f.Patterns},
+				Days:        f.Days,
+			})
+			if err != nil {
+				return err
+			}
+
+			printer, err := gc.NewPrinter(cmd.OutOrStdout(), f.Format)
+			if err != nil {
+				return err
+			}
+			for _, candidate := range candidates {
+				if err := printer.Print(*candidate); err != nil {
+					return err
+				}
+			}
+			if err := printer.Close(); err != nil {
+				return err
+			}
+			if f.dryRun || f.Format == "json" {
+				return nil
+			}
+
+			var removables iter.Seq2[*gc.Candidate, error] = func(yield func(*gc.Candidate, error) bool) {
+				for _, candidate := range candidates {
+					if candidate.Removable() && !yield(candidate, nil) {
+						return
+					}
+				}
+			}
+			remove := func(candidate *gc.Candidate) error {
+				if err := uc.Remove(ctx, candidate); err != nil {
+					if errors.Is(err, gc.ErrNotRemovable) {
+						logger.Warnf("Skipped %s: %v", candidate.Location.Path(), err)
+						return nil
+					}
+					return err
+				}
+				logger.Infof("Removed %s (%s)", candidate.Location.Path(), gc.FormatSize(candidate.DiskUsage))
+				return nil
+			}
+			if f.force {
+				for candidate := range removables {
+					if err := remove(candidate); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if err := view.ProcessWithConfirmation(ctx, removables, func(candidate *gc.Candidate) string {
+				return fmt.Sprintf("Remove %s (%s)?", candidate.Location.Path(), gc.FormatSize(candidate.DiskUsage))
+			}, remove); err != nil && !errors.Is(err, view.ErrQuit) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&f.Patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	cmd.Flags().BoolVarP(&f.Primary, "primary", "", svc.Flags.GC.Primary, "Remove repositories in just a primary root")
+	cmd.Flags().IntVarP(&f.Days, "days", "", svc.Flags.GC.Days, "Remove repositories untouched for the days")
+	cmd.Flags().StringVarP(&f.Format, "format", "f", svc.Flags.GC.Format, `Print the ranking in a given format, where [format] can be one of "table" or "json"`)
+	if err := cmd.RegisterFlagCompletionFunc("format", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json"}, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		return nil, fmt.Errorf("registering completion function for format flag: %w", err)
+	}
+	cmd.Flags().BoolVarP(&f.force, "force", "", false, "Do NOT confirm to remove")
+	cmd.Flags().BoolVarP(&f.dryRun, "dry-run", "", false, "Displays the operations that would be performed using the specified command without actually running them")
+	return cmd, nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/charmbracelet/huh"
+	"github.com/kyoh86/gogh/v4/app/jump"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+// pickRepository shows a fuzzy picker over the local repositories ranked by frecency,
+// and records a visit to the chosen one.
+func pickRepository(ctx context.Context, svc *service.ServiceSet, opts jump.Options) (*jump.Location, error) {
+	uc := jump.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.UsageService, svc.ReferenceParser)
+	locations, err := uc.Execute(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, errors.New("no repository found")
+	}
+	options := make([]huh.Option[*jump.Location], 0, len(locations))
+	for _, location := range locations {
+		options = append(options, huh.NewOption(location.Path(), location))
+	}
+	var selected *jump.Location
+	if err := huh.NewForm(huh.NewGroup(
+		huh.NewSelect[*jump.Location]().
+			Title("A repository to jump").
+			Options(options...).
+			Filtering(true).
+			Height(15).
+			Value(&selected),
+	)).WithOutput(os.Stderr).Run(); err != nil {
+		return nil, err
+	}
+	uc.Visit(selected)
+	return selected, nil
+}
+
+func NewJumpCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		print    bool
+		patterns []string
+		tags     []string
+	}
+	cmd := &cobra.Command{
+		Use:   "jump [flags] [<query>...]",
+		Short: "Jump to a local repository ranked by frequency and recency",
+		Long: `Jump to a local repository ranked by frecency: how often and how recently it is visited.
+Visits are recorded when a repository is chosen by "jump" or resolved by "cwd".
+
+Without a query, it shows a fuzzy picker over the local repositories.
+With a query, it chooses the highest ranked repository whose path contains all words of the query.`,
+		Example: `  jump
+  jump gogh
+  cd "$(gogh jump --print kyoh86 dot)"`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			opts := jump.Options{
+				ListOptions: jump.ListOptions{
+					Patterns: f.patterns,
+					Tags:     f.tags,
+				},
+			}
+			var location *jump.Location
+			if len(args) == 0 {
+				selected, err := pickRepository(ctx, svc, opts)
+				if err != nil {
+					return fmt.Errorf("picking a repository: %w", err)
+				}
+				location = selected
+			} else {
+				opts.Query = strings.Join(args, " ")
+				uc := jump.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.UsageService, svc.ReferenceParser)
+				locations, err := uc.Execute(ctx, opts)
+				if err != nil {
+					return err
+				}
+				if len(locations) == 0 {
+					return fmt.Errorf("no repository matches %q", opts.Query)
+				}
+				location = locations[0]
+				uc.Visit(location)
+			}
+
+			if f.print {
+				fmt.Fprintln(cmd.OutOrStdout(), location.FullPath())
+				return nil
+			}
+			shell := os.Getenv("SHELL")
+			if shell == "" {
+				shell = "/bin/sh"
+			}
+			log.FromContext(ctx).Infof("Entering %s (exit the shell to return)", location.Path())
+			sub := exec.CommandContext(ctx, shell)
+			sub.Dir = location.FullPath()
+			sub.Env = os.Environ()
+			sub.Stdin = os.Stdin
+			sub.Stdout = os.Stdout
+			sub.Stderr = os.Stderr
+			return sub.Run()
+		},
+	}
+	cmd.Flags().BoolVarP(&f.print, "print", "", false, "Print the full path of the repository instead of starting a shell in it")
+	cmd.Flags().StringSliceVarP(&f.patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	if err := flags.TagsFlag(cmd, &f.tags, svc); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
@@ -34,6 +34,7 @@ func NewListCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Comman
 				ListOptions: list.ListOptions{
 					Limit:    f.Limit,
 					Patterns: f.Patterns,
+					Tags:     f.Tags,
 				},
 			}
 			cnt := 0
@@ -61,6 +62,9 @@ func NewListCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Comman
 				if opts.Primary {
 					logger = logger.WithField("primary", true)
 				}
+				if len(opts.Tags) > 0 {
+					logger = logger.WithField("tags", strings.Join(opts.Tags, ","))
+				}
 				if len(opts.Patterns) > 0 {
 					logger = logger.WithField("patterns", strings.Join(opts.Patterns, "|"))
 					logger.Info(strings.Join([]string{
@@ -78,6 +82,9 @@ func NewListCommand(ctx context.Context, svc *service.ServiceSet) (*cobra.Comman
 	}
 	cmd.Flags().IntVarP(&f.Limit, "limit", "", svc.Flags.List.Limit, "Max number of repositories to list. -1 means unlimited")
 	cmd.Flags().StringSliceVarP(&f.Patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	if err := flags.TagsFlag(cmd, &f.Tags, svc); err != nil {
+		return nil, err
+	}
 	cmd.Flags().BoolVarP(&f.Primary, "primary", "", svc.Flags.List.Primary, "List up repositories in just a primary root")
 	if err := flags.LocationFormatFlag(cmd, &format, svc.Flags.List.Format); err != nil {
 		return nil, fmt.Errorf("initializing format flag: %s", err)
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/move"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewMoveCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f move.Options
+	cmd := &cobra.Command{
+		Use:     "move [flags] [[<host>/]<owner>/]<name>",
+		Aliases: []string{"mv", "rename"},
+		Short:   "Move a local repository to another root or alias",
+		Long: `Move a local repository to another root or alias.
+
+The working tree is moved into the root given by --root,
+or the root chosen by the routes (or the primary root) if it is omitted.
+With --alias, the repository is renamed to the alias in the local.
+If the alias changes the owner or the name, the default remotes pointing to
+the old repository are updated to point to the new one (unless --keep-remotes).
+The auto extra and the tags bound to the old repository are re-bound to the new one.
+It refuses to move when the destination already exists.`,
+		Example: `  gogh move kyoh86/gogh --root ~/Secure
+  gogh move kyoh86/gogh --alias kyoh86/gogh-old --keep-remotes`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			location, err := move.NewUsecase(
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.IndexService,
+				svc.TagService,
+				svc.GitService,
+				svc.ExtraService,
+				svc.ReferenceParser,
+			).Execute(ctx, args[0], f)
+			if err != nil {
+				return fmt.Errorf("moving the repository: %w", err)
+			}
+			log.FromContext(ctx).Infof("Moved %s to %s", args[0], location.FullPath())
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&f.Root, "root", "", "", "Root to move the repository into")
+	if err := cmd.RegisterFlagCompletionFunc("root", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return svc.WorkspaceService.GetRoots(), cobra.ShellCompDirectiveNoFileComp
+	}); err != nil {
+		return nil, fmt.Errorf("registering completion for root flag: %w", err)
+	}
+	cmd.Flags().StringVarP(&f.Alias, "alias", "", "", "New local reference of the repository")
+	cmd.Flags().BoolVarP(&f.KeepRemotes, "keep-remotes", "", false, "Keep the remotes even if the owner or the name is changed")
+	return cmd, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/kyoh86/gogh/v4/app/overlay/apply"
 	"github.com/kyoh86/gogh/v4/app/service"
 	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +20,7 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 	var f struct {
 		allRepositories bool
 		patterns        []string
+		tags            []string
 	}
 	cmd := &cobra.Command{
 		Use:   "apply [flags] <overlay-id> [[<host>/]<owner>/]<name>",
@@ -33,6 +35,7 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 		Example: `  invoke [flags] <overlay-id> [[[<host>/]<owner>/]<name>...]
   invoke [flags] <overlay-id> --all
   invoke [flags] <overlay-id> --pattern <pattern> [--pattern <pattern>]...
+  invoke [flags] <overlay-id> --tag <tag> [--tag <tag>]...
 
   It accepts a short notation for each repository
   (for example, "github.com/kyoh86/example") like below.
@@ -41,6 +44,10 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
     - "." for the current directory repository
   They'll be completed with the default host and owner set by "config set-default{-host|-owner}".
 
+  It also accepts a URL or an SCP-like address of a repository
+  (e.g. "https://github.com/kyoh86/example.git" or "git@github.com:kyoh86/example.git").
+  Host aliases configured in the default names are expanded (e.g. "gh:kyoh86/example").
+
   It also accepts an alias for each repository.
 	The alias is a local name for the remote repository.
   For example:
@@ -60,9 +67,9 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 				svc.ReferenceParser,
 				svc.OverlayService,
 			)
-			if f.allRepositories || len(f.patterns) > 0 {
+			if f.allRepositories || len(f.patterns) > 0 || len(f.tags) > 0 {
 				if len(refs) > 0 {
-					return errors.New("cannot specify repositories when --all or --pattern flag is set")
+					return errors.New("cannot specify repositories when --all, --pattern or --tag flag is set")
 				}
 
 				// If --all flag is set, apply the script to all repositories in the workspace
@@ -72,6 +79,7 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 				).Execute(ctx, list.Options{ListOptions: list.ListOptions{
 					Limit:    0,
 					Patterns: f.patterns,
+					Tags:     f.tags,
 				}}) {
 					if err != nil {
 						return fmt.Errorf("listing repositories: %w", err)
@@ -80,6 +88,9 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 				}
 				if len(refs) == 0 {
 					logger := log.FromContext(ctx)
+					if len(f.tags) > 0 {
+						logger = logger.WithField("tags", strings.Join(f.tags, ","))
+					}
 					if len(f.patterns) > 0 {
 						logger = logger.WithField("patterns", strings.Join(f.patterns, "|"))
 						logger.Info(strings.Join([]string{
@@ -112,5 +123,8 @@ func NewOverlayApplyCommand(_ context.Context, svc *service.ServiceSet) (*cobra.
 	}
 	cmd.Flags().BoolVarP(&f.allRepositories, "all", "", false, "Apply to all repositories in the workspace")
 	cmd.Flags().StringSliceVarP(&f.patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	if err := flags.TagsFlag(cmd, &f.tags, svc); err != nil {
+		return nil, err
+	}
 	return cmd, nil
 }
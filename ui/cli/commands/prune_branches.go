@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/prunebranch"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/view"
+	"github.com/spf13/cobra"
+)
+
+// NewPruneBranchesCommand creates a new command to delete the local branches which are no longer needed.
+func NewPruneBranchesCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		patterns []string
+		primary  bool
+		force    bool
+		dryRun   bool
+	}
+	cmd := &cobra.Command{
+		Use:   "prune-branches",
+		Short: "Delete local branches merged or gone upstream",
+		Long: `Delete local branches which are merged into the default branch of the remote "origin",
+or whose upstream branch is deleted from the remote, in each local repository.
+The current branch and the default branch are never deleted.
+
+The deleted upstream branches are detected after they are pruned from the remote-tracking branches
+(e.g. by "git fetch --prune").`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			logger := log.FromContext(ctx)
+			uc := prunebranch.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.GitService)
+
+			// Errors on each repository are just logged to go on to the next one
+			var branches iter.Seq2[*prunebranch.Branch, error] = func(yield func(*prunebranch.Branch, error) bool) {
+				for branch, err := range uc.List(ctx, prunebranch.Options{
+					Primary:     f.primary,
+					ListOptions: prunebranch.ListOptions{Patterns: f.patterns},
+				}) {
+					if err != nil {
+						logger.WithField("error", err).Warn("Failed to search branches")
+						continue
+					}
+					if !yield(branch, nil) {
+						return
+					}
+				}
+			}
+
+			if f.dryRun {
+				for branch := range branches {
+					fmt.Printf("%s\t%s\t%s\n", branch.Location.Path(), branch.Name, branch.Reason)
+				}
+				return nil
+			}
+			prune := func(branch *prunebranch.Branch) error {
+				if err := uc.Prune(ctx, branch); err != nil {
+					return err
+				}
+				logger.Infof("Deleted %s in %s", branch.Name, branch.Location.Path())
+				return nil
+			}
+			if f.force {
+				for branch := range branches {
+					if err := prune(branch); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if err := view.ProcessWithConfirmation(ctx, branches, func(branch *prunebranch.Branch) string {
+				return fmt.Sprintf("Delete branch %q in %s (%s)?", branch.Name, branch.Location.Path(), branch.Reason)
+			}, prune); err != nil && !errors.Is(err, view.ErrQuit) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&f.patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	cmd.Flags().BoolVarP(&f.primary, "primary", "", false, "Prune branches in repositories in just a primary root")
+	cmd.Flags().BoolVarP(&f.force, "force", "", false, "Do NOT confirm to delete")
+	cmd.Flags().BoolVarP(&f.dryRun, "dry-run", "", false, "Displays the operations that would be performed using the specified command without actually running them")
+	return cmd, nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/app/pull"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+// NewPullCommand creates a new command to update local repositories from their remotes.
+func NewPullCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f config.PullFlags
+	cmd := &cobra.Command{
+		Use:     "pull",
+		Aliases: []string{"update"},
+		Short:   "Update local repositories from their remotes",
+		Long: `Fetch the remote of each local repository and fast-forward its current branch to its upstream.
+If the current branch has no upstream, the branch of the same name on the remote is used.
+Repositories which have uncommitted changes, have diverged from the remote or have no upstream are left as they are.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			counts := map[pull.Status]int{}
+			for result, err := range pull.NewUsecase(
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.HostingService,
+				svc.ProviderService,
+				svc.GitService,
+			).Execute(ctx, pull.Options{
+				Primary:     f.Primary,
+				ListOptions: pull.ListOptions{Patterns: f.Patterns},
+				Concurrency: f.Concurrency,
+				FetchOnly:   f.FetchOnly,
+			}) {
+				if err != nil {
+					return err
+				}
+				counts[result.Status]++
+				if result.Err != nil {
+					fmt.Printf("%s\t%s\t%s\n", result.Location.Path(), result.Status, result.Err)
+				} else {
+					fmt.Printf("%s\t%s\n", result.Location.Path(), result.Status)
+				}
+			}
+
+			summary := make([]string, 0, 5)
+			for _, status := range []pull.Status{
+				pull.StatusUpdated,
+				pull.StatusUpToDate,
+				pull.StatusDiverged,
+				pull.StatusDirty,
+				pull.StatusNoUpstream,
+				pull.StatusFailed,
+			} {
+				summary = append(summary, fmt.Sprintf("%d %s", counts[status], status))
+			}
+			log.FromContext(ctx).Info(strings.Join(summary, ", "))
+			if n := counts[pull.StatusFailed]; n > 0 {
+				return fmt.Errorf("failed to update %d repositories", n)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&f.Patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	cmd.Flags().BoolVarP(&f.Primary, "primary", "", svc.Flags.Pull.Primary, "Update repositories in just a primary root")
+	cmd.Flags().IntVarP(&f.Concurrency, "concurrency", "j", svc.Flags.Pull.Concurrency, "The number of repositories to update at once")
+	cmd.Flags().BoolVarP(&f.FetchOnly, "fetch-only", "", svc.Flags.Pull.FetchOnly, "Fetch the remotes without fast-forwarding the current branches")
+	return cmd, nil
+}
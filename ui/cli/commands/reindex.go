@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/reindex"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewReindexCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f struct {
+		drop bool
+	}
+	cmd := &cobra.Command{
+		Use:   "reindex [flags]",
+		Short: "Rebuild the index of the local repositories",
+		Long: `Rebuild the index of the local repositories.
+
+It walks all roots and stores the found repositories in the index.
+Once the index is built, "list" and finding repositories by reference
+(e.g. in "delete" and "move") read it instead of walking the roots, and "clone", "create", "fork", "delete"
+and "move" keep it up to date. Stale entries (e.g. removed outside gogh) are
+dropped lazily when they are found missing. Repositories put under the roots
+outside gogh are not listed until the next reindex.
+With --drop, it removes the index and gogh walks the roots again.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			uc := reindex.NewUsecase(svc.WorkspaceService, svc.FinderService, svc.IndexService)
+			if f.drop {
+				uc.Drop()
+				log.FromContext(ctx).Info("Dropped the index")
+				return nil
+			}
+			count, err := uc.Execute(ctx)
+			if err != nil {
+				return fmt.Errorf("rebuilding the index: %w", err)
+			}
+			log.FromContext(ctx).Infof("Indexed %d repositories", count)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&f.drop, "drop", "", false, "Remove the index and walk the roots to find repositories")
+	return cmd, nil
+}
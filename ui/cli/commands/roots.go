@@ -7,6 +7,7 @@ import (
 
 	"github.com/apex/log"
 	"github.com/charmbracelet/huh"
+	"github.com/kyoh86/gogh/v4/app/roots/add"
 	"github.com/kyoh86/gogh/v4/app/service"
 	"github.com/spf13/cobra"
 )
@@ -36,6 +37,10 @@ func RootsListRunE(svc *service.ServiceSet) func(*cobra.Command, []string) error
 			return errors.New("no roots found: you need to set root by `gogh roots add`")
 		}
 		for _, root := range roots {
+			if layout := svc.WorkspaceService.GetRootLayout(root); !layout.IsDefault() {
+				fmt.Printf("%s\t%s\n", root, layout)
+				continue
+			}
 			fmt.Println(root)
 		}
 		return nil
@@ -43,21 +48,35 @@ func RootsListRunE(svc *service.ServiceSet) func(*cobra.Command, []string) error
 }
 
 func NewRootsAddCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
-	var asPrimary bool
+	var f add.Options
 	cmd := &cobra.Command{
 		Use:   "add [flags] <directory>",
 		Short: "Add a directory into the roots",
-		Args:  cobra.ExactArgs(1),
+		Long: `Add a directory into the roots.
+
+By default, repositories are placed in "<root>/<host>/<owner>/<name>".
+With --layout, the directory structure under the root can be changed
+with a template that consists of "{host}", "{owner}" and "{name}" joined by "/".
+For example, "{owner}/{name}" or "{name}".
+When the template omits "{host}" or "{owner}", all repositories in the root
+have the host or owner given by --host or --owner (default: the default host and owner).`,
+		Example: `  gogh roots add ~/Projects
+  gogh roots add --layout "{owner}/{name}" ~/GitHub
+  gogh roots add --layout "{name}" --owner kyoh86 ~/Works`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, rootList []string) error {
 			ctx := cmd.Context()
-			if err := svc.WorkspaceService.AddRoot(rootList[0], asPrimary); err != nil {
+			if err := add.NewUsecase(svc.WorkspaceService, svc.DefaultNameService).Execute(ctx, rootList[0], f); err != nil {
 				return err
 			}
 			log.FromContext(ctx).Infof("Added root: %q", rootList[0])
 			return nil
 		},
 	}
-	cmd.Flags().BoolVarP(&asPrimary, "as-primary", "", false, "Set as primary root")
+	cmd.Flags().BoolVarP(&f.AsPrimary, "as-primary", "", false, "Set as primary root")
+	cmd.Flags().StringVarP(&f.Layout, "layout", "", "", `Layout template of the repositories in the root (default: "{host}/{owner}/{name}")`)
+	cmd.Flags().StringVarP(&f.Host, "host", "", "", "Host of the repositories for the layout without {host}")
+	cmd.Flags().StringVarP(&f.Owner, "owner", "", "", "Owner of the repositories for the layout without {owner}")
 	return cmd, nil
 }
 
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/charmbracelet/huh"
+	"github.com/kyoh86/gogh/v4/app/roots/route"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewRootsRouteCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:     "route",
+		Short:   "Manage routes which choose the root for new repositories",
+		Aliases: []string{"routes"},
+		Long: `Manage routes which choose the root for new repositories.
+
+A route maps a doublestar pattern of "<host>/<owner>/<name>" to a root.
+When a repository is cloned, created, forked or restored from a bundle,
+it is placed in the root of the first route whose pattern matches it.
+If no route matches, it is placed in the primary root.`,
+		RunE: RootsRouteListRunE(svc),
+	}, nil
+}
+
+func NewRootsRouteListCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all of the routes in the order of priority",
+		Args:  cobra.NoArgs,
+		RunE:  RootsRouteListRunE(svc),
+	}, nil
+}
+
+func RootsRouteListRunE(svc *service.ServiceSet) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		for _, r := range route.NewUsecase(svc.WorkspaceService).List(cmd.Context()) {
+			fmt.Printf("%s\t%s\n", r.Pattern, r.Root)
+		}
+		return nil
+	}
+}
+
+func NewRootsRouteAddCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "add [flags] <pattern> <directory>",
+		Short: "Add a route from the pattern to the root",
+		Long: `Add a route from the pattern to the root.
+
+The route is added with the lowest priority.
+The directory must be registered as a root with "gogh roots add".`,
+		Example: `  gogh roots route add "github.com/our-org/**" /Volumes/Secure`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if err := route.NewUsecase(svc.WorkspaceService).Add(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			log.FromContext(ctx).Infof("Added route: %q -> %q", args[0], args[1])
+			return nil
+		},
+	}, nil
+}
+
+func NewRootsRouteRemoveCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "remove [flags] [<pattern>]",
+		Short: "Remove a route",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			uc := route.NewUsecase(svc.WorkspaceService)
+			var selected string
+			if len(args) > 0 {
+				selected = args[0]
+			} else {
+				routes := uc.List(ctx)
+				if len(routes) == 0 {
+					return errors.New("no routes found")
+				}
+				opts := make([]huh.Option[string], 0, len(routes))
+				for _, r := range routes {
+					opts = append(opts, huh.Option[string]{Key: fmt.Sprintf("%s -> %s", r.Pattern, r.Root), Value: r.Pattern})
+				}
+				form := huh.NewForm(huh.NewGroup(
+					huh.NewSelect[string]().
+						Title("Route to remove").
+						Options(opts...).
+						Value(&selected),
+				))
+				if err := form.Run(); err != nil {
+					return err
+				}
+			}
+			if err := uc.Remove(ctx, selected); err != nil {
+				return err
+			}
+			log.FromContext(ctx).Infof("Removed route: %q", selected)
+			return nil
+		},
+	}, nil
+}
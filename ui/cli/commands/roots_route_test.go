@@ -0,0 +1,27 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/commands"
+	"github.com/spf13/cobra"
+)
+
+func TestNewRootsRouteCommands(t *testing.T) {
+	ctx := context.Background()
+	serviceSet := &service.ServiceSet{Flags: &config.Flags{}}
+
+	for name, newCommand := range map[string]func(context.Context, *service.ServiceSet) (*cobra.Command, error){
+		"route":  commands.NewRootsRouteCommand,
+		"list":   commands.NewRootsRouteListCommand,
+		"add":    commands.NewRootsRouteAddCommand,
+		"remove": commands.NewRootsRouteRemoveCommand,
+	} {
+		if _, err := newCommand(ctx, serviceSet); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", name, err)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/kyoh86/gogh/v4/app/list"
 	"github.com/kyoh86/gogh/v4/app/script/invoke"
 	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/flags"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +21,7 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
 	var f struct {
 		allRepositories bool
 		patterns        []string
+		tags            []string
 		file            string
 	}
 	cmd := &cobra.Command{
@@ -32,6 +34,7 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
   invoke-instant --file script.lua .  # Use current directory repository
   invoke-instant --file script.lua --all
   invoke-instant --file script.lua --pattern <pattern>
+  invoke-instant --file script.lua --tag <tag>
 
   It accepts a short notation for each repository
   (for example, "github.com/kyoh86/example") like below.
@@ -65,9 +68,9 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
 			}
 
 			// Get repository list
-			if f.allRepositories || len(f.patterns) > 0 {
+			if f.allRepositories || len(f.patterns) > 0 || len(f.tags) > 0 {
 				if len(refs) > 0 {
-					return errors.New("cannot specify repositories when --all or --pattern flag is set")
+					return errors.New("cannot specify repositories when --all, --pattern or --tag flag is set")
 				}
 
 				for repo, err := range list.NewUsecase(
@@ -76,6 +79,7 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
 				).Execute(ctx, list.Options{ListOptions: list.ListOptions{
 					Limit:    0,
 					Patterns: f.patterns,
+					Tags:     f.tags,
 				}}) {
 					if err != nil {
 						return fmt.Errorf("listing repositories: %w", err)
@@ -84,6 +88,9 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
 				}
 				if len(refs) == 0 {
 					logger := log.FromContext(ctx)
+					if len(f.tags) > 0 {
+						logger = logger.WithField("tags", strings.Join(f.tags, ","))
+					}
 					if len(f.patterns) > 0 {
 						logger = logger.WithField("patterns", strings.Join(f.patterns, "|"))
 						logger.Info(strings.Join([]string{
@@ -130,6 +137,9 @@ func NewScriptInvokeInstantCommand(_ context.Context, svc *service.ServiceSet) (
 	}
 	cmd.Flags().BoolVarP(&f.allRepositories, "all", "", false, "Apply to all repositories in the workspace")
 	cmd.Flags().StringSliceVarP(&f.patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	if err := flags.TagsFlag(cmd, &f.tags, svc); err != nil {
+		return nil, err
+	}
 	cmd.Flags().StringVarP(&f.file, "file", "f", "", "Path to script file to invoke (use '-' for stdin)")
 	if err := cmd.MarkFlagRequired("file"); err != nil {
 		return nil, err
@@ -0,0 +1,39 @@
+# gogh shell integration for bash.
+# Add the following line to ~/.bashrc:
+#
+#   eval "$(gogh shell-init bash)"
+#
+# It provides:
+#   - "gogh cd [<query>...]" to change into a local repository (see "gogh jump")
+#   - changing into the repository after "gogh clone", "gogh create" and "gogh fork"
+#   - "gogh_prompt" to print the repository of the current directory:
+#       PS1='$(gogh_prompt) \$ '
+
+gogh() {
+  case "$1" in
+    cd)
+      shift
+      local __gogh_dir
+      __gogh_dir="$(command gogh jump --print "$@")" && builtin cd -- "$__gogh_dir"
+      ;;
+    clone | get | create | new | fork)
+      local __gogh_file __gogh_dir __gogh_status
+      __gogh_file="$(mktemp)" || return
+      GOGH_CHDIR_FILE="$__gogh_file" command gogh "$@"
+      __gogh_status=$?
+      __gogh_dir="$(cat -- "$__gogh_file")"
+      rm -f -- "$__gogh_file"
+      if [ "$__gogh_status" -eq 0 ] && [ -n "$__gogh_dir" ]; then
+        builtin cd -- "$__gogh_dir" || return
+      fi
+      return "$__gogh_status"
+      ;;
+    *)
+      command gogh "$@"
+      ;;
+  esac
+}
+
+gogh_prompt() {
+  command gogh cwd --prompt 2>/dev/null
+}
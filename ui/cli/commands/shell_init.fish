@@ -0,0 +1,34 @@
+# gogh shell integration for fish.
+# Add the following line to ~/.config/fish/config.fish:
+#
+#   gogh shell-init fish | source
+#
+# It provides:
+#   - "gogh cd [<query>...]" to change into a local repository (see "gogh jump")
+#   - changing into the repository after "gogh clone", "gogh create" and "gogh fork"
+#   - "gogh_prompt" to print the repository of the current directory:
+#       function fish_right_prompt; gogh_prompt; end
+
+function gogh
+    switch "$argv[1]"
+        case cd
+            set -l dir (command gogh jump --print $argv[2..-1]); or return
+            builtin cd -- $dir
+        case clone get create new fork
+            set -l file (mktemp); or return
+            GOGH_CHDIR_FILE=$file command gogh $argv
+            set -l code $status
+            set -l dir (cat -- $file)
+            rm -f -- $file
+            if test $code -eq 0; and test -n "$dir"
+                builtin cd -- $dir; or return
+            end
+            return $code
+        case '*'
+            command gogh $argv
+    end
+end
+
+function gogh_prompt
+    command gogh cwd --prompt 2>/dev/null
+end
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	_ "embed"
+	"os"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed shell_init.bash
+	shellInitBash string
+	//go:embed shell_init.zsh
+	shellInitZsh string
+	//go:embed shell_init.fish
+	shellInitFish string
+)
+
+// chdirFileEnv is the environment variable set by the shell functions of "gogh shell-init"
+// to the file which "clone", "create" and "fork" write the path of the local repository into.
+const chdirFileEnv = "GOGH_CHDIR_FILE"
+
+// writeChdirFile writes the full path of the local repository to the file named by chdirFileEnv
+// to let the shell functions change into it.
+// Failures are just logged, because the repository itself is ready.
+func writeChdirFile(ctx context.Context, svc *service.ServiceSet, refWithAlias string) {
+	file := os.Getenv(chdirFileEnv)
+	if file == "" {
+		return
+	}
+	logger := log.FromContext(ctx).WithField("ref", refWithAlias)
+	ref, err := svc.ReferenceParser.ParseWithAlias(refWithAlias)
+	if err != nil {
+		logger.WithField("error", err).Warn("Failed to parse the reference to change the directory")
+		return
+	}
+	location, err := svc.FinderService.FindByReference(ctx, svc.WorkspaceService, ref.Local())
+	if err != nil {
+		logger.WithField("error", err).Warn("Failed to find the repository to change the directory")
+		return
+	}
+	if err := os.WriteFile(file, []byte(location.FullPath()), 0o600); err != nil {
+		logger.WithField("error", err).Warn("Failed to write the path to change the directory")
+	}
+}
+
+func NewShellInitCommand(_ context.Context, _ *service.ServiceSet) (*cobra.Command, error) {
+	scripts := map[string]string{
+		"bash": shellInitBash,
+		"zsh":  shellInitZsh,
+		"fish": shellInitFish,
+	}
+	cmd := &cobra.Command{
+		Use:   "shell-init <bash|zsh|fish>",
+		Short: "Generate the shell integration script for the specified shell",
+		Long: `Generate the shell integration script for the specified shell.
+It defines a "gogh" shell function wrapping the command to provide:
+  - "gogh cd [<query>...]" to change into a local repository (see "gogh jump")
+  - changing into the repository after "gogh clone", "gogh create" and "gogh fork"
+and a "gogh_prompt" function printing the repository of the current directory for the prompt.`,
+		Example: `  bash:  eval "$(gogh shell-init bash)"     # in ~/.bashrc
+  zsh:   eval "$(gogh shell-init zsh)"      # in ~/.zshrc
+  fish:  gogh shell-init fish | source      # in ~/.config/fish/config.fish`,
+		ValidArgs: []cobra.Completion{"bash", "zsh", "fish"},
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := cmd.OutOrStdout().Write([]byte(scripts[args[0]]))
+			return err
+		},
+	}
+	return cmd, nil
+}
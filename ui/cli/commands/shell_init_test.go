@@ -0,0 +1,55 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/commands"
+)
+
+func TestNewShellInitCommand(t *testing.T) {
+	// Setup
+	ctx := context.Background()
+	serviceSet := &service.ServiceSet{}
+
+	// Execute
+	_, err := commands.NewShellInitCommand(ctx, serviceSet)
+	// Verify no error occurs
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestShellInitCommand_Execute(t *testing.T) {
+	ctx := context.Background()
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		cmd, err := commands.NewShellInitCommand(ctx, &service.ServiceSet{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{shell})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected no error for %s, got %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), "gogh_prompt") {
+			t.Errorf("Expected the script for %s to define gogh_prompt, got %q", shell, buf.String())
+		}
+	}
+
+	cmd, err := commands.NewShellInitCommand(ctx, &service.ServiceSet{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"powershell"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+}
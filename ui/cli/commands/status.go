@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/app/status"
+	"github.com/spf13/cobra"
+)
+
+// NewStatusCommand creates a new command to report the status of local repositories.
+func NewStatusCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	var f config.StatusFlags
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of local repositories",
+		Long: `Show the current branch, the commits ahead/behind the upstream, uncommitted changes,
+stashes, other branches with unpushed commits and branches without upstream of each local repository.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			printer, err := status.NewPrinter(cmd.OutOrStdout(), f.Format)
+			if err != nil {
+				return err
+			}
+			for result, err := range status.NewUsecase(
+				svc.WorkspaceService,
+				svc.FinderService,
+				svc.GitService,
+			).Execute(ctx, status.Options{
+				Primary:     f.Primary,
+				ListOptions: status.ListOptions{Patterns: f.Patterns},
+				OnlyDirty:   f.OnlyDirty,
+			}) {
+				if err != nil {
+					return err
+				}
+				if err := printer.Print(*result); err != nil {
+					return err
+				}
+			}
+			return printer.Close()
+		},
+	}
+	cmd.Flags().StringSliceVarP(&f.Patterns, "pattern", "p", nil, "Patterns for selecting repositories")
+	cmd.Flags().BoolVarP(&f.Primary, "primary", "", svc.Flags.Status.Primary, "Show repositories in just a primary root")
+	cmd.Flags().BoolVarP(&f.OnlyDirty, "only-dirty", "", svc.Flags.Status.OnlyDirty, "Show only repositories which have uncommitted changes, untracked files, unpushed commits, stashes or branches without upstream")
+	cmd.Flags().StringVarP(&f.Format, "format", "f", svc.Flags.Status.Format, `Print each status in a given format, where [format] can be one of "table" or "json"`)
+	if err := cmd.RegisterFlagCompletionFunc("format", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json"}, cobra.ShellCompDirectiveDefault
+	}); err != nil {
+		return nil, fmt.Errorf("registering completion function for format flag: %w", err)
+	}
+	return cmd, nil
+}
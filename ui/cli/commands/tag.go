@@ -0,0 +1,15 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+func NewTagCommand(_ context.Context, _ *service.ServiceSet) (*cobra.Command, error) {
+	return &cobra.Command{
+		Use:   "tag",
+		Short: "Manage tags of the local repositories",
+	}, nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyoh86/gogh/v4/app/cwd"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/app/tag/list"
+	"github.com/spf13/cobra"
+)
+
+func NewTagListCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:   "list [flags] [<[[<host>/]<owner>/]<name>|.>]",
+		Short: "List tags of the repositories",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			var refs string
+			if len(args) > 0 {
+				refs = args[0]
+			}
+			if refs == "." {
+				repo, err := cwd.NewUsecase(svc.WorkspaceService, svc.FinderService).Execute(ctx)
+				if err != nil {
+					return fmt.Errorf("finding repository from current directory: %w", err)
+				}
+				refs = repo.Ref().String()
+			}
+			for entry, err := range list.NewUsecase(svc.TagService, svc.ReferenceParser).Execute(ctx, refs) {
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", entry.Reference, strings.Join(entry.Tags, ","))
+			}
+			return nil
+		},
+	}
+	return cmd, nil
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/kyoh86/gogh/v4/app/cwd"
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/app/tag/remove"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/spf13/cobra"
+)
+
+func NewTagRemoveCommand(_ context.Context, svc *service.ServiceSet) (*cobra.Command, error) {
+	cmd := &cobra.Command{
+		Use:     "remove [flags] <[[<host>/]<owner>/]<name>|.> <tag>...",
+		Aliases: []string{"rm", "del", "delete"},
+		Short:   "Remove tags from a repository",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completion.Tags(cmd.Context(), svc, toComplete)
+		},
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			refs := args[0]
+			if refs == "." {
+				repo, err := cwd.NewUsecase(svc.WorkspaceService, svc.FinderService).Execute(ctx)
+				if err != nil {
+					return fmt.Errorf("finding repository from current directory: %w", err)
+				}
+				refs = repo.Ref().String()
+			}
+			ref, err := remove.NewUsecase(svc.TagService, svc.ReferenceParser).Execute(ctx, refs, args[1:]...)
+			if err != nil {
+				return fmt.Errorf("removing tags: %w", err)
+			}
+			log.FromContext(ctx).WithField("tags", args[1:]).Infof("Removed tags from %s", ref)
+			return nil
+		},
+	}
+	return cmd, nil
+}
@@ -0,0 +1,25 @@
+package completion
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/spf13/cobra"
+)
+
+// HostAliases completes the host aliases configured in the default names (e.g. "gh:")
+func HostAliases(_ context.Context, svc *service.ServiceSet, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	aliases := svc.DefaultNameService.GetHostAliases()
+	completions := make([]cobra.Completion, 0, len(aliases))
+	for _, alias := range slices.Sorted(maps.Keys(aliases)) {
+		prefix := alias + ":"
+		if !strings.HasPrefix(prefix, toComplete) {
+			continue
+		}
+		completions = append(completions, prefix+"\t"+aliases[alias])
+	}
+	return completions, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
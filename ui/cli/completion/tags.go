@@ -0,0 +1,28 @@
+package completion
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	taglist "github.com/kyoh86/gogh/v4/app/tag/list"
+	"github.com/spf13/cobra"
+)
+
+func Tags(ctx context.Context, svc *service.ServiceSet, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	completions := make([]cobra.Completion, 0)
+	for entry, err := range taglist.NewUsecase(svc.TagService, svc.ReferenceParser).Execute(ctx, "") {
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		for _, tag := range entry.Tags {
+			if !strings.HasPrefix(tag, toComplete) || slices.Contains(completions, tag) {
+				continue
+			}
+			completions = append(completions, tag)
+		}
+	}
+	slices.Sort(completions)
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
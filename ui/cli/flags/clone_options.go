@@ -0,0 +1,36 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/app/clone/try"
+	"github.com/kyoh86/gogh/v4/app/config"
+	"github.com/spf13/cobra"
+)
+
+// CloneOptionFlags adds flags to the command for the local clone operation.
+// If withBranch is true, it also adds the "branch" flag to check out a branch other than the default one.
+func CloneOptionFlags(cmd *cobra.Command, f *config.CloneOptionFlags, defaultValue config.CloneOptionFlags, withBranch bool) error {
+	cmd.Flags().IntVarP(&f.Depth, "depth", "", defaultValue.Depth, "Create a shallow clone with a history truncated to the specified number of commits")
+	cmd.Flags().BoolVarP(&f.SingleBranch, "single-branch", "", defaultValue.SingleBranch, "Clone only the history of a single branch (the default branch, or the one specified by --branch)")
+	cmd.Flags().StringVarP(&f.Filter, "filter", "", defaultValue.Filter, `Filter for the partial clone (e.g. "blob:none"); ignored if the git backend does not support it`)
+	cmd.Flags().BoolVarP(&f.RecurseSubmodules, "recurse-submodules", "", defaultValue.RecurseSubmodules, "Clone the submodules recursively")
+	if err := cmd.RegisterFlagCompletionFunc("filter", cobra.FixedCompletions([]string{"blob:none", "tree:0"}, cobra.ShellCompDirectiveNoFileComp)); err != nil {
+		return fmt.Errorf("registering completion function for filter flag: %w", err)
+	}
+	if withBranch {
+		cmd.Flags().StringVarP(&f.Branch, "branch", "", "", "Check out the branch instead of the default branch of the remote")
+	}
+	return nil
+}
+
+// CloneOptions converts the flags to the options for the local clone operation.
+func CloneOptions(f config.CloneOptionFlags) try.CloneOptions {
+	return try.CloneOptions{
+		Depth:             f.Depth,
+		Branch:            f.Branch,
+		SingleBranch:      f.SingleBranch,
+		Filter:            f.Filter,
+		RecurseSubmodules: f.RecurseSubmodules,
+	}
+}
@@ -0,0 +1,20 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/kyoh86/gogh/v4/app/service"
+	"github.com/kyoh86/gogh/v4/ui/cli/completion"
+	"github.com/spf13/cobra"
+)
+
+// TagsFlag adds the flag to select repositories by the tags (see "gogh tag")
+func TagsFlag(cmd *cobra.Command, tags *[]string, svc *service.ServiceSet) error {
+	cmd.Flags().StringSliceVarP(tags, "tag", "t", nil, "Tags for selecting repositories (any of them)")
+	if err := cmd.RegisterFlagCompletionFunc("tag", func(cmd *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completion.Tags(cmd.Context(), svc, toComplete)
+	}); err != nil {
+		return fmt.Errorf("registering completion function for tag flag: %w", err)
+	}
+	return nil
+}